@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/custard-technology/abakcus/backend/internal/auth"
+	"github.com/custard-technology/abakcus/backend/internal/config"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+const grantAdminUsage = "usage: api grant-admin EMAIL"
+
+// runGrantAdmin implements the grant-admin command, which makes an existing
+// account an admin without starting the server:
+//
+//	api grant-admin ops@example.com
+//
+// Admins can grant the role to others through PUT /admin/users/{email}/role;
+// the command creates the first one. The role applies from the next login.
+func runGrantAdmin(ctx context.Context, args []string) error {
+	if len(args) != 1 {
+		return errors.New(grantAdminUsage)
+	}
+
+	storageCfg, err := config.LoadStorageConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if storageCfg.Backend == config.StorageMemory {
+		return errors.New("grant-admin needs persistent storage; STORAGE=memory keeps accounts in the server process")
+	}
+
+	repos, err := openRepositories(ctx, storageCfg, defaultCurrency())
+	if err != nil {
+		return err
+	}
+	defer repos.close()
+
+	// no tokens are issued, so the service needs no token manager
+	users := service.NewAuthService(repos.user, repos.business, nil)
+	user, err := users.SetUserRole(ctx, args[0], auth.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s is now an admin; the role applies from the next login\n", user.Email)
+	return nil
+}
@@ -6,21 +6,25 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
+	_ "time/tzdata" // business time zones are validated against the IANA database
 
+	"github.com/custard-technology/abakcus/backend/internal/auth"
 	"github.com/custard-technology/abakcus/backend/internal/config"
 	"github.com/custard-technology/abakcus/backend/internal/handler"
-	mongopkg "github.com/custard-technology/abakcus/backend/internal/repository/mongo"
 	"github.com/custard-technology/abakcus/backend/internal/service"
+	"github.com/custard-technology/abakcus/backend/internal/storage"
 	"github.com/joho/godotenv"
 )
 
 func cors(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,DELETE,OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type,X-Business-ID")
+		w.Header().Set("Access-Control-Allow-Methods", "GET,POST,PUT,PATCH,DELETE,OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization,If-Match,X-Request-ID")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Next-Cursor,ETag,X-Request-ID")
 		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
 			return
@@ -29,6 +33,15 @@ func cors(h http.Handler) http.Handler {
 	})
 }
 
+// defaultCurrency is the currency assumed for legacy prices and backfilled
+// businesses.
+func defaultCurrency() string {
+	if currency := os.Getenv("DEFAULT_CURRENCY"); currency != "" {
+		return currency
+	}
+	return service.DefaultBusinessCurrency
+}
+
 func main() {
 	if os.Getenv("ENV") != "production" {
 		err := godotenv.Load()
@@ -37,50 +50,217 @@ func main() {
 		}
 	}
 
-	cfg, err := config.LoadMongoConfig()
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "grant-admin" {
+		if err := runGrantAdmin(context.Background(), os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	storageCfg, err := config.LoadStorageConfig()
+	if err != nil {
+		log.Fatalf("configuration error: %v", err)
+	}
+
+	authCfg, err := config.LoadAuthConfig()
+	if err != nil {
+		log.Fatalf("configuration error: %v", err)
+	}
+	tokens, err := auth.NewTokenManager(authCfg.Secret, authCfg.TokenTTL)
+	if err != nil {
+		log.Fatalf("configuration error: %v", err)
+	}
+
+	retentionCfg, err := config.LoadRetentionConfig()
 	if err != nil {
 		log.Fatalf("configuration error: %v", err)
 	}
 
-	log.Printf("connecting to MongoDB at %s", cfg.URI)
+	publicCfg, err := config.LoadPublicConfig()
+	if err != nil {
+		log.Fatalf("configuration error: %v", err)
+	}
+
+	mediaCfg, err := config.LoadMediaConfig()
+	if err != nil {
+		log.Fatalf("configuration error: %v", err)
+	}
+	var store storage.Storage
+	var localStore *storage.LocalStorage
+	switch mediaCfg.Storage {
+	case config.MediaStorageS3:
+		store, err = storage.NewS3Storage(storage.S3Options{
+			Endpoint:  mediaCfg.S3Endpoint,
+			Region:    mediaCfg.S3Region,
+			Bucket:    mediaCfg.S3Bucket,
+			AccessKey: mediaCfg.S3AccessKey,
+			SecretKey: mediaCfg.S3SecretKey,
+			UseSSL:    mediaCfg.S3UseSSL,
+			PublicURL: mediaCfg.BaseURL,
+		})
+	default:
+		localStore, err = storage.NewLocalStorage(mediaCfg.Dir, mediaCfg.BaseURL)
+		store = localStore
+	}
+	if err != nil {
+		log.Fatalf("media storage error: %v", err)
+	}
+
 	ctx := context.Background()
-	client, err := mongopkg.NewClient(ctx, cfg)
+	repos, err := openRepositories(ctx, storageCfg, defaultCurrency())
 	if err != nil {
-		log.Fatalf("MongoDB connection failed: %v", err)
+		log.Fatal(err)
 	}
-	defer func() {
-		if err := client.Disconnect(context.Background()); err != nil {
-			log.Printf("error disconnecting MongoDB client: %v", err)
-		}
-	}()
-	log.Printf("MongoDB connection successful")
+	defer repos.close()
+
+	businessSvc := service.NewBusinessService(repos.business)
+	businessHandler := handler.NewBusinessHandler(businessSvc)
+
+	authSvc := service.NewAuthService(repos.user, repos.business, tokens)
+	authHandler := handler.NewAuthHandler(authSvc)
 
-	menuRepo := mongopkg.NewMenuRepository(client, cfg.Database)
-	menuSvc := service.NewMenuService(menuRepo)
+	auditSvc := service.NewAuditService(repos.audit)
+	auditHandler := handler.NewAuditHandler(auditSvc)
+
+	menuSvc := service.NewMenuService(repos.menu, repos.business, auditSvc)
 	menuHandler := handler.NewMenuHandler(menuSvc)
 
+	itemSvc := service.NewMenuItemService(repos.menu, repos.section, repos.item, repos.business, auditSvc)
+	itemHandler := handler.NewMenuItemHandler(itemSvc)
+	imageSvc := service.NewMenuItemImageService(repos.menu, repos.item, store, auditSvc)
+	imageHandler := handler.NewMenuItemImageHandler(imageSvc)
+	sectionSvc := service.NewMenuSectionService(repos.menu, repos.section, repos.item, auditSvc)
+	sectionHandler := handler.NewMenuSectionHandler(sectionSvc)
+
+	versionSvc := service.NewMenuVersionService(repos.menu, repos.section, repos.item, repos.version, auditSvc)
+	versionHandler := handler.NewMenuVersionHandler(versionSvc)
+
+	publicSvc := service.NewPublicMenuService(repos.menu, repos.version, repos.business)
+	publicHandler := handler.NewPublicMenuHandler(publicSvc)
+	qrSvc := service.NewMenuQRService(repos.menu, publicCfg.MenuBaseURL)
+	qrHandler := handler.NewMenuQRHandler(qrSvc)
+
+	purgeSvc := service.NewMenuPurgeService(repos.menu, repos.section, repos.item, repos.version, store, auditSvc, retentionCfg.Retention)
+	adminHandler := handler.NewAdminHandler(purgeSvc, authSvc)
+
+	jobCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go purgeSvc.Run(jobCtx, retentionCfg.PurgeInterval)
+
 	mux := http.NewServeMux()
-	mux.HandleFunc("/menus", func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.Path == "/menus" {
+	mux.HandleFunc("/auth/register", authHandler.Register)
+	mux.HandleFunc("/auth/login", authHandler.Login)
+	mux.HandleFunc("/public/menus/", publicHandler.GetPublicMenu)
+	if localStore != nil {
+		mux.Handle("/media/", http.StripPrefix("/media/", localStore.Handler()))
+	}
+
+	mux.Handle("/business", handler.RequireAuth(tokens, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			businessHandler.GetBusiness(w, r)
+		} else if r.Method == http.MethodPut {
+			businessHandler.UpdateBusiness(w, r)
+		} else {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})))
+
+	mux.Handle("/menus", handler.RequireAuth(tokens, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			menuHandler.CreateMenu(w, r)
+		} else if r.Method == http.MethodGet {
+			menuHandler.ListMenus(w, r)
+		} else {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	})))
+
+	mux.Handle("/menus/", handler.RequireAuth(tokens, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/menus/"), "/"), "/")
+		switch {
+		case len(parts) == 1:
+			if r.Method == http.MethodGet && r.URL.Query().Get("expand") == "tree" {
+				sectionHandler.GetMenuTree(w, r)
+			} else if r.Method == http.MethodGet {
+				menuHandler.GetMenu(w, r)
+			} else if r.Method == http.MethodPut {
+				menuHandler.UpdateMenu(w, r)
+			} else if r.Method == http.MethodPatch {
+				menuHandler.PatchMenu(w, r)
+			} else if r.Method == http.MethodDelete {
+				menuHandler.DeleteMenu(w, r)
+			} else {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case len(parts) == 2 && parts[1] == "items":
 			if r.Method == http.MethodPost {
-				menuHandler.CreateMenu(w, r)
+				itemHandler.CreateMenuItem(w, r)
 			} else if r.Method == http.MethodGet {
-				menuHandler.ListMenus(w, r)
+				itemHandler.ListMenuItems(w, r)
 			} else {
 				w.WriteHeader(http.StatusMethodNotAllowed)
 			}
-		} else {
+		case len(parts) == 3 && parts[1] == "items":
 			if r.Method == http.MethodGet {
-				menuHandler.GetMenu(w, r)
+				itemHandler.GetMenuItem(w, r)
 			} else if r.Method == http.MethodPut {
-				menuHandler.UpdateMenu(w, r)
+				itemHandler.UpdateMenuItem(w, r)
 			} else if r.Method == http.MethodDelete {
-				menuHandler.DeleteMenu(w, r)
+				itemHandler.DeleteMenuItem(w, r)
+			} else {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case len(parts) == 4 && parts[1] == "items" && parts[3] == "price":
+			itemHandler.PriceMenuItem(w, r)
+		case len(parts) == 4 && parts[1] == "items" && parts[3] == "image":
+			imageHandler.UploadMenuItemImage(w, r)
+		case len(parts) == 2 && parts[1] == "restore":
+			menuHandler.RestoreMenu(w, r)
+		case len(parts) == 2 && parts[1] == "qr":
+			qrHandler.GetMenuQR(w, r)
+		case len(parts) == 2 && parts[1] == "publish":
+			versionHandler.PublishMenu(w, r)
+		case len(parts) == 2 && parts[1] == "versions":
+			versionHandler.ListMenuVersions(w, r)
+		case len(parts) == 3 && parts[1] == "versions":
+			versionHandler.GetMenuVersion(w, r)
+		case len(parts) == 4 && parts[1] == "versions" && parts[3] == "rollback":
+			versionHandler.RollbackMenu(w, r)
+		case len(parts) == 2 && parts[1] == "order":
+			sectionHandler.ReorderMenu(w, r)
+		case len(parts) == 2 && parts[1] == "sections":
+			if r.Method == http.MethodPost {
+				sectionHandler.CreateMenuSection(w, r)
+			} else if r.Method == http.MethodGet {
+				sectionHandler.ListMenuSections(w, r)
 			} else {
 				w.WriteHeader(http.StatusMethodNotAllowed)
 			}
+		case len(parts) == 3 && parts[1] == "sections":
+			if r.Method == http.MethodGet {
+				sectionHandler.GetMenuSection(w, r)
+			} else if r.Method == http.MethodPut {
+				sectionHandler.UpdateMenuSection(w, r)
+			} else if r.Method == http.MethodDelete {
+				sectionHandler.DeleteMenuSection(w, r)
+			} else {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		default:
+			http.NotFound(w, r)
 		}
-	})
+	})))
+
+	mux.Handle("/audit", handler.RequireAuth(tokens, http.HandlerFunc(auditHandler.ListAuditEvents)))
+
+	mux.Handle("/admin/menus/", handler.RequireAuth(tokens, handler.RequireRole(auth.RoleAdmin, http.HandlerFunc(adminHandler.PurgeMenu))))
+	mux.Handle("/admin/users/", handler.RequireAuth(tokens, handler.RequireRole(auth.RoleAdmin, http.HandlerFunc(adminHandler.SetUserRole))))
 
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -95,7 +275,7 @@ func main() {
 
 	server := &http.Server{
 		Addr:         ":" + port,
-		Handler:      cors(mux),
+		Handler:      handler.RequestID(cors(mux)),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -112,6 +292,7 @@ func main() {
 	signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
 	<-sig
 	log.Printf("shutting down server")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
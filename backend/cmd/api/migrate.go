@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/config"
+	mongopkg "github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+const migrateUsage = "usage: api migrate up|status"
+
+// runMigrate implements the migrate command, which manages the MongoDB
+// schema without starting the server:
+//
+//	api migrate up      apply the pending migrations
+//	api migrate status  list every migration and when it was applied
+//
+// The server applies pending migrations on startup as well; the command lets
+// them run ahead of a rollout and shows where a database stands.
+func runMigrate(ctx context.Context, args []string) error {
+	if len(args) != 1 || (args[0] != "up" && args[0] != "status") {
+		return errors.New(migrateUsage)
+	}
+
+	storageCfg, err := config.LoadStorageConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if storageCfg.Backend != config.StorageMongo {
+		return fmt.Errorf("migrate manages MongoDB storage; STORAGE=%s needs no migrate command", storageCfg.Backend)
+	}
+	mongoCfg, err := config.LoadMongoConfig()
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+
+	client, err := mongopkg.NewClient(ctx, mongoCfg)
+	if err != nil {
+		return fmt.Errorf("MongoDB connection failed: %w", err)
+	}
+	defer disconnectMongo(client)
+
+	migrator, err := mongopkg.NewMigrator(client, mongoCfg.Database, mongoMigrations(defaultCurrency()))
+	if err != nil {
+		return err
+	}
+
+	if args[0] == "up" {
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			fmt.Printf("applied %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+		return nil
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+	for _, s := range statuses {
+		applied := "pending"
+		if s.AppliedAt != nil {
+			applied = s.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Version, s.Name, applied)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/custard-technology/abakcus/backend/internal/config"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	mongopkg "github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/custard-technology/abakcus/backend/internal/repository/sqldb"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+// repositories are the stores the services are built on, from whichever
+// backend STORAGE selects.
+type repositories struct {
+	business mongopkg.BusinessRepositoryI
+	user     mongopkg.UserRepositoryI
+	audit    mongopkg.AuditRepositoryI
+	menu     mongopkg.MenuRepositoryI
+	section  mongopkg.MenuSectionRepositoryI
+	item     mongopkg.MenuItemRepositoryI
+	version  mongopkg.MenuVersionRepositoryI
+
+	close func()
+}
+
+// openRepositories connects to the backend in cfg and brings its data up to
+// date. currency is assumed for legacy prices and backfilled businesses.
+func openRepositories(ctx context.Context, cfg config.StorageConfig, currency string) (*repositories, error) {
+	switch cfg.Backend {
+	case config.StorageMemory:
+		log.Printf("using in-memory storage; data is lost when the server stops")
+		return &repositories{
+			business: memory.NewBusinessRepository(),
+			user:     memory.NewUserRepository(),
+			audit:    memory.NewAuditRepository(),
+			menu:     memory.NewMenuRepository(),
+			section:  memory.NewMenuSectionRepository(),
+			item:     memory.NewMenuItemRepository(),
+			version:  memory.NewMenuVersionRepository(),
+			close:    func() {},
+		}, nil
+	case config.StorageSQLite:
+		return openSQL(ctx, sqldb.SQLite, cfg.DSN)
+	case config.StoragePostgres:
+		return openSQL(ctx, sqldb.Postgres, cfg.DSN)
+	}
+
+	mongoCfg, err := config.LoadMongoConfig()
+	if err != nil {
+		return nil, fmt.Errorf("configuration error: %w", err)
+	}
+
+	log.Printf("connecting to MongoDB at %s", mongoCfg.URI)
+	client, err := mongopkg.NewClient(ctx, mongoCfg)
+	if err != nil {
+		return nil, fmt.Errorf("MongoDB connection failed: %w", err)
+	}
+	log.Printf("MongoDB connection successful")
+
+	repos, err := prepareMongo(ctx, client, mongoCfg, currency)
+	if err != nil {
+		disconnectMongo(client)
+		return nil, err
+	}
+	repos.close = func() { disconnectMongo(client) }
+	return repos, nil
+}
+
+// disconnectMongo closes client, logging rather than returning a failure
+// since the caller is already shutting down or failing.
+func disconnectMongo(client *mongo.Client) {
+	if err := client.Disconnect(context.Background()); err != nil {
+		log.Printf("error disconnecting MongoDB client: %v", err)
+	}
+}
+
+// openSQL connects to a SQL database and applies the schema migrations it
+// has not seen yet.
+func openSQL(ctx context.Context, dialect sqldb.Dialect, dsn string) (*repositories, error) {
+	log.Printf("opening %s database", dialect)
+	db, err := sqldb.Open(ctx, dialect, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("%s connection failed: %w", dialect, err)
+	}
+
+	applied, err := sqldb.Migrate(ctx, db)
+	if err != nil {
+		closeSQL(db, dialect)
+		return nil, fmt.Errorf("%s migration failed: %w", dialect, err)
+	}
+	if applied > 0 {
+		log.Printf("applied %d schema migrations", applied)
+	}
+
+	return &repositories{
+		business: sqldb.NewBusinessRepository(db),
+		user:     sqldb.NewUserRepository(db),
+		audit:    sqldb.NewAuditRepository(db),
+		menu:     sqldb.NewMenuRepository(db),
+		section:  sqldb.NewMenuSectionRepository(db),
+		item:     sqldb.NewMenuItemRepository(db),
+		version:  sqldb.NewMenuVersionRepository(db),
+		close:    func() { closeSQL(db, dialect) },
+	}, nil
+}
+
+// closeSQL closes db, logging a failure like disconnectMongo does.
+func closeSQL(db *sqldb.DB, dialect sqldb.Dialect) {
+	if err := db.Close(); err != nil {
+		log.Printf("error closing %s database: %v", dialect, err)
+	}
+}
+
+// mongoMigrations is the schema history of the MongoDB database. currency
+// is assumed for legacy prices and backfilled businesses.
+func mongoMigrations(currency string) []mongopkg.Migration {
+	return mongopkg.Migrations(models.Business{
+		Name:            service.DefaultBusinessName,
+		Timezone:        service.DefaultBusinessTimezone,
+		DefaultCurrency: currency,
+		Locale:          service.DefaultBusinessLocale,
+	}, publishUnversionedMenus)
+}
+
+// publishUnversionedMenus publishes menus created before versioning as their
+// version 1, through the same service the API uses.
+func publishUnversionedMenus(ctx context.Context, db *mongo.Database, menus []models.Menu) error {
+	client, dbName := db.Client(), db.Name()
+	versionSvc := service.NewMenuVersionService(
+		mongopkg.NewMenuRepository(client, dbName),
+		mongopkg.NewMenuSectionRepository(client, dbName),
+		mongopkg.NewMenuItemRepository(client, dbName),
+		mongopkg.NewMenuVersionRepository(client, dbName),
+		service.NewAuditService(mongopkg.NewAuditRepository(client, dbName)))
+
+	published, err := versionSvc.PublishUnversionedMenus(ctx, menus)
+	if published > 0 {
+		log.Printf("published %d menus created before versioning", published)
+	}
+	return err
+}
+
+// prepareMongo applies the pending schema migrations and creates the MongoDB
+// repositories, which give each call cfg.OperationTimeout.
+func prepareMongo(ctx context.Context, client *mongo.Client, cfg config.MongoConfig, currency string) (*repositories, error) {
+	dbName := cfg.Database
+	migrator, err := mongopkg.NewMigrator(client, dbName, mongoMigrations(currency))
+	if err != nil {
+		return nil, err
+	}
+	applied, err := migrator.Up(ctx)
+	for _, m := range applied {
+		log.Printf("applied migration %d_%s", m.Version, m.Name)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("MongoDB migration failed: %w", err)
+	}
+
+	business := mongopkg.NewBusinessRepository(client, dbName)
+	user := mongopkg.NewUserRepository(client, dbName)
+	audit := mongopkg.NewAuditRepository(client, dbName)
+	menu := mongopkg.NewMenuRepository(client, dbName)
+	section := mongopkg.NewMenuSectionRepository(client, dbName)
+	item := mongopkg.NewMenuItemRepository(client, dbName)
+	version := mongopkg.NewMenuVersionRepository(client, dbName)
+	for _, repo := range []interface{ SetOperationTimeout(time.Duration) }{business, user, audit, menu, section, item, version} {
+		repo.SetOperationTimeout(cfg.OperationTimeout)
+	}
+
+	return &repositories{
+		business: business,
+		user:     user,
+		audit:    audit,
+		menu:     menu,
+		section:  section,
+		item:     item,
+		version:  version,
+	}, nil
+}
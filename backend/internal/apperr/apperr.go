@@ -0,0 +1,121 @@
+// Package apperr defines the error kinds shared by the repository, service
+// and handler layers. Lower layers return errors of a kind, and the HTTP
+// layer picks the status code from the kind alone, never from the message.
+//
+// Kinds are sentinels for errors.Is:
+//
+//	if errors.Is(err, apperr.ErrNotFound) { ... }
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Kind classifies an error by how the caller should react to it.
+type Kind string
+
+func (k Kind) Error() string { return string(k) }
+
+// Error kinds.
+const (
+	// ErrNotFound: the entity does not exist, or belongs to another business.
+	ErrNotFound Kind = "not found"
+	// ErrValidation: the input is invalid; resending it unchanged will fail.
+	ErrValidation Kind = "validation failed"
+	// ErrConflict: the input clashes with the current state, such as a
+	// duplicate slug or a section that still has items.
+	ErrConflict Kind = "conflict"
+	// ErrForbidden: the caller is known but may not do this.
+	ErrForbidden Kind = "forbidden"
+	// ErrUnauthorized: the caller could not be authenticated.
+	ErrUnauthorized Kind = "unauthorized"
+	// ErrPreconditionFailed: a condition the caller set, such as an
+	// expected revision, no longer holds.
+	ErrPreconditionFailed Kind = "precondition failed"
+	// ErrInvalidFields: fields of a request body are invalid; the error
+	// lists every one of them rather than stopping at the first.
+	ErrInvalidFields Kind = "invalid fields"
+)
+
+// Error is an error of a Kind. Field names the offending input field, in its
+// JSON form, for validation errors about a single field; Fields lists the
+// fields of an ErrInvalidFields error.
+type Error struct {
+	Kind    Kind
+	Field   string
+	Message string
+	Fields  []FieldError
+}
+
+// FieldError is an invalid input field and why it is invalid.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *Error) Error() string { return e.Message }
+
+// Is makes errors.Is(err, kind) report whether err is of kind.
+func (e *Error) Is(target error) bool {
+	kind, ok := target.(Kind)
+	return ok && kind == e.Kind
+}
+
+// New returns an error of kind with message.
+func New(kind Kind, message string) error {
+	return &Error{Kind: kind, Message: message}
+}
+
+// NotFound returns an ErrNotFound error for entity, e.g. "menu section".
+func NotFound(entity string) error {
+	return &Error{Kind: ErrNotFound, Message: entity + " not found"}
+}
+
+// Invalid returns an ErrValidation error about field. field may be empty
+// when the message is not about a single field.
+func Invalid(field, message string) error {
+	return &Error{Kind: ErrValidation, Field: field, Message: message}
+}
+
+// Invalidf is Invalid with a formatted message.
+func Invalidf(field, format string, args ...any) error {
+	return &Error{Kind: ErrValidation, Field: field, Message: fmt.Sprintf(format, args...)}
+}
+
+// Required returns the ErrValidation error for a missing field.
+func Required(field string) error {
+	return Invalid(field, field+" is required")
+}
+
+// Conflict returns an ErrConflict error with message.
+func Conflict(message string) error {
+	return &Error{Kind: ErrConflict, Message: message}
+}
+
+// InvalidFields returns an ErrInvalidFields error listing fields, which must
+// not be empty. Its message joins the messages of the fields.
+func InvalidFields(fields []FieldError) error {
+	messages := make([]string, len(fields))
+	for i, f := range fields {
+		messages[i] = f.Message
+	}
+	return &Error{Kind: ErrInvalidFields, Message: strings.Join(messages, "; "), Fields: fields}
+}
+
+// FieldsOf returns the invalid fields err is about: the Fields of an
+// ErrInvalidFields error, the Field of a validation error, or nil.
+func FieldsOf(err error) []FieldError {
+	var e *Error
+	if !errors.As(err, &e) {
+		return nil
+	}
+	if len(e.Fields) > 0 {
+		return e.Fields
+	}
+	if e.Field != "" {
+		return []FieldError{{Field: e.Field, Message: e.Message}}
+	}
+	return nil
+}
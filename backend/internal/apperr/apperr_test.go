@@ -0,0 +1,55 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestKinds(t *testing.T) {
+	tests := []struct {
+		err  error
+		kind Kind
+		msg  string
+	}{
+		{NotFound("menu section"), ErrNotFound, "menu section not found"},
+		{Required("menu_id"), ErrValidation, "menu_id is required"},
+		{Invalidf("limit", "limit must be between 1 and %d", 100), ErrValidation, "limit must be between 1 and 100"},
+		{Conflict("menu slug already exists"), ErrConflict, "menu slug already exists"},
+		{New(ErrPreconditionFailed, "menu has been modified"), ErrPreconditionFailed, "menu has been modified"},
+	}
+	for _, tt := range tests {
+		wrapped := fmt.Errorf("context: %w", tt.err)
+		if !errors.Is(wrapped, tt.kind) {
+			t.Errorf("%q: expected kind %q", tt.msg, tt.kind)
+		}
+		if errors.Is(wrapped, ErrForbidden) {
+			t.Errorf("%q: unexpectedly of kind %q", tt.msg, ErrForbidden)
+		}
+		if tt.err.Error() != tt.msg {
+			t.Errorf("expected message %q, got %q", tt.msg, tt.err.Error())
+		}
+	}
+}
+
+func TestFieldsOf(t *testing.T) {
+	got := FieldsOf(fmt.Errorf("menu item price: %w", Required("currency")))
+	if len(got) != 1 || got[0].Field != "currency" || got[0].Message != "currency is required" {
+		t.Errorf("expected the currency field, got %+v", got)
+	}
+
+	err := InvalidFields([]FieldError{{"name", "name is required"}, {"slug", "slug must be lowercase"}})
+	if !errors.Is(err, ErrInvalidFields) || err.Error() != "name is required; slug must be lowercase" {
+		t.Errorf("unexpected error %v", err)
+	}
+	if got := FieldsOf(err); len(got) != 2 || got[1].Field != "slug" {
+		t.Errorf("expected both fields, got %+v", got)
+	}
+
+	if got := FieldsOf(errors.New("plain")); got != nil {
+		t.Errorf("expected no fields, got %+v", got)
+	}
+	if got := FieldsOf(NotFound("menu")); got != nil {
+		t.Errorf("expected no fields, got %+v", got)
+	}
+}
@@ -0,0 +1,31 @@
+package auth
+
+import "context"
+
+// Roles carried in tokens. Owners manage their business; admins operate the
+// platform and may use maintenance endpoints.
+const (
+	RoleOwner = "owner"
+	RoleAdmin = "admin"
+)
+
+// Identity is the authenticated caller of a request.
+type Identity struct {
+	UserID     string
+	BusinessID string
+	Role       string
+}
+
+type contextKey struct{}
+
+// WithIdentity returns a copy of ctx carrying id.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the identity stored by WithIdentity. ok is false for
+// unauthenticated contexts.
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(contextKey{}).(Identity)
+	return id, ok
+}
@@ -0,0 +1,29 @@
+package auth
+
+import (
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+)
+
+// HashPassword returns a bcrypt hash of password.
+func HashPassword(password string) (string, error) {
+	if len(password) < 8 {
+		return "", apperr.Invalid("password", "password must be at least 8 characters")
+	}
+	// bcrypt silently ignores everything after 72 bytes
+	if len(password) > 72 {
+		return "", apperr.Invalid("password", "password must not exceed 72 bytes")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// CheckPassword reports whether password matches the bcrypt hash.
+func CheckPassword(hash, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
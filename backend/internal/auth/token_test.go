@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+var testSecret = []byte("0123456789abcdef0123456789abcdef")
+
+func TestIssueAndVerify(t *testing.T) {
+	tm, err := NewTokenManager(testSecret, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	token, _, err := tm.Issue("u1", "b1", RoleOwner)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	claims, err := tm.Verify(token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.UserID != "u1" || claims.BusinessID != "b1" || claims.Role != RoleOwner {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+}
+
+func TestVerifyRejectsTampering(t *testing.T) {
+	tm, _ := NewTokenManager(testSecret, time.Hour)
+	token, _, _ := tm.Issue("u1", "b1", RoleOwner)
+	parts := strings.Split(token, ".")
+
+	other, _, _ := tm.Issue("u2", "b2", RoleAdmin)
+	otherParts := strings.Split(other, ".")
+
+	cases := []string{
+		"",
+		"a.b",
+		parts[0] + "." + otherParts[1] + "." + parts[2],
+		// alg none with an empty signature
+		"eyJhbGciOiJub25lIiwidHlwIjoiSldUIn0." + parts[1] + ".",
+	}
+	for _, c := range cases {
+		if _, err := tm.Verify(c); err != ErrInvalidToken {
+			t.Errorf("%q: expected ErrInvalidToken, got %v", c, err)
+		}
+	}
+
+	otherKey, _ := NewTokenManager([]byte("fedcba9876543210fedcba9876543210"), time.Hour)
+	if _, err := otherKey.Verify(token); err != ErrInvalidToken {
+		t.Errorf("expected ErrInvalidToken for wrong key, got %v", err)
+	}
+}
+
+func TestVerifyExpired(t *testing.T) {
+	tm, _ := NewTokenManager(testSecret, time.Minute)
+	tm.now = func() time.Time { return time.Now().Add(-2 * time.Minute) }
+	token, _, _ := tm.Issue("u1", "b1", RoleOwner)
+
+	tm.now = time.Now
+	if _, err := tm.Verify(token); err != ErrExpiredToken {
+		t.Errorf("expected ErrExpiredToken, got %v", err)
+	}
+}
+
+func TestNewTokenManagerShortSecret(t *testing.T) {
+	if _, err := NewTokenManager([]byte("short"), time.Hour); err == nil {
+		t.Fatal("expected error for short secret")
+	}
+}
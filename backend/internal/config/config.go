@@ -2,16 +2,35 @@ package config
 
 import (
 	"errors"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // MongoConfig holds the values necessary to connect to MongoDB.
-// It is intentionally small; additional configuration can be added later
-// if needed (e.g. connection timeouts, max pool size, etc.).
 //
 // Fields are read from environment variables so the application can be
 // configured via Renderer secrets or an .env file in development.
 //
+// MONGO_URI and MONGO_DB are required. The rest are optional and, when set,
+// take precedence over the same option in the URI's query string:
+//
+//   - MONGO_APP_NAME names the client in server logs and profiler output.
+//   - MONGO_MAX_POOL_SIZE and MONGO_MIN_POOL_SIZE bound the connection pool
+//     of each server; 0 keeps the driver's defaults (100 and 0).
+//   - MONGO_CONNECT_TIMEOUT (default 10s) limits connecting and the first
+//     ping; MONGO_OPERATION_TIMEOUT (default 5s) limits each repository
+//     call. Both are parsed with time.ParseDuration.
+//   - MONGO_TLS_CA_FILE is a PEM file of CAs to trust instead of the system
+//     pool, and MONGO_TLS_CERT_FILE a PEM file holding the client certificate
+//     and its key. Setting either enables TLS.
+//   - MONGO_READ_PREFERENCE is primary, primaryPreferred, secondary,
+//     secondaryPreferred or nearest.
+//   - MONGO_WRITE_CONCERN is majority or the number of members, at least 1,
+//     that must acknowledge a write.
+//
 // Example usage:
 //    cfg, err := config.LoadMongoConfig()
 //    if err != nil {
@@ -23,8 +42,30 @@ import (
 type MongoConfig struct {
 	URI      string
 	Database string
+	AppName  string
+
+	MaxPoolSize uint64
+	MinPoolSize uint64
+
+	ConnectTimeout   time.Duration
+	OperationTimeout time.Duration
+
+	TLSCAFile   string
+	TLSCertFile string
+
+	ReadPreference string
+	WriteConcern   string
 }
 
+// Defaults for the MongoDB timeouts.
+const (
+	DefaultMongoConnectTimeout   = 10 * time.Second
+	DefaultMongoOperationTimeout = 5 * time.Second
+)
+
+// MongoReadPreferences are the modes accepted in MONGO_READ_PREFERENCE.
+var MongoReadPreferences = []string{"primary", "primaryPreferred", "secondary", "secondaryPreferred", "nearest"}
+
 func LoadMongoConfig() (MongoConfig, error) {
 	uri := os.Getenv("MONGO_URI")
 	if uri == "" {
@@ -36,5 +77,295 @@ func LoadMongoConfig() (MongoConfig, error) {
 		return MongoConfig{}, errors.New("MONGO_DB is required")
 	}
 
-	return MongoConfig{URI: uri, Database: db}, nil
+	cfg := MongoConfig{
+		URI:              uri,
+		Database:         db,
+		AppName:          os.Getenv("MONGO_APP_NAME"),
+		ConnectTimeout:   DefaultMongoConnectTimeout,
+		OperationTimeout: DefaultMongoOperationTimeout,
+		TLSCAFile:        os.Getenv("MONGO_TLS_CA_FILE"),
+		TLSCertFile:      os.Getenv("MONGO_TLS_CERT_FILE"),
+		WriteConcern:     os.Getenv("MONGO_WRITE_CONCERN"),
+	}
+
+	for _, pool := range []struct {
+		name string
+		dst  *uint64
+	}{
+		{"MONGO_MAX_POOL_SIZE", &cfg.MaxPoolSize},
+		{"MONGO_MIN_POOL_SIZE", &cfg.MinPoolSize},
+	} {
+		if raw := os.Getenv(pool.name); raw != "" {
+			parsed, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				return MongoConfig{}, errors.New(pool.name + " must be a non-negative integer")
+			}
+			*pool.dst = parsed
+		}
+	}
+	if cfg.MaxPoolSize > 0 && cfg.MinPoolSize > cfg.MaxPoolSize {
+		return MongoConfig{}, errors.New("MONGO_MIN_POOL_SIZE must not exceed MONGO_MAX_POOL_SIZE")
+	}
+
+	for _, timeout := range []struct {
+		name string
+		dst  *time.Duration
+	}{
+		{"MONGO_CONNECT_TIMEOUT", &cfg.ConnectTimeout},
+		{"MONGO_OPERATION_TIMEOUT", &cfg.OperationTimeout},
+	} {
+		if raw := os.Getenv(timeout.name); raw != "" {
+			parsed, err := time.ParseDuration(raw)
+			if err != nil || parsed <= 0 {
+				return MongoConfig{}, errors.New(timeout.name + " must be a positive duration such as 5s")
+			}
+			*timeout.dst = parsed
+		}
+	}
+
+	if raw := os.Getenv("MONGO_READ_PREFERENCE"); raw != "" {
+		for _, mode := range MongoReadPreferences {
+			if strings.EqualFold(raw, mode) {
+				cfg.ReadPreference = mode
+			}
+		}
+		if cfg.ReadPreference == "" {
+			return MongoConfig{}, errors.New("MONGO_READ_PREFERENCE must be one of " + strings.Join(MongoReadPreferences, ", "))
+		}
+	}
+
+	if cfg.WriteConcern != "" && cfg.WriteConcern != "majority" {
+		// w=0 leaves writes unacknowledged, which would hide revision
+		// conflicts and migration lock failures
+		if w, err := strconv.ParseUint(cfg.WriteConcern, 10, 31); err != nil || w == 0 {
+			return MongoConfig{}, errors.New("MONGO_WRITE_CONCERN must be majority or a positive integer")
+		}
+	}
+
+	return cfg, nil
+}
+
+// Storage backends accepted in STORAGE.
+const (
+	StorageMongo    = "mongo"
+	StorageMemory   = "memory"
+	StorageSQLite   = "sqlite"
+	StoragePostgres = "postgres"
+)
+
+// DefaultSQLiteDSN is the database file used by STORAGE=sqlite when SQL_DSN
+// is not set.
+const DefaultSQLiteDSN = "abakcus.db"
+
+// StorageConfig selects where the API keeps its data.
+//
+// STORAGE is "mongo" (the default), which needs MongoConfig, "sqlite",
+// "postgres" or "memory". The SQL backends read SQL_DSN: a file path or
+// file: URI for SQLite, defaulting to abakcus.db, and a postgres:// URL,
+// which is required, for PostgreSQL. Memory storage keeps everything in
+// process memory and loses it on restart; it is meant for demos and local
+// development without a database.
+type StorageConfig struct {
+	Backend string
+	DSN     string
+}
+
+func LoadStorageConfig() (StorageConfig, error) {
+	switch backend := os.Getenv("STORAGE"); backend {
+	case "", StorageMongo:
+		return StorageConfig{Backend: StorageMongo}, nil
+	case StorageMemory:
+		return StorageConfig{Backend: StorageMemory}, nil
+	case StorageSQLite:
+		dsn := os.Getenv("SQL_DSN")
+		if dsn == "" {
+			dsn = DefaultSQLiteDSN
+		}
+		return StorageConfig{Backend: StorageSQLite, DSN: dsn}, nil
+	case StoragePostgres:
+		dsn := os.Getenv("SQL_DSN")
+		if dsn == "" {
+			return StorageConfig{}, errors.New("SQL_DSN is required when STORAGE is postgres")
+		}
+		return StorageConfig{Backend: StoragePostgres, DSN: dsn}, nil
+	default:
+		return StorageConfig{}, errors.New("STORAGE must be mongo, sqlite, postgres or memory")
+	}
+}
+
+// AuthConfig holds the values used to sign and verify access tokens.
+//
+// AUTH_SECRET is the HMAC key and must be at least 32 bytes. AUTH_TOKEN_TTL
+// is optional, parsed with time.ParseDuration, and defaults to 24h.
+type AuthConfig struct {
+	Secret   []byte
+	TokenTTL time.Duration
+}
+
+func LoadAuthConfig() (AuthConfig, error) {
+	secret := os.Getenv("AUTH_SECRET")
+	if secret == "" {
+		return AuthConfig{}, errors.New("AUTH_SECRET is required")
+	}
+	if len(secret) < 32 {
+		return AuthConfig{}, errors.New("AUTH_SECRET must be at least 32 bytes")
+	}
+
+	ttl := 24 * time.Hour
+	if raw := os.Getenv("AUTH_TOKEN_TTL"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return AuthConfig{}, errors.New("AUTH_TOKEN_TTL must be a positive duration such as 24h")
+		}
+		ttl = parsed
+	}
+
+	return AuthConfig{Secret: []byte(secret), TokenTTL: ttl}, nil
+}
+
+// RetentionConfig controls how long soft-deleted menus are kept.
+//
+// MENU_RETENTION (default 720h, i.e. 30 days) is the age after which a
+// deleted menu is purged; MENU_PURGE_INTERVAL (default 1h) is how often the
+// purge job runs. Both are parsed with time.ParseDuration.
+type RetentionConfig struct {
+	Retention     time.Duration
+	PurgeInterval time.Duration
+}
+
+func LoadRetentionConfig() (RetentionConfig, error) {
+	cfg := RetentionConfig{Retention: 30 * 24 * time.Hour, PurgeInterval: time.Hour}
+
+	if raw := os.Getenv("MENU_RETENTION"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return RetentionConfig{}, errors.New("MENU_RETENTION must be a positive duration such as 720h")
+		}
+		cfg.Retention = parsed
+	}
+
+	if raw := os.Getenv("MENU_PURGE_INTERVAL"); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return RetentionConfig{}, errors.New("MENU_PURGE_INTERVAL must be a positive duration such as 1h")
+		}
+		cfg.PurgeInterval = parsed
+	}
+
+	return cfg, nil
+}
+
+// PublicConfig describes where diners reach public menus.
+//
+// PUBLIC_MENU_BASE_URL is the absolute http(s) URL that a menu slug is
+// appended to, e.g. https://menu.example.com/m gives
+// https://menu.example.com/m/brunch. It defaults to this API's own public
+// endpoint on localhost, which is only useful in development.
+type PublicConfig struct {
+	MenuBaseURL string
+}
+
+func LoadPublicConfig() (PublicConfig, error) {
+	base := os.Getenv("PUBLIC_MENU_BASE_URL")
+	if base == "" {
+		return PublicConfig{MenuBaseURL: "http://localhost:8080/public/menus"}, nil
+	}
+
+	if err := validateBaseURL("PUBLIC_MENU_BASE_URL", base); err != nil {
+		return PublicConfig{}, err
+	}
+
+	return PublicConfig{MenuBaseURL: strings.TrimRight(base, "/")}, nil
+}
+
+// validateBaseURL checks that the variable name holds an absolute http(s)
+// URL that paths can be appended to.
+func validateBaseURL(name, raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return errors.New(name + " must be an absolute http or https URL")
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return errors.New(name + " must not have a query or fragment")
+	}
+	return nil
+}
+
+// Media storage backends accepted in MEDIA_STORAGE.
+const (
+	MediaStorageLocal = "local"
+	MediaStorageS3    = "s3"
+)
+
+// MediaConfig selects where uploaded images are stored.
+//
+// MEDIA_STORAGE is "local" (the default) or "s3". Local storage writes to
+// MEDIA_DIR (default ./media) and the API serves the files under /media/;
+// MEDIA_BASE_URL (default http://localhost:8080/media) is the address
+// clients download them from.
+//
+// S3 storage works with any S3-compatible service such as AWS S3 or MinIO
+// and needs S3_ENDPOINT (host[:port]), S3_BUCKET, S3_ACCESS_KEY and
+// S3_SECRET_KEY. S3_REGION is optional and S3_USE_SSL defaults to true.
+// MEDIA_BASE_URL, when set, replaces the bucket URL in image links, e.g. to
+// point them at a CDN.
+type MediaConfig struct {
+	Storage string
+	Dir     string
+	BaseURL string
+
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3UseSSL    bool
+}
+
+func LoadMediaConfig() (MediaConfig, error) {
+	cfg := MediaConfig{
+		Storage: os.Getenv("MEDIA_STORAGE"),
+		Dir:     os.Getenv("MEDIA_DIR"),
+		BaseURL: strings.TrimRight(os.Getenv("MEDIA_BASE_URL"), "/"),
+	}
+	if cfg.BaseURL != "" {
+		if err := validateBaseURL("MEDIA_BASE_URL", cfg.BaseURL); err != nil {
+			return MediaConfig{}, err
+		}
+	}
+
+	switch cfg.Storage {
+	case "", MediaStorageLocal:
+		cfg.Storage = MediaStorageLocal
+		if cfg.Dir == "" {
+			cfg.Dir = "media"
+		}
+		if cfg.BaseURL == "" {
+			cfg.BaseURL = "http://localhost:8080/media"
+		}
+	case MediaStorageS3:
+		cfg.S3Endpoint = os.Getenv("S3_ENDPOINT")
+		cfg.S3Region = os.Getenv("S3_REGION")
+		cfg.S3Bucket = os.Getenv("S3_BUCKET")
+		cfg.S3AccessKey = os.Getenv("S3_ACCESS_KEY")
+		cfg.S3SecretKey = os.Getenv("S3_SECRET_KEY")
+		if cfg.S3Endpoint == "" || cfg.S3Bucket == "" || cfg.S3AccessKey == "" || cfg.S3SecretKey == "" {
+			return MediaConfig{}, errors.New("S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY and S3_SECRET_KEY are required when MEDIA_STORAGE is s3")
+		}
+		if strings.Contains(cfg.S3Endpoint, "://") {
+			return MediaConfig{}, errors.New("S3_ENDPOINT must be a host[:port] without a scheme; use S3_USE_SSL to choose https")
+		}
+		cfg.S3UseSSL = true
+		if raw := os.Getenv("S3_USE_SSL"); raw != "" {
+			parsed, err := strconv.ParseBool(raw)
+			if err != nil {
+				return MediaConfig{}, errors.New("S3_USE_SSL must be true or false")
+			}
+			cfg.S3UseSSL = parsed
+		}
+	default:
+		return MediaConfig{}, errors.New("MEDIA_STORAGE must be local or s3")
+	}
+
+	return cfg, nil
 }
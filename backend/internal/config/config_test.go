@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestLoadMongoConfig(t *testing.T) {
@@ -48,3 +49,315 @@ func TestLoadMongoConfig(t *testing.T) {
 		t.Errorf("db mismatch: got %s", cfg.Database)
 	}
 }
+
+func TestLoadMongoConfigOptions(t *testing.T) {
+	keys := []string{"MONGO_URI", "MONGO_DB", "MONGO_APP_NAME", "MONGO_MAX_POOL_SIZE", "MONGO_MIN_POOL_SIZE",
+		"MONGO_CONNECT_TIMEOUT", "MONGO_OPERATION_TIMEOUT", "MONGO_TLS_CA_FILE", "MONGO_TLS_CERT_FILE",
+		"MONGO_READ_PREFERENCE", "MONGO_WRITE_CONCERN"}
+	for _, key := range keys {
+		orig, ok := os.LookupEnv(key)
+		if ok {
+			defer os.Setenv(key, orig)
+		} else {
+			defer os.Unsetenv(key)
+		}
+		os.Unsetenv(key)
+	}
+	os.Setenv("MONGO_URI", "mongodb://localhost:27017")
+	os.Setenv("MONGO_DB", "testdb")
+
+	// defaults
+	cfg, err := LoadMongoConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.ConnectTimeout != DefaultMongoConnectTimeout || cfg.OperationTimeout != DefaultMongoOperationTimeout {
+		t.Errorf("expected default timeouts, got %v and %v", cfg.ConnectTimeout, cfg.OperationTimeout)
+	}
+	if cfg.MaxPoolSize != 0 || cfg.MinPoolSize != 0 || cfg.ReadPreference != "" || cfg.WriteConcern != "" {
+		t.Errorf("expected driver defaults, got %+v", cfg)
+	}
+
+	// everything set
+	os.Setenv("MONGO_APP_NAME", "abakcus-api")
+	os.Setenv("MONGO_MAX_POOL_SIZE", "50")
+	os.Setenv("MONGO_MIN_POOL_SIZE", "5")
+	os.Setenv("MONGO_CONNECT_TIMEOUT", "3s")
+	os.Setenv("MONGO_OPERATION_TIMEOUT", "750ms")
+	os.Setenv("MONGO_TLS_CA_FILE", "/etc/mongo/ca.pem")
+	os.Setenv("MONGO_TLS_CERT_FILE", "/etc/mongo/client.pem")
+	os.Setenv("MONGO_READ_PREFERENCE", "secondarypreferred")
+	os.Setenv("MONGO_WRITE_CONCERN", "majority")
+	cfg, err = LoadMongoConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := MongoConfig{
+		URI:              "mongodb://localhost:27017",
+		Database:         "testdb",
+		AppName:          "abakcus-api",
+		MaxPoolSize:      50,
+		MinPoolSize:      5,
+		ConnectTimeout:   3 * time.Second,
+		OperationTimeout: 750 * time.Millisecond,
+		TLSCAFile:        "/etc/mongo/ca.pem",
+		TLSCertFile:      "/etc/mongo/client.pem",
+		ReadPreference:   "secondaryPreferred",
+		WriteConcern:     "majority",
+	}
+	if cfg != want {
+		t.Errorf("expected %+v, got %+v", want, cfg)
+	}
+
+	os.Setenv("MONGO_WRITE_CONCERN", "2")
+	if _, err := LoadMongoConfig(); err != nil {
+		t.Errorf("unexpected error for a numeric write concern: %v", err)
+	}
+
+	// unacknowledged writes would hide revision conflicts
+	os.Setenv("MONGO_WRITE_CONCERN", "0")
+	if _, err := LoadMongoConfig(); err == nil {
+		t.Error("expected error for MONGO_WRITE_CONCERN=0")
+	}
+	os.Setenv("MONGO_WRITE_CONCERN", "majority")
+
+	for key, value := range map[string]string{
+		"MONGO_MAX_POOL_SIZE":     "-1",
+		"MONGO_MIN_POOL_SIZE":     "500",
+		"MONGO_CONNECT_TIMEOUT":   "soon",
+		"MONGO_OPERATION_TIMEOUT": "0s",
+		"MONGO_READ_PREFERENCE":   "fastest",
+		"MONGO_WRITE_CONCERN":     "all",
+	} {
+		orig := os.Getenv(key)
+		os.Setenv(key, value)
+		if _, err := LoadMongoConfig(); err == nil {
+			t.Errorf("expected error for %s=%s", key, value)
+		}
+		os.Setenv(key, orig)
+	}
+}
+
+func TestLoadStorageConfig(t *testing.T) {
+	for _, key := range []string{"STORAGE", "SQL_DSN"} {
+		orig, ok := os.LookupEnv(key)
+		if ok {
+			defer os.Setenv(key, orig)
+		} else {
+			defer os.Unsetenv(key)
+		}
+	}
+
+	os.Unsetenv("STORAGE")
+	os.Unsetenv("SQL_DSN")
+	cfg, err := LoadStorageConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Backend != StorageMongo {
+		t.Errorf("expected mongo by default, got %q", cfg.Backend)
+	}
+
+	os.Setenv("STORAGE", "memory")
+	cfg, err = LoadStorageConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Backend != StorageMemory {
+		t.Errorf("expected memory, got %q", cfg.Backend)
+	}
+
+	os.Setenv("STORAGE", "sqlite")
+	cfg, err = LoadStorageConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Backend != StorageSQLite || cfg.DSN != DefaultSQLiteDSN {
+		t.Errorf("expected sqlite in %s, got %+v", DefaultSQLiteDSN, cfg)
+	}
+
+	os.Setenv("STORAGE", "postgres")
+	if _, err := LoadStorageConfig(); err == nil {
+		t.Error("expected error when SQL_DSN is missing for postgres")
+	}
+	os.Setenv("SQL_DSN", "postgres://abakcus@db/abakcus")
+	cfg, err = LoadStorageConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Backend != StoragePostgres || cfg.DSN != "postgres://abakcus@db/abakcus" {
+		t.Errorf("expected postgres with SQL_DSN, got %+v", cfg)
+	}
+
+	os.Setenv("STORAGE", "redis")
+	if _, err := LoadStorageConfig(); err == nil {
+		t.Error("expected error for unknown storage")
+	}
+}
+
+func TestLoadAuthConfig(t *testing.T) {
+	origSecret := os.Getenv("AUTH_SECRET")
+	origTTL := os.Getenv("AUTH_TOKEN_TTL")
+	defer func() {
+		os.Setenv("AUTH_SECRET", origSecret)
+		os.Setenv("AUTH_TOKEN_TTL", origTTL)
+	}()
+
+	// missing secret
+	os.Unsetenv("AUTH_SECRET")
+	os.Unsetenv("AUTH_TOKEN_TTL")
+	if _, err := LoadAuthConfig(); err == nil {
+		t.Fatal("expected error when AUTH_SECRET is missing")
+	}
+
+	// short secret
+	os.Setenv("AUTH_SECRET", "too-short")
+	if _, err := LoadAuthConfig(); err == nil {
+		t.Fatal("expected error when AUTH_SECRET is too short")
+	}
+
+	// invalid ttl
+	os.Setenv("AUTH_SECRET", "0123456789abcdef0123456789abcdef")
+	os.Setenv("AUTH_TOKEN_TTL", "forever")
+	if _, err := LoadAuthConfig(); err == nil {
+		t.Fatal("expected error when AUTH_TOKEN_TTL is invalid")
+	}
+
+	// valid with default ttl
+	os.Unsetenv("AUTH_TOKEN_TTL")
+	cfg, err := LoadAuthConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.TokenTTL != 24*time.Hour {
+		t.Errorf("ttl mismatch: got %s", cfg.TokenTTL)
+	}
+}
+
+func TestLoadRetentionConfig(t *testing.T) {
+	origRetention := os.Getenv("MENU_RETENTION")
+	origInterval := os.Getenv("MENU_PURGE_INTERVAL")
+	defer func() {
+		os.Setenv("MENU_RETENTION", origRetention)
+		os.Setenv("MENU_PURGE_INTERVAL", origInterval)
+	}()
+
+	// defaults
+	os.Unsetenv("MENU_RETENTION")
+	os.Unsetenv("MENU_PURGE_INTERVAL")
+	cfg, err := LoadRetentionConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Retention != 30*24*time.Hour || cfg.PurgeInterval != time.Hour {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+
+	// invalid
+	os.Setenv("MENU_RETENTION", "-1h")
+	if _, err := LoadRetentionConfig(); err == nil {
+		t.Fatal("expected error for negative MENU_RETENTION")
+	}
+
+	// valid
+	os.Setenv("MENU_RETENTION", "48h")
+	os.Setenv("MENU_PURGE_INTERVAL", "10m")
+	cfg, err = LoadRetentionConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Retention != 48*time.Hour || cfg.PurgeInterval != 10*time.Minute {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestLoadPublicConfig(t *testing.T) {
+	orig := os.Getenv("PUBLIC_MENU_BASE_URL")
+	defer os.Setenv("PUBLIC_MENU_BASE_URL", orig)
+
+	// default
+	os.Unsetenv("PUBLIC_MENU_BASE_URL")
+	cfg, err := LoadPublicConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MenuBaseURL != "http://localhost:8080/public/menus" {
+		t.Errorf("unexpected default: %q", cfg.MenuBaseURL)
+	}
+
+	// invalid
+	for _, raw := range []string{"menu.example.com/m", "ftp://example.com", "https://example.com/m?x=1"} {
+		os.Setenv("PUBLIC_MENU_BASE_URL", raw)
+		if _, err := LoadPublicConfig(); err == nil {
+			t.Errorf("expected error for %q", raw)
+		}
+	}
+
+	// valid, trailing slash trimmed
+	os.Setenv("PUBLIC_MENU_BASE_URL", "https://menu.example.com/m/")
+	cfg, err = LoadPublicConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.MenuBaseURL != "https://menu.example.com/m" {
+		t.Errorf("unexpected base URL: %q", cfg.MenuBaseURL)
+	}
+}
+
+func TestLoadMediaConfig(t *testing.T) {
+	keys := []string{"MEDIA_STORAGE", "MEDIA_DIR", "MEDIA_BASE_URL", "S3_ENDPOINT", "S3_REGION", "S3_BUCKET", "S3_ACCESS_KEY", "S3_SECRET_KEY", "S3_USE_SSL"}
+	for _, key := range keys {
+		orig, ok := os.LookupEnv(key)
+		if ok {
+			defer os.Setenv(key, orig)
+		} else {
+			defer os.Unsetenv(key)
+		}
+		os.Unsetenv(key)
+	}
+
+	// local defaults
+	cfg, err := LoadMediaConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Storage != MediaStorageLocal || cfg.Dir != "media" || cfg.BaseURL != "http://localhost:8080/media" {
+		t.Errorf("unexpected defaults: %+v", cfg)
+	}
+
+	// unknown backend and bad base URL
+	os.Setenv("MEDIA_STORAGE", "ftp")
+	if _, err := LoadMediaConfig(); err == nil {
+		t.Error("expected error for unknown storage")
+	}
+	os.Setenv("MEDIA_STORAGE", "local")
+	os.Setenv("MEDIA_BASE_URL", "cdn.example.com")
+	if _, err := LoadMediaConfig(); err == nil {
+		t.Error("expected error for relative base URL")
+	}
+
+	// s3 requires its settings
+	os.Setenv("MEDIA_STORAGE", "s3")
+	os.Setenv("MEDIA_BASE_URL", "https://cdn.example.com/")
+	if _, err := LoadMediaConfig(); err == nil {
+		t.Error("expected error for missing S3 settings")
+	}
+
+	os.Setenv("S3_ENDPOINT", "localhost:9000")
+	os.Setenv("S3_BUCKET", "menus")
+	os.Setenv("S3_ACCESS_KEY", "minio")
+	os.Setenv("S3_SECRET_KEY", "minio123")
+	os.Setenv("S3_USE_SSL", "false")
+	cfg, err = LoadMediaConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.S3UseSSL || cfg.S3Endpoint != "localhost:9000" || cfg.BaseURL != "https://cdn.example.com" {
+		t.Errorf("unexpected s3 config: %+v", cfg)
+	}
+
+	os.Setenv("S3_ENDPOINT", "http://localhost:9000")
+	if _, err := LoadMediaConfig(); err == nil {
+		t.Error("expected error for endpoint with a scheme")
+	}
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+// AdminHandler serves platform maintenance endpoints. Routes must be wrapped
+// with RequireAuth and RequireRole(auth.RoleAdmin).
+type AdminHandler struct {
+	purge *service.MenuPurgeService
+	users *service.AuthService
+}
+
+func NewAdminHandler(purge *service.MenuPurgeService, users *service.AuthService) *AdminHandler {
+	return &AdminHandler{purge: purge, users: users}
+}
+
+// PurgeMenu handles DELETE /admin/menus/{menu_id}. Only soft-deleted menus
+// can be purged; live menus are reported as not found.
+func (h *AdminHandler) PurgeMenu(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	menuID := extractMenuIDFromPath(r.URL.Path, "/admin/menus/")
+	if menuID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id is required")
+		return
+	}
+
+	err := h.purge.PurgeMenu(r.Context(), menuID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// SetUserRole handles PUT /admin/users/{email}/role with a
+// models.SetRoleRequest body and returns the updated user.
+func (h *AdminHandler) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	email, ok := strings.CutSuffix(strings.TrimPrefix(r.URL.Path, "/admin/users/"), "/role")
+	if !ok || email == "" || strings.Contains(email, "/") {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req models.SetRoleRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	user, err := h.users.SetUserRole(r.Context(), email, req.Role)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, user)
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/auth"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+	"github.com/custard-technology/abakcus/backend/internal/storage"
+)
+
+func TestPurgeMenuRequiresAdmin(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	deleted := time.Now()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1", DeletedAt: &deleted})
+
+	purge := service.NewMenuPurgeService(menuRepo, memory.NewMenuSectionRepository(), memory.NewMenuItemRepository(), memory.NewMenuVersionRepository(), storage.NewMemoryStorage("https://media.example.com"), service.NewAuditService(memory.NewAuditRepository()), time.Hour)
+	protected := RequireRole(auth.RoleAdmin, http.HandlerFunc(NewAdminHandler(purge, nil).PurgeMenu))
+
+	req := httptest.NewRequest(http.MethodDelete, "/admin/menus/m1", nil)
+	req = withBusiness(req, "b1")
+	w := httptest.NewRecorder()
+	protected.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for owner, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/admin/menus/m1", nil)
+	req = req.WithContext(auth.WithIdentity(req.Context(), auth.Identity{UserID: "admin", BusinessID: "ops", Role: auth.RoleAdmin}))
+	w = httptest.NewRecorder()
+	protected.ServeHTTP(w, req)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected 204 for admin, got %d", w.Code)
+	}
+}
+
+func TestSetUserRole(t *testing.T) {
+	users := service.NewAuthService(memory.NewUserRepository(), memory.NewBusinessRepository(), newTestTokenManager(t))
+	registered, err := users.Register(context.Background(), &models.RegisterRequest{Email: "owner@example.com", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	protected := RequireRole(auth.RoleAdmin, http.HandlerFunc(NewAdminHandler(nil, users).SetUserRole))
+	asAdmin := func(req *http.Request) *http.Request {
+		return req.WithContext(auth.WithIdentity(req.Context(), auth.Identity{UserID: "admin", BusinessID: "ops", Role: auth.RoleAdmin}))
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/owner@example.com/role", strings.NewReader(`{"role":"admin"}`))
+	req = withBusiness(req, registered.User.BusinessID)
+	w := httptest.NewRecorder()
+	protected.ServeHTTP(w, req)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected 403 for owner, got %d", w.Code)
+	}
+
+	req = asAdmin(httptest.NewRequest(http.MethodPut, "/admin/users/owner@example.com/role", strings.NewReader(`{"role":"admin"}`)))
+	w = httptest.NewRecorder()
+	protected.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var user models.User
+	if err := json.NewDecoder(w.Body).Decode(&user); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if user.Email != "owner@example.com" || user.Role != auth.RoleAdmin {
+		t.Errorf("expected owner@example.com to be an admin, got %+v", user)
+	}
+
+	for path, want := range map[string]int{
+		"/admin/users/nobody@example.com/role": http.StatusNotFound,
+		"/admin/users/owner@example.com":       http.StatusNotFound,
+		"/admin/users//role":                   http.StatusNotFound,
+	} {
+		req = asAdmin(httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{"role":"admin"}`)))
+		w = httptest.NewRecorder()
+		protected.ServeHTTP(w, req)
+		if w.Code != want {
+			t.Errorf("%s: expected %d, got %d", path, want, w.Code)
+		}
+	}
+
+	req = asAdmin(httptest.NewRequest(http.MethodPut, "/admin/users/owner@example.com/role", strings.NewReader(`{"role":"root"}`)))
+	w = httptest.NewRecorder()
+	protected.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an unknown role, got %d", w.Code)
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+type AuditHandler struct {
+	service *service.AuditService
+}
+
+func NewAuditHandler(svc *service.AuditService) *AuditHandler {
+	return &AuditHandler{service: svc}
+}
+
+// ListAuditEvents handles GET /audit. The body is the array of events on the
+// requested page, newest first; like GET /menus, the cursor for the next page
+// is returned in the X-Next-Cursor header.
+//
+// Query parameters: entity ("menu:{id}", "section:{id}" or "item:{id}"; a
+// menu also matches the events of its sections and items), limit and cursor.
+func (h *AuditHandler) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	q := r.URL.Query()
+	opts := models.AuditListOptions{Entity: q.Get("entity"), Cursor: q.Get("cursor")}
+	if raw := q.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "limit must be an integer")
+			return
+		}
+		opts.Limit = limit
+	}
+
+	page, err := h.service.ListAuditEvents(r.Context(), businessID, opts)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	respondJSON(w, http.StatusOK, page.Events)
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+func TestListAuditEventsHandler(t *testing.T) {
+	businessRepo := memory.NewBusinessRepository()
+	seedBusinesses(t, businessRepo, &models.Business{BusinessID: "b1", Name: "Test"})
+	auditSvc := service.NewAuditService(memory.NewAuditRepository())
+	menuHandler := NewMenuHandler(service.NewMenuService(memory.NewMenuRepository(), businessRepo, auditSvc))
+	handler := NewAuditHandler(auditSvc)
+
+	// creating a menu through the middleware records the request ID
+	create := RequestID(http.HandlerFunc(menuHandler.CreateMenu))
+	var menu models.Menu
+	for _, name := range []string{"Lunch", "Dinner"} {
+		req := httptest.NewRequest(http.MethodPost, "/menus", bytes.NewReader([]byte(`{"name":"`+name+`"}`)))
+		req.Header.Set(RequestIDHeader, "req-"+name)
+		w := httptest.NewRecorder()
+		create.ServeHTTP(w, withBusiness(req, "b1"))
+		if w.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+		}
+		json.NewDecoder(w.Body).Decode(&menu)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/audit?entity=menu:"+menu.MenuID, nil)
+	w := httptest.NewRecorder()
+	handler.ListAuditEvents(w, withBusiness(req, "b1"))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var events []models.AuditEvent
+	if err := json.NewDecoder(w.Body).Decode(&events); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event for the menu, got %d", len(events))
+	}
+	if events[0].Action != models.AuditCreate || events[0].ActorID != "u1" || events[0].RequestID != "req-Dinner" {
+		t.Errorf("expected create by u1 in req-Dinner, got %+v", events[0])
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/audit?limit=1", nil)
+	w = httptest.NewRecorder()
+	handler.ListAuditEvents(w, withBusiness(req, "b1"))
+	if w.Code != http.StatusOK || w.Header().Get("X-Next-Cursor") == "" {
+		t.Errorf("expected 200 with a next cursor, got %d %q", w.Code, w.Header().Get("X-Next-Cursor"))
+	}
+
+	// other businesses see nothing
+	req = httptest.NewRequest(http.MethodGet, "/audit?entity=menu:"+menu.MenuID, nil)
+	w = httptest.NewRecorder()
+	handler.ListAuditEvents(w, withBusiness(req, "b2"))
+	if w.Code != http.StatusOK || w.Body.String() != "[]\n" {
+		t.Errorf("expected an empty list, got %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestListAuditEventsHandlerInvalidEntity(t *testing.T) {
+	handler := NewAuditHandler(service.NewAuditService(memory.NewAuditRepository()))
+
+	req := httptest.NewRequest(http.MethodGet, "/audit?entity=menus", nil)
+	w := httptest.NewRecorder()
+	handler.ListAuditEvents(w, withBusiness(req, "b1"))
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", w.Code)
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/custard-technology/abakcus/backend/internal/auth"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+type AuthHandler struct {
+	service *service.AuthService
+}
+
+func NewAuthHandler(svc *service.AuthService) *AuthHandler {
+	return &AuthHandler{service: svc}
+}
+
+// RequireAuth verifies the bearer token of every request and stores the
+// caller's identity in the request context. Requests without a valid token
+// are rejected with 401 before reaching next.
+func RequireAuth(tokens *auth.TokenManager, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		scheme, token, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="abakcus"`)
+			respondError(w, http.StatusUnauthorized, "bearer token is required")
+			return
+		}
+
+		claims, err := tokens.Verify(strings.TrimSpace(token))
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="abakcus", error="invalid_token"`)
+			respondError(w, http.StatusUnauthorized, err.Error())
+			return
+		}
+
+		ctx := auth.WithIdentity(r.Context(), auth.Identity{
+			UserID:     claims.UserID,
+			BusinessID: claims.BusinessID,
+			Role:       claims.Role,
+		})
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequireRole rejects authenticated callers whose role is not role with 403.
+// It must be wrapped by RequireAuth.
+func RequireRole(role string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, ok := auth.FromContext(r.Context())
+		if !ok {
+			respondError(w, http.StatusUnauthorized, "authentication required")
+			return
+		}
+		if id.Role != role {
+			respondError(w, http.StatusForbidden, "insufficient permissions")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req models.RegisterRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	resp, err := h.service.Register(r.Context(), &req)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, resp)
+}
+
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req models.LoginRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	resp, err := h.service.Login(r.Context(), &req)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/auth"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+func newTestTokenManager(t *testing.T) *auth.TokenManager {
+	t.Helper()
+	tokens, err := auth.NewTokenManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return tokens
+}
+
+func TestRequireAuth(t *testing.T) {
+	tokens := newTestTokenManager(t)
+	token, _, _ := tokens.Issue("u1", "b1", auth.RoleOwner)
+
+	var seen auth.Identity
+	protected := RequireAuth(tokens, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen, _ = auth.FromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	cases := map[string]int{
+		"":                   http.StatusUnauthorized,
+		"Bearer":             http.StatusUnauthorized,
+		"Basic abc":          http.StatusUnauthorized,
+		"Bearer not.a.token": http.StatusUnauthorized,
+		"Bearer " + token:    http.StatusNoContent,
+	}
+	for header, want := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/menus", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		w := httptest.NewRecorder()
+
+		protected.ServeHTTP(w, req)
+
+		if w.Code != want {
+			t.Errorf("%q: expected %d, got %d", header, want, w.Code)
+		}
+	}
+
+	if seen.BusinessID != "b1" || seen.UserID != "u1" {
+		t.Errorf("unexpected identity in context: %+v", seen)
+	}
+}
+
+func TestRegisterAndLoginHandlers(t *testing.T) {
+	handler := NewAuthHandler(service.NewAuthService(memory.NewUserRepository(), memory.NewBusinessRepository(), newTestTokenManager(t)))
+
+	body, _ := json.Marshal(models.RegisterRequest{Email: "owner@example.com", Password: "correct horse"})
+	req := httptest.NewRequest(http.MethodPost, "/auth/register", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.Register(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", w.Code)
+	}
+
+	body, _ = json.Marshal(models.LoginRequest{Email: "owner@example.com", Password: "wrong password"})
+	req = httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	handler.Login(w, req)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+
+	body, _ = json.Marshal(models.LoginRequest{Email: "owner@example.com", Password: "correct horse"})
+	req = httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	handler.Login(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+// BusinessHandler serves the profile and settings of the caller's business.
+// The business is always the one in the access token; there is no business
+// ID in the path.
+type BusinessHandler struct {
+	service *service.BusinessService
+}
+
+func NewBusinessHandler(svc *service.BusinessService) *BusinessHandler {
+	return &BusinessHandler{service: svc}
+}
+
+// GetBusiness handles GET /business.
+func (h *BusinessHandler) GetBusiness(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	business, err := h.service.GetBusiness(r.Context(), businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, business)
+}
+
+// UpdateBusiness handles PUT /business.
+func (h *BusinessHandler) UpdateBusiness(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	var req models.UpdateBusinessRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	business, err := h.service.UpdateBusiness(r.Context(), businessID, &req)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, business)
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+func newTestBusinessHandler(t *testing.T) *BusinessHandler {
+	t.Helper()
+	repo := memory.NewBusinessRepository()
+	seedBusinesses(t, repo, &models.Business{
+		BusinessID:      "b1",
+		Name:            "Tasca",
+		Timezone:        "UTC",
+		DefaultCurrency: "EUR",
+		Locale:          "en",
+	})
+	return NewBusinessHandler(service.NewBusinessService(repo))
+}
+
+func TestGetBusinessHandler(t *testing.T) {
+	handler := newTestBusinessHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/business", nil)
+	req = withBusiness(req, "b1")
+	w := httptest.NewRecorder()
+
+	handler.GetBusiness(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var business models.Business
+	if err := json.NewDecoder(w.Body).Decode(&business); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if business.Name != "Tasca" {
+		t.Errorf("unexpected business: %+v", business)
+	}
+}
+
+func TestUpdateBusinessHandler(t *testing.T) {
+	cases := []struct {
+		body string
+		want int
+	}{
+		{`{"name":"Tasca Nova","locale":"pt-PT"}`, http.StatusOK},
+		{`{"timezone":"Nowhere/City"}`, http.StatusUnprocessableEntity},
+		{`{"name":"Tasca","tagline":"x"}`, http.StatusBadRequest},
+		{`{"name":`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		handler := newTestBusinessHandler(t)
+
+		req := httptest.NewRequest(http.MethodPut, "/business", bytes.NewReader([]byte(tc.body)))
+		req = withBusiness(req, "b1")
+		w := httptest.NewRecorder()
+
+		handler.UpdateBusiness(w, req)
+
+		if w.Code != tc.want {
+			t.Errorf("%s: expected %d, got %d", tc.body, tc.want, w.Code)
+		}
+	}
+}
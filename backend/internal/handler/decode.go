@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// maxRequestBodyBytes bounds JSON request bodies.
+const maxRequestBodyBytes = 1 << 20
+
+// decodeJSON decodes the JSON body of r into dst. Bodies over
+// maxRequestBodyBytes, unknown fields and anything after the JSON value are
+// refused; ok is false when an error response has been written.
+func decodeJSON(w http.ResponseWriter, r *http.Request, dst any) (ok bool) {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBodyBytes))
+	dec.DisallowUnknownFields()
+
+	err := dec.Decode(dst)
+	if err == nil {
+		if _, extra := dec.Token(); extra != io.EOF {
+			err = errors.New("body must contain a single JSON value")
+		}
+	}
+	if err == nil {
+		return true
+	}
+
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		respondError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body must be at most %d bytes", maxRequestBodyBytes))
+		return false
+	}
+
+	detail := "invalid request body: " + strings.TrimPrefix(err.Error(), "json: ")
+	p := Problem{Status: http.StatusBadRequest, Detail: detail}
+	// encoding/json has no typed error for unknown fields
+	if quoted, found := strings.CutPrefix(err.Error(), "json: unknown field "); found {
+		if field, err := strconv.Unquote(quoted); err == nil {
+			p.InvalidParams = []InvalidParam{{Name: field, Reason: "unknown field"}}
+		}
+	}
+	respondProblem(w, p)
+	return false
+}
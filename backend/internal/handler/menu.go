@@ -2,10 +2,20 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log"
+	"mime"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/auth"
+	"github.com/custard-technology/abakcus/backend/internal/mergepatch"
 	"github.com/custard-technology/abakcus/backend/internal/models"
 	"github.com/custard-technology/abakcus/backend/internal/service"
 )
@@ -18,8 +28,14 @@ func NewMenuHandler(svc *service.MenuService) *MenuHandler {
 	return &MenuHandler{service: svc}
 }
 
-func getBusinessIDFromRequest(r *http.Request) string {
-	return r.Header.Get("X-Business-ID")
+// getBusinessIDFromContext returns the business of the authenticated caller.
+// The identity is set by RequireAuth; it is empty for unauthenticated requests.
+func getBusinessIDFromContext(r *http.Request) string {
+	id, ok := auth.FromContext(r.Context())
+	if !ok {
+		return ""
+	}
+	return id.BusinessID
 }
 
 func extractMenuIDFromPath(path string, prefix string) string {
@@ -40,8 +56,43 @@ func respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
 	}
 }
 
-func respondError(w http.ResponseWriter, statusCode int, message string) {
-	respondJSON(w, statusCode, map[string]string{"error": message})
+// setMenuETag sets the ETag of a menu, which is its revision.
+func setMenuETag(w http.ResponseWriter, menu *models.Menu) {
+	w.Header().Set("ETag", fmt.Sprintf(`"%d"`, menu.Revision))
+}
+
+// ifMatchRevisions returns the menu revisions named by the If-Match header of
+// a write, which may list several ETags. "*" yields service.AnyRevision. Weak
+// ETags name the same revision as strong ones, since proxies that compress
+// responses weaken them, and quoted tags that are not revisions never match.
+// ok is false when a response has been written because the header is missing
+// or malformed.
+func ifMatchRevisions(w http.ResponseWriter, r *http.Request) (revisions []int64, ok bool) {
+	raw := strings.TrimSpace(r.Header.Get("If-Match"))
+	if raw == "" {
+		respondError(w, http.StatusPreconditionRequired, "If-Match header is required; send the ETag of the menu")
+		return nil, false
+	}
+
+	for _, tag := range strings.Split(raw, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if tag == "*" {
+			revisions = append(revisions, service.AnyRevision)
+			continue
+		}
+		opaque := strings.TrimPrefix(tag, "W/")
+		if len(opaque) < 2 || !strings.HasPrefix(opaque, `"`) || !strings.HasSuffix(opaque, `"`) {
+			respondError(w, http.StatusBadRequest, "If-Match must be * or a list of ETags returned for the menu")
+			return nil, false
+		}
+		if revision, err := strconv.ParseInt(opaque[1:len(opaque)-1], 10, 64); err == nil && revision > 0 {
+			revisions = append(revisions, revision)
+		}
+	}
+	return revisions, true
 }
 
 func (h *MenuHandler) CreateMenu(w http.ResponseWriter, r *http.Request) {
@@ -50,28 +101,24 @@ func (h *MenuHandler) CreateMenu(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	businessID := getBusinessIDFromRequest(r)
+	businessID := getBusinessIDFromContext(r)
 	if businessID == "" {
-		respondError(w, http.StatusBadRequest, "X-Business-ID header is required")
+		respondError(w, http.StatusUnauthorized, "authentication required")
 		return
 	}
 
 	var req models.CreateMenuRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
 	menu, err := h.service.CreateMenu(r.Context(), &req, businessID)
 	if err != nil {
-		statusCode := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "required") {
-			statusCode = http.StatusBadRequest
-		}
-		respondError(w, statusCode, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
+	setMenuETag(w, menu)
 	respondJSON(w, http.StatusCreated, menu)
 }
 
@@ -81,22 +128,25 @@ func (h *MenuHandler) GetMenu(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
 	menuID := extractMenuIDFromPath(r.URL.Path, "/menus/")
 	if menuID == "" {
 		respondError(w, http.StatusBadRequest, "menu_id is required")
 		return
 	}
 
-	menu, err := h.service.GetMenu(r.Context(), menuID)
+	menu, err := h.service.GetMenu(r.Context(), menuID, businessID)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			respondError(w, http.StatusNotFound, err.Error())
-			return
-		}
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
+	setMenuETag(w, menu)
 	respondJSON(w, http.StatusOK, menu)
 }
 
@@ -106,32 +156,92 @@ func (h *MenuHandler) UpdateMenu(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
 	menuID := extractMenuIDFromPath(r.URL.Path, "/menus/")
 	if menuID == "" {
 		respondError(w, http.StatusBadRequest, "menu_id is required")
 		return
 	}
 
+	revisions, ok := ifMatchRevisions(w, r)
+	if !ok {
+		return
+	}
+
 	var req models.UpdateMenuRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		respondError(w, http.StatusBadRequest, "invalid request body")
+	if !decodeJSON(w, r, &req) {
 		return
 	}
 
-	menu, err := h.service.UpdateMenu(r.Context(), menuID, &req)
+	menu, err := h.service.UpdateMenu(r.Context(), menuID, businessID, revisions, &req)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			respondError(w, http.StatusNotFound, err.Error())
+		respondServiceError(w, err)
+		return
+	}
+
+	setMenuETag(w, menu)
+	respondJSON(w, http.StatusOK, menu)
+}
+
+// maxMenuPatchBytes bounds PATCH bodies, which are read whole before merging.
+const maxMenuPatchBytes = 1 << 20
+
+// PatchMenu handles PATCH /menus/{menu_id} with a JSON Merge Patch (RFC 7396)
+// body: members replace the menu's fields and null members clear them.
+// Plain application/json bodies are read as merge patches too.
+func (h *MenuHandler) PatchMenu(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPatch {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID := extractMenuIDFromPath(r.URL.Path, "/menus/")
+	if menuID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id is required")
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != mergepatch.ContentType && mediaType != "application/json" {
+		w.Header().Set("Accept-Patch", mergepatch.ContentType)
+		respondError(w, http.StatusUnsupportedMediaType, "Content-Type must be "+mergepatch.ContentType)
+		return
+	}
+
+	revisions, ok := ifMatchRevisions(w, r)
+	if !ok {
+		return
+	}
+
+	patch, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxMenuPatchBytes))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			respondError(w, http.StatusRequestEntityTooLarge, fmt.Sprintf("patch must be at most %d bytes", maxMenuPatchBytes))
 			return
 		}
-		statusCode := http.StatusInternalServerError
-		if strings.Contains(err.Error(), "required") {
-			statusCode = http.StatusBadRequest
-		}
-		respondError(w, statusCode, err.Error())
+		respondError(w, http.StatusBadRequest, "invalid request body: "+err.Error())
 		return
 	}
 
+	menu, err := h.service.PatchMenu(r.Context(), menuID, businessID, revisions, patch)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	setMenuETag(w, menu)
 	respondJSON(w, http.StatusOK, menu)
 }
 
@@ -141,42 +251,143 @@ func (h *MenuHandler) DeleteMenu(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
 	menuID := extractMenuIDFromPath(r.URL.Path, "/menus/")
 	if menuID == "" {
 		respondError(w, http.StatusBadRequest, "menu_id is required")
 		return
 	}
 
-	err := h.service.DeleteMenu(r.Context(), menuID)
+	revisions, ok := ifMatchRevisions(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteMenu(r.Context(), menuID, businessID, revisions)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
-			respondError(w, http.StatusNotFound, err.Error())
-			return
-		}
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ListMenus handles GET /menus. The body is the array of menus on the
+// requested page; when more pages exist the opaque cursor for the next one is
+// returned in the X-Next-Cursor header, so existing clients that expect a
+// bare array keep working.
+//
+// Query parameters: limit, cursor, is_active, created_after, created_before,
+// updated_after, updated_before (RFC 3339) and sort (name, created_at or
+// updated_at, prefixed with "-" for descending order).
 func (h *MenuHandler) ListMenus(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
 		return
 	}
 
-	businessID := getBusinessIDFromRequest(r)
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	opts, err := parseMenuListOptions(r.URL.Query())
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	page, err := h.service.ListMenusByBusiness(r.Context(), businessID, opts)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	respondJSON(w, http.StatusOK, page.Menus)
+}
+
+func parseMenuListOptions(q url.Values) (models.MenuListOptions, error) {
+	var opts models.MenuListOptions
+
+	if raw := q.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil {
+			return opts, apperr.Invalid("limit", "limit must be an integer")
+		}
+		opts.Limit = limit
+	}
+	opts.Cursor = q.Get("cursor")
+
+	if raw := q.Get("is_active"); raw != "" {
+		active, err := strconv.ParseBool(raw)
+		if err != nil {
+			return opts, apperr.Invalid("is_active", "is_active must be true or false")
+		}
+		opts.IsActive = &active
+	}
+
+	times := map[string]**time.Time{
+		"created_after":  &opts.CreatedAfter,
+		"created_before": &opts.CreatedBefore,
+		"updated_after":  &opts.UpdatedAfter,
+		"updated_before": &opts.UpdatedBefore,
+	}
+	for name, dst := range times {
+		raw := q.Get(name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return opts, apperr.Invalidf(name, "%s must be an RFC 3339 timestamp", name)
+		}
+		*dst = &t
+	}
+
+	sortBy := q.Get("sort")
+	if strings.HasPrefix(sortBy, "-") {
+		opts.SortDesc = true
+		sortBy = strings.TrimPrefix(sortBy, "-")
+	}
+	opts.SortBy = sortBy
+
+	return opts, nil
+}
+
+// RestoreMenu handles POST /menus/{menu_id}/restore.
+func (h *MenuHandler) RestoreMenu(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
 	if businessID == "" {
-		respondError(w, http.StatusBadRequest, "X-Business-ID header is required")
+		respondError(w, http.StatusUnauthorized, "authentication required")
 		return
 	}
 
-	menus, err := h.service.ListMenusByBusiness(r.Context(), businessID)
+	menuID := extractMenuIDFromPath(r.URL.Path, "/menus/")
+	if menuID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id is required")
+		return
+	}
+
+	menu, err := h.service.RestoreMenu(r.Context(), menuID, businessID)
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, err.Error())
+		respondServiceError(w, err)
 		return
 	}
 
-	respondJSON(w, http.StatusOK, menus)
+	setMenuETag(w, menu)
+	respondJSON(w, http.StatusOK, menu)
 }
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+type MenuItemHandler struct {
+	service *service.MenuItemService
+}
+
+func NewMenuItemHandler(svc *service.MenuItemService) *MenuItemHandler {
+	return &MenuItemHandler{service: svc}
+}
+
+// extractMenuItemIDsFromPath parses /menus/{menu_id}/items[/{item_id}].
+// itemID is empty when the path addresses the collection.
+func extractMenuItemIDsFromPath(path string) (menuID, itemID string) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, "/menus/"), "/"), "/")
+	if len(parts) < 2 || parts[1] != "items" {
+		return "", ""
+	}
+	menuID = parts[0]
+	if len(parts) > 2 {
+		itemID = parts[2]
+	}
+	return menuID, itemID
+}
+
+func (h *MenuItemHandler) CreateMenuItem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, _ := extractMenuItemIDsFromPath(r.URL.Path)
+	if menuID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id is required")
+		return
+	}
+
+	var req models.CreateMenuItemRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	item, err := h.service.CreateMenuItem(r.Context(), menuID, &req, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, item)
+}
+
+func (h *MenuItemHandler) GetMenuItem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, itemID := extractMenuItemIDsFromPath(r.URL.Path)
+	if menuID == "" || itemID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id and item_id are required")
+		return
+	}
+
+	item, err := h.service.GetMenuItem(r.Context(), menuID, itemID, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, item)
+}
+
+func (h *MenuItemHandler) UpdateMenuItem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, itemID := extractMenuItemIDsFromPath(r.URL.Path)
+	if menuID == "" || itemID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id and item_id are required")
+		return
+	}
+
+	var req models.UpdateMenuItemRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	item, err := h.service.UpdateMenuItem(r.Context(), menuID, itemID, &req, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, item)
+}
+
+func (h *MenuItemHandler) DeleteMenuItem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, itemID := extractMenuItemIDsFromPath(r.URL.Path)
+	if menuID == "" || itemID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id and item_id are required")
+		return
+	}
+
+	err := h.service.DeleteMenuItem(r.Context(), menuID, itemID, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MenuItemHandler) ListMenuItems(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, _ := extractMenuItemIDsFromPath(r.URL.Path)
+	if menuID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id is required")
+		return
+	}
+
+	items, err := h.service.ListMenuItems(r.Context(), menuID, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, items)
+}
+
+// PriceMenuItem handles POST /menus/{menu_id}/items/{item_id}/price and
+// responds with the price of the item for the selected modifiers.
+func (h *MenuItemHandler) PriceMenuItem(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, itemID := extractMenuItemIDsFromPath(r.URL.Path)
+	if menuID == "" || itemID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id and item_id are required")
+		return
+	}
+
+	var req models.PriceRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	quote, err := h.service.PriceMenuItem(r.Context(), menuID, itemID, &req, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, quote)
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/custard-technology/abakcus/backend/internal/imaging"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+// imageFormField is the multipart field carrying the uploaded file.
+const imageFormField = "image"
+
+type MenuItemImageHandler struct {
+	service *service.MenuItemImageService
+}
+
+func NewMenuItemImageHandler(svc *service.MenuItemImageService) *MenuItemImageHandler {
+	return &MenuItemImageHandler{service: svc}
+}
+
+// UploadMenuItemImage handles POST /menus/{menu_id}/items/{item_id}/image. The
+// body is multipart/form-data with the file in the "image" field; other
+// fields are ignored. It responds with the updated item.
+func (h *MenuItemImageHandler) UploadMenuItemImage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, itemID := extractMenuItemIDsFromPath(r.URL.Path)
+	if menuID == "" || itemID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id and item_id are required")
+		return
+	}
+
+	data, status, err := readImageUpload(w, r)
+	if err != nil {
+		respondError(w, status, err.Error())
+		return
+	}
+
+	item, err := h.service.UploadMenuItemImage(r.Context(), menuID, itemID, data, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, item)
+}
+
+// readImageUpload streams the multipart body and returns the contents of the
+// image field, reading at most imaging.MaxUploadBytes of it. The returned
+// status applies when err is not nil.
+func readImageUpload(w http.ResponseWriter, r *http.Request) ([]byte, int, error) {
+	// leave room for the multipart framing and any small extra fields
+	r.Body = http.MaxBytesReader(w, r.Body, imaging.MaxUploadBytes+1<<20)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, http.StatusBadRequest, errors.New("request body must be multipart/form-data")
+	}
+
+	tooLarge := fmt.Errorf("image must be at most %d MiB", imaging.MaxUploadBytes>>20)
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil, http.StatusBadRequest, errors.New("image field is required")
+		}
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				return nil, http.StatusRequestEntityTooLarge, tooLarge
+			}
+			return nil, http.StatusBadRequest, errors.New("invalid multipart body")
+		}
+		if part.FormName() != imageFormField {
+			part.Close()
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(part, imaging.MaxUploadBytes+1))
+		part.Close()
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				return nil, http.StatusRequestEntityTooLarge, tooLarge
+			}
+			return nil, http.StatusBadRequest, errors.New("invalid multipart body")
+		}
+		if len(data) > imaging.MaxUploadBytes {
+			return nil, http.StatusRequestEntityTooLarge, tooLarge
+		}
+		if len(data) == 0 {
+			return nil, http.StatusBadRequest, errors.New("image field is required")
+		}
+		return data, 0, nil
+	}
+}
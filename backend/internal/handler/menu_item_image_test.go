@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/imaging"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+	"github.com/custard-technology/abakcus/backend/internal/storage"
+)
+
+func newTestImageHandler(t *testing.T) *MenuItemImageHandler {
+	t.Helper()
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+	itemRepo := memory.NewMenuItemRepository()
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", MenuID: "m1", BusinessID: "b1", Title: "Pizza"})
+	return NewMenuItemImageHandler(service.NewMenuItemImageService(menuRepo, itemRepo, storage.NewMemoryStorage("https://media.example.com"), service.NewAuditService(memory.NewAuditRepository())))
+}
+
+func multipartUpload(t *testing.T, field string, data []byte) (*bytes.Buffer, string) {
+	t.Helper()
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("caption", "ignored"); err != nil {
+		t.Fatal(err)
+	}
+	part, err := writer.CreateFormFile(field, "photo.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(data)
+	writer.Close()
+	return &body, writer.FormDataContentType()
+}
+
+func TestUploadMenuItemImageHandler(t *testing.T) {
+	handler := newTestImageHandler(t)
+
+	var img bytes.Buffer
+	png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 400, 300)))
+	body, contentType := multipartUpload(t, "image", img.Bytes())
+
+	req := httptest.NewRequest(http.MethodPost, "/menus/m1/items/i1/image", body)
+	req.Header.Set("Content-Type", contentType)
+	req = withBusiness(req, "b1")
+	w := httptest.NewRecorder()
+	handler.UploadMenuItemImage(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var item models.MenuItem
+	if err := json.NewDecoder(w.Body).Decode(&item); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if item.Image == nil || item.Image.Width != 400 || len(item.Image.Thumbnails) != 2 {
+		t.Errorf("unexpected image: %+v", item.Image)
+	}
+	if item.ImageURL != item.Image.URL {
+		t.Errorf("expected image_url %q, got %q", item.Image.URL, item.ImageURL)
+	}
+}
+
+func TestUploadMenuItemImageHandlerErrors(t *testing.T) {
+	handler := newTestImageHandler(t)
+
+	var img bytes.Buffer
+	png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 10, 10)))
+
+	cases := []struct {
+		name   string
+		field  string
+		data   []byte
+		status int
+	}{
+		{"wrong field", "file", img.Bytes(), http.StatusBadRequest},
+		{"not an image", "image", []byte("<html></html>"), http.StatusUnsupportedMediaType},
+		{"corrupt image", "image", []byte("\x89PNG\r\n\x1a\nnot really"), http.StatusBadRequest},
+		{"too large", "image", make([]byte, imaging.MaxUploadBytes+1), http.StatusRequestEntityTooLarge},
+	}
+	for _, c := range cases {
+		body, contentType := multipartUpload(t, c.field, c.data)
+		req := httptest.NewRequest(http.MethodPost, "/menus/m1/items/i1/image", body)
+		req.Header.Set("Content-Type", contentType)
+		req = withBusiness(req, "b1")
+		w := httptest.NewRecorder()
+
+		handler.UploadMenuItemImage(w, req)
+
+		if w.Code != c.status {
+			t.Errorf("%s: expected %d, got %d", c.name, c.status, w.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/menus/m1/items/i1/image", bytes.NewReader(img.Bytes()))
+	req.Header.Set("Content-Type", "image/png")
+	req = withBusiness(req, "b1")
+	w := httptest.NewRecorder()
+	handler.UploadMenuItemImage(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("raw body: expected 400, got %d", w.Code)
+	}
+}
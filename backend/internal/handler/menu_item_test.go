@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+func newTestMenuItemHandler(t *testing.T) (*MenuItemHandler, *memory.MenuItemRepository) {
+	t.Helper()
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+	itemRepo := memory.NewMenuItemRepository()
+
+	svc := service.NewMenuItemService(menuRepo, memory.NewMenuSectionRepository(), itemRepo, memory.NewBusinessRepository(), service.NewAuditService(memory.NewAuditRepository()))
+	return NewMenuItemHandler(svc), itemRepo
+}
+
+func TestCreateMenuItemHandler(t *testing.T) {
+	handler, _ := newTestMenuItemHandler(t)
+
+	body := models.CreateMenuItemRequest{Title: "Espresso", Price: models.Money{Amount: 220, Currency: "EUR"}}
+	bodyBytes, _ := json.Marshal(body)
+
+	req := httptest.NewRequest(http.MethodPost, "/menus/m1/items", bytes.NewReader(bodyBytes))
+	req = withBusiness(req, "b1")
+	w := httptest.NewRecorder()
+
+	handler.CreateMenuItem(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d", w.Code)
+	}
+}
+
+func TestGetMenuItemHandlerWrongBusiness(t *testing.T) {
+	handler, itemRepo := newTestMenuItemHandler(t)
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", Title: "Tea", MenuID: "m1", BusinessID: "b1"})
+
+	req := httptest.NewRequest(http.MethodGet, "/menus/m1/items/i1", nil)
+	req = withBusiness(req, "b2")
+	w := httptest.NewRecorder()
+
+	handler.GetMenuItem(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestListMenuItemsHandler(t *testing.T) {
+	handler, itemRepo := newTestMenuItemHandler(t)
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", Title: "Tea", MenuID: "m1", BusinessID: "b1"})
+
+	req := httptest.NewRequest(http.MethodGet, "/menus/m1/items", nil)
+	req = withBusiness(req, "b1")
+	w := httptest.NewRecorder()
+
+	handler.ListMenuItems(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+
+	var items []models.MenuItem
+	if err := json.NewDecoder(w.Body).Decode(&items); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(items) != 1 {
+		t.Errorf("expected 1 item, got %d", len(items))
+	}
+}
+
+func TestDeleteMenuItemHandler(t *testing.T) {
+	handler, itemRepo := newTestMenuItemHandler(t)
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", Title: "Tea", MenuID: "m1", BusinessID: "b1"})
+
+	req := httptest.NewRequest(http.MethodDelete, "/menus/m1/items/i1", nil)
+	req = withBusiness(req, "b1")
+	w := httptest.NewRecorder()
+
+	handler.DeleteMenuItem(w, req)
+
+	if w.Code != http.StatusNoContent {
+		t.Errorf("expected 204, got %d", w.Code)
+	}
+}
+
+func TestPriceMenuItemHandler(t *testing.T) {
+	handler, itemRepo := newTestMenuItemHandler(t)
+	seedItems(t, itemRepo, &models.MenuItem{
+		ItemID:     "i1",
+		MenuID:     "m1",
+		BusinessID: "b1",
+		Title:      "Latte",
+		Price:      models.Money{Amount: 300, Currency: "EUR"},
+		ModifierGroups: []models.ModifierGroup{{
+			GroupID: "milk", Name: "Milk", MinSelect: 0, MaxSelect: 1,
+			Options: []models.ModifierOption{{OptionID: "oat", Name: "Oat", PriceDelta: models.Money{Amount: 40, Currency: "EUR"}}},
+		}},
+	})
+
+	cases := []struct {
+		body string
+		want int
+	}{
+		{`{"selections":[{"group_id":"milk","option_ids":["oat"]}]}`, http.StatusOK},
+		{`{"selections":[{"group_id":"milk","option_ids":["soy"]}]}`, http.StatusBadRequest},
+		{`{"quantity":-1}`, http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/menus/m1/items/i1/price", bytes.NewReader([]byte(tc.body)))
+		req = withBusiness(req, "b1")
+		w := httptest.NewRecorder()
+
+		handler.PriceMenuItem(w, req)
+
+		if w.Code != tc.want {
+			t.Errorf("%s: expected %d, got %d: %s", tc.body, tc.want, w.Code, w.Body.String())
+		}
+		if w.Code == http.StatusOK {
+			var quote models.PriceQuote
+			if err := json.NewDecoder(w.Body).Decode(&quote); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if quote.Total.Amount != 340 {
+				t.Errorf("expected total 340, got %d", quote.Total.Amount)
+			}
+		}
+	}
+}
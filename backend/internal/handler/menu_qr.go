@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/qr"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+type MenuQRHandler struct {
+	service *service.MenuQRService
+}
+
+func NewMenuQRHandler(svc *service.MenuQRService) *MenuQRHandler {
+	return &MenuQRHandler{service: svc}
+}
+
+// GetMenuQR handles GET /menus/{menu_id}/qr and responds with a PNG or SVG
+// image of the QR code pointing at the menu's public URL.
+//
+// Query parameters: format (png or svg, default png), size (pixels, default
+// 256), level (error correction L, M, Q or H, default M) and quiet_zone
+// (margin in modules, default 4).
+func (h *MenuQRHandler) GetMenuQR(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID := extractMenuIDFromPath(r.URL.Path, "/menus/")
+	if menuID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id is required")
+		return
+	}
+
+	opts, err := parseQROptions(r.URL.Query())
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	image, err := h.service.RenderMenuQR(r.Context(), menuID, businessID, opts)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", qr.ContentType(opts.Format))
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	w.Write(image)
+}
+
+func parseQROptions(q url.Values) (qr.Options, error) {
+	opts := qr.Options{Format: q.Get("format"), Level: q.Get("level")}
+
+	if raw := q.Get("size"); raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil {
+			return opts, apperr.Invalid("size", "size must be an integer")
+		}
+		opts.Size = size
+	}
+
+	if raw := q.Get("quiet_zone"); raw != "" {
+		zone, err := strconv.Atoi(raw)
+		if err != nil {
+			return opts, apperr.Invalid("quiet_zone", "quiet_zone must be an integer")
+		}
+		opts.QuietZone = &zone
+	}
+
+	return opts.Normalize()
+}
@@ -0,0 +1,72 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+func newTestMenuQRHandler(t *testing.T) *MenuQRHandler {
+	t.Helper()
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", Slug: "brunch", BusinessID: "b1"})
+	return NewMenuQRHandler(service.NewMenuQRService(menuRepo, "https://menu.example.com/m"))
+}
+
+func TestGetMenuQRHandler(t *testing.T) {
+	handler := newTestMenuQRHandler(t)
+
+	cases := map[string]string{
+		"/menus/m1/qr":                      "image/png",
+		"/menus/m1/qr?format=svg&size=512":  "image/svg+xml",
+		"/menus/m1/qr?level=H&quiet_zone=0": "image/png",
+	}
+	for target, contentType := range cases {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		req = withBusiness(req, "b1")
+		w := httptest.NewRecorder()
+
+		handler.GetMenuQR(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Errorf("%s: expected 200, got %d: %s", target, w.Code, w.Body.String())
+			continue
+		}
+		if got := w.Header().Get("Content-Type"); got != contentType {
+			t.Errorf("%s: expected %s, got %s", target, contentType, got)
+		}
+	}
+}
+
+func TestGetMenuQRHandlerErrors(t *testing.T) {
+	handler := newTestMenuQRHandler(t)
+
+	cases := []struct {
+		target     string
+		businessID string
+		want       int
+	}{
+		{"/menus/m1/qr?format=gif", "b1", http.StatusBadRequest},
+		{"/menus/m1/qr?size=big", "b1", http.StatusBadRequest},
+		{"/menus/m1/qr?quiet_zone=99", "b1", http.StatusBadRequest},
+		{"/menus/m1/qr", "b2", http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodGet, tc.target, nil)
+		req = withBusiness(req, tc.businessID)
+		w := httptest.NewRecorder()
+
+		handler.GetMenuQR(w, req)
+
+		if w.Code != tc.want {
+			t.Errorf("%s: expected %d, got %d", tc.target, tc.want, w.Code)
+		}
+		if got := w.Header().Get("Content-Type"); got != ProblemContentType {
+			t.Errorf("%s: errors must be %s, got %q", tc.target, ProblemContentType, got)
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+type MenuSectionHandler struct {
+	service *service.MenuSectionService
+}
+
+func NewMenuSectionHandler(svc *service.MenuSectionService) *MenuSectionHandler {
+	return &MenuSectionHandler{service: svc}
+}
+
+// extractMenuSectionIDsFromPath parses /menus/{menu_id}/sections[/{section_id}].
+// sectionID is empty when the path addresses the collection.
+func extractMenuSectionIDsFromPath(path string) (menuID, sectionID string) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, "/menus/"), "/"), "/")
+	if len(parts) < 2 || parts[1] != "sections" {
+		return "", ""
+	}
+	menuID = parts[0]
+	if len(parts) > 2 {
+		sectionID = parts[2]
+	}
+	return menuID, sectionID
+}
+
+func (h *MenuSectionHandler) CreateMenuSection(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, _ := extractMenuSectionIDsFromPath(r.URL.Path)
+	if menuID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id is required")
+		return
+	}
+
+	var req models.CreateMenuSectionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	section, err := h.service.CreateMenuSection(r.Context(), menuID, &req, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, section)
+}
+
+func (h *MenuSectionHandler) GetMenuSection(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, sectionID := extractMenuSectionIDsFromPath(r.URL.Path)
+	if menuID == "" || sectionID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id and section_id are required")
+		return
+	}
+
+	section, err := h.service.GetMenuSection(r.Context(), menuID, sectionID, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, section)
+}
+
+func (h *MenuSectionHandler) UpdateMenuSection(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, sectionID := extractMenuSectionIDsFromPath(r.URL.Path)
+	if menuID == "" || sectionID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id and section_id are required")
+		return
+	}
+
+	var req models.UpdateMenuSectionRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	section, err := h.service.UpdateMenuSection(r.Context(), menuID, sectionID, &req, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, section)
+}
+
+func (h *MenuSectionHandler) DeleteMenuSection(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodDelete {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, sectionID := extractMenuSectionIDsFromPath(r.URL.Path)
+	if menuID == "" || sectionID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id and section_id are required")
+		return
+	}
+
+	err := h.service.DeleteMenuSection(r.Context(), menuID, sectionID, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *MenuSectionHandler) ListMenuSections(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, _ := extractMenuSectionIDsFromPath(r.URL.Path)
+	if menuID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id is required")
+		return
+	}
+
+	sections, err := h.service.ListMenuSections(r.Context(), menuID, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, sections)
+}
+
+// ReorderMenu handles PUT /menus/{menu_id}/order and responds with the
+// reordered menu tree.
+func (h *MenuSectionHandler) ReorderMenu(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID := extractMenuIDFromPath(r.URL.Path, "/menus/")
+	if menuID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id is required")
+		return
+	}
+
+	var req models.MenuOrderRequest
+	if !decodeJSON(w, r, &req) {
+		return
+	}
+
+	tree, err := h.service.ReorderMenu(r.Context(), menuID, &req, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tree)
+}
+
+// GetMenuTree handles GET /menus/{menu_id}?expand=tree and responds with the
+// menu, its sections and its items nested in display order.
+func (h *MenuSectionHandler) GetMenuTree(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID := extractMenuIDFromPath(r.URL.Path, "/menus/")
+	if menuID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id is required")
+		return
+	}
+
+	tree, err := h.service.GetMenuTree(r.Context(), menuID, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tree)
+}
@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+func TestReorderMenuHandler(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+	sectionRepo := memory.NewMenuSectionRepository()
+	seedSections(t, sectionRepo, &models.MenuSection{SectionID: "s1", Name: "Starters", MenuID: "m1"})
+	itemRepo := memory.NewMenuItemRepository()
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", Title: "Tea", BusinessID: "b1", MenuID: "m1"})
+
+	svc := service.NewMenuSectionService(menuRepo, sectionRepo, itemRepo, service.NewAuditService(memory.NewAuditRepository()))
+	handler := NewMenuSectionHandler(svc)
+
+	body := models.MenuOrderRequest{Sections: []models.SectionOrder{{SectionID: "s1", ItemIDs: []string{"i1"}}}}
+	bodyBytes, _ := json.Marshal(body)
+
+	req := httptest.NewRequest(http.MethodPut, "/menus/m1/order", bytes.NewReader(bodyBytes))
+	req = withBusiness(req, "b1")
+	w := httptest.NewRecorder()
+
+	handler.ReorderMenu(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+
+	var tree models.MenuTree
+	if err := json.NewDecoder(w.Body).Decode(&tree); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(tree.Sections) != 1 || len(tree.Sections[0].Items) != 1 {
+		t.Errorf("unexpected tree: %+v", tree)
+	}
+}
+
+func TestDeleteMenuSectionHandlerConflict(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+	sectionRepo := memory.NewMenuSectionRepository()
+	seedSections(t, sectionRepo, &models.MenuSection{SectionID: "s1", Name: "Starters", MenuID: "m1"})
+	itemRepo := memory.NewMenuItemRepository()
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", Title: "Tea", BusinessID: "b1", MenuID: "m1", SectionID: "s1"})
+
+	handler := NewMenuSectionHandler(service.NewMenuSectionService(menuRepo, sectionRepo, itemRepo, service.NewAuditService(memory.NewAuditRepository())))
+
+	req := httptest.NewRequest(http.MethodDelete, "/menus/m1/sections/s1", nil)
+	req = withBusiness(req, "b1")
+	w := httptest.NewRecorder()
+
+	handler.DeleteMenuSection(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", w.Code)
+	}
+}
@@ -2,18 +2,27 @@ package handler
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"testing/iotest"
+	"time"
 
+	"github.com/custard-technology/abakcus/backend/internal/auth"
 	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
 	"github.com/custard-technology/abakcus/backend/internal/service"
 )
 
 func TestCreateMenuHandler(t *testing.T) {
-	mockRepo := service.NewMockMenuRepository()
-	svc := service.NewMenuService(mockRepo)
+	menuRepo := memory.NewMenuRepository()
+	businessRepo := memory.NewBusinessRepository()
+	seedBusinesses(t, businessRepo, &models.Business{BusinessID: "biz-1", Name: "Test"})
+	svc := service.NewMenuService(menuRepo, businessRepo, service.NewAuditService(memory.NewAuditRepository()))
 	handler := NewMenuHandler(svc)
 
 	body := models.CreateMenuRequest{
@@ -23,7 +32,7 @@ func TestCreateMenuHandler(t *testing.T) {
 	bodyBytes, _ := json.Marshal(body)
 
 	req := httptest.NewRequest(http.MethodPost, "/menus", bytes.NewReader(bodyBytes))
-	req.Header.Set("X-Business-ID", "biz-1")
+	req = withBusiness(req, "biz-1")
 	w := httptest.NewRecorder()
 
 	handler.CreateMenu(w, req)
@@ -33,14 +42,65 @@ func TestCreateMenuHandler(t *testing.T) {
 	}
 }
 
+func TestCreateMenuHandlerValidation(t *testing.T) {
+	businessRepo := memory.NewBusinessRepository()
+	seedBusinesses(t, businessRepo, &models.Business{BusinessID: "biz-1", Name: "Test"})
+	handler := NewMenuHandler(service.NewMenuService(memory.NewMenuRepository(), businessRepo, service.NewAuditService(memory.NewAuditRepository())))
+
+	post := func(body string) (*httptest.ResponseRecorder, Problem) {
+		req := httptest.NewRequest(http.MethodPost, "/menus", strings.NewReader(body))
+		req = withBusiness(req, "biz-1")
+		w := httptest.NewRecorder()
+		handler.CreateMenu(w, req)
+		var p Problem
+		json.Unmarshal(w.Body.Bytes(), &p)
+		return w, p
+	}
+
+	w, p := post(`{"name":"  ","slug":"Not A Slug","description":"` + strings.Repeat("x", 2001) + `"}`)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", w.Code)
+	}
+	var fields []string
+	for _, param := range p.InvalidParams {
+		fields = append(fields, param.Name)
+	}
+	if strings.Join(fields, ",") != "name,slug,description" {
+		t.Errorf("expected every invalid field, got %+v", p.InvalidParams)
+	}
+
+	w, p = post(`{"name":"Lunch","colour":"red"}`)
+	if w.Code != http.StatusBadRequest || len(p.InvalidParams) != 1 || p.InvalidParams[0].Name != "colour" {
+		t.Errorf("expected 400 naming the unknown field, got %d %+v", w.Code, p)
+	}
+
+	w, _ = post(`{"name":"Lunch"} {"name":"Dinner"}`)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for trailing data, got %d", w.Code)
+	}
+
+	w, _ = post(`{"name":"Lunch","description":"` + strings.Repeat("x", maxRequestBodyBytes) + `"}`)
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected 413, got %d", w.Code)
+	}
+
+	w, _ = post(`{"name":"  Lunch  "}`)
+	var menu models.Menu
+	json.NewDecoder(w.Body).Decode(&menu)
+	if w.Code != http.StatusCreated || menu.Name != "Lunch" {
+		t.Errorf("expected 201 with the trimmed name, got %d %q", w.Code, menu.Name)
+	}
+}
+
 func TestGetMenuHandler(t *testing.T) {
-	mockRepo := service.NewMockMenuRepository()
-	mockRepo.SetMenu("m1", &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
 
-	svc := service.NewMenuService(mockRepo)
+	svc := service.NewMenuService(menuRepo, memory.NewBusinessRepository(), service.NewAuditService(memory.NewAuditRepository()))
 	handler := NewMenuHandler(svc)
 
 	req := httptest.NewRequest(http.MethodGet, "/menus/m1", nil)
+	req = withBusiness(req, "b1")
 	w := httptest.NewRecorder()
 
 	handler.GetMenu(w, req)
@@ -51,13 +111,15 @@ func TestGetMenuHandler(t *testing.T) {
 }
 
 func TestDeleteMenuHandler(t *testing.T) {
-	mockRepo := service.NewMockMenuRepository()
-	mockRepo.SetMenu("m1", &models.Menu{MenuID: "m1"})
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1", Revision: 1})
 
-	svc := service.NewMenuService(mockRepo)
+	svc := service.NewMenuService(menuRepo, memory.NewBusinessRepository(), service.NewAuditService(memory.NewAuditRepository()))
 	handler := NewMenuHandler(svc)
 
 	req := httptest.NewRequest(http.MethodDelete, "/menus/m1", nil)
+	req.Header.Set("If-Match", `"1"`)
+	req = withBusiness(req, "b1")
 	w := httptest.NewRecorder()
 
 	handler.DeleteMenu(w, req)
@@ -68,15 +130,15 @@ func TestDeleteMenuHandler(t *testing.T) {
 }
 
 func TestListMenusHandler(t *testing.T) {
-	mockRepo := service.NewMockMenuRepository()
-	mockRepo.SetMenu("m1", &models.Menu{MenuID: "m1", BusinessID: "b1"})
-	mockRepo.SetMenu("m2", &models.Menu{MenuID: "m2", BusinessID: "b1"})
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m2", Name: "Test", BusinessID: "b1"})
 
-	svc := service.NewMenuService(mockRepo)
+	svc := service.NewMenuService(menuRepo, memory.NewBusinessRepository(), service.NewAuditService(memory.NewAuditRepository()))
 	handler := NewMenuHandler(svc)
 
 	req := httptest.NewRequest(http.MethodGet, "/menus", nil)
-	req.Header.Set("X-Business-ID", "b1")
+	req = withBusiness(req, "b1")
 	w := httptest.NewRecorder()
 
 	handler.ListMenus(w, req)
@@ -85,3 +147,259 @@ func TestListMenusHandler(t *testing.T) {
 		t.Errorf("expected 200, got %d", w.Code)
 	}
 }
+
+// withBusiness authenticates req as an owner of businessID, as RequireAuth
+// would after verifying a token.
+func withBusiness(req *http.Request, businessID string) *http.Request {
+	ctx := auth.WithIdentity(req.Context(), auth.Identity{UserID: "u1", BusinessID: businessID, Role: auth.RoleOwner})
+	return req.WithContext(ctx)
+}
+
+func TestCreateMenuHandlerUnauthenticated(t *testing.T) {
+	handler := NewMenuHandler(service.NewMenuService(memory.NewMenuRepository(), memory.NewBusinessRepository(), service.NewAuditService(memory.NewAuditRepository())))
+
+	req := httptest.NewRequest(http.MethodPost, "/menus", bytes.NewReader([]byte(`{"name":"x"}`)))
+	req.Header.Set("X-Business-ID", "biz-1")
+	w := httptest.NewRecorder()
+
+	handler.CreateMenu(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected 401, got %d", w.Code)
+	}
+}
+
+func TestMenuHandlersOtherBusinessNotFound(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+
+	handler := NewMenuHandler(service.NewMenuService(menuRepo, memory.NewBusinessRepository(), service.NewAuditService(memory.NewAuditRepository())))
+
+	cases := []struct {
+		method string
+		body   string
+		serve  func(http.ResponseWriter, *http.Request)
+	}{
+		{http.MethodGet, "", handler.GetMenu},
+		{http.MethodPut, `{"name":"Hijacked"}`, handler.UpdateMenu},
+		{http.MethodDelete, "", handler.DeleteMenu},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(c.method, "/menus/m1", bytes.NewReader([]byte(c.body)))
+		req.Header.Set("If-Match", "*")
+		req = withBusiness(req, "b2")
+		w := httptest.NewRecorder()
+
+		c.serve(w, req)
+
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", c.method, w.Code)
+		}
+	}
+}
+
+func TestUpdateMenuHandlerIfMatch(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Lunch", Slug: "lunch", BusinessID: "b1", Revision: 3})
+	handler := NewMenuHandler(service.NewMenuService(menuRepo, memory.NewBusinessRepository(), service.NewAuditService(memory.NewAuditRepository())))
+
+	req := httptest.NewRequest(http.MethodGet, "/menus/m1", nil)
+	w := httptest.NewRecorder()
+	handler.GetMenu(w, withBusiness(req, "b1"))
+	etag := w.Header().Get("ETag")
+	if etag != `"3"` {
+		t.Fatalf("expected ETag \"3\", got %q", etag)
+	}
+
+	put := func(ifMatch, name string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, "/menus/m1", bytes.NewReader([]byte(`{"name":"`+name+`","slug":"lunch","is_active":true}`)))
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		handler.UpdateMenu(w, withBusiness(req, "b1"))
+		return w
+	}
+
+	if w := put("", "Brunch"); w.Code != http.StatusPreconditionRequired {
+		t.Errorf("without If-Match: expected 428, got %d", w.Code)
+	}
+	for _, ifMatch := range []string{`3`, `"3`, `"3", 4`, `W/"3"x`} {
+		if w := put(ifMatch, "Brunch"); w.Code != http.StatusBadRequest {
+			t.Errorf("If-Match %s: expected 400, got %d", ifMatch, w.Code)
+		}
+	}
+	for _, ifMatch := range []string{`"1", "2"`, `"abc"`, `W/"2", "5"`} {
+		if w := put(ifMatch, "Brunch"); w.Code != http.StatusPreconditionFailed {
+			t.Errorf("If-Match %s: expected 412, got %d", ifMatch, w.Code)
+		}
+	}
+
+	// the first of two editors holding the same ETag wins, named here in a
+	// list and weakened as a compressing proxy would
+	w = put(`"1", W/"3"`, "Brunch")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if got := w.Header().Get("ETag"); got != `"4"` {
+		t.Errorf("expected the new ETag \"4\", got %q", got)
+	}
+
+	w = put(etag, "Dinner")
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("stale ETag: expected 412, got %d", w.Code)
+	}
+	menu, _ := menuRepo.GetMenuByID(context.Background(), "m1", "b1")
+	if menu.Name != "Brunch" {
+		t.Errorf("expected the first edit to survive, got %q", menu.Name)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/menus/m1", nil)
+	req.Header.Set("If-Match", etag)
+	w = httptest.NewRecorder()
+	handler.DeleteMenu(w, withBusiness(req, "b1"))
+	if w.Code != http.StatusPreconditionFailed {
+		t.Errorf("stale delete: expected 412, got %d", w.Code)
+	}
+}
+
+func TestPatchMenuHandler(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Lunch", Slug: "lunch", Description: "Weekdays", BusinessID: "b1", IsActive: true, Revision: 1})
+	handler := NewMenuHandler(service.NewMenuService(menuRepo, memory.NewBusinessRepository(), service.NewAuditService(memory.NewAuditRepository())))
+
+	patch := func(contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPatch, "/menus/m1", bytes.NewReader([]byte(body)))
+		req.Header.Set("Content-Type", contentType)
+		req.Header.Set("If-Match", "*")
+		w := httptest.NewRecorder()
+		handler.PatchMenu(w, withBusiness(req, "b1"))
+		return w
+	}
+
+	w := patch("text/plain", `{"description":null}`)
+	if w.Code != http.StatusUnsupportedMediaType || w.Header().Get("Accept-Patch") != "application/merge-patch+json" {
+		t.Errorf("expected 415 with Accept-Patch, got %d %q", w.Code, w.Header().Get("Accept-Patch"))
+	}
+
+	w = patch("application/merge-patch+json", `{"description":null}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var menu models.Menu
+	json.NewDecoder(w.Body).Decode(&menu)
+	if menu.Description != "" || menu.Name != "Lunch" {
+		t.Errorf("expected only the description to be cleared, got %+v", menu)
+	}
+	if w.Header().Get("ETag") != `"2"` {
+		t.Errorf("expected ETag \"2\", got %q", w.Header().Get("ETag"))
+	}
+
+	for body, want := range map[string]int{
+		`{"name":null}`:    http.StatusUnprocessableEntity,
+		`{"name":`:         http.StatusBadRequest,
+		`{"menu_id":"m2"}`: http.StatusBadRequest,
+	} {
+		if w := patch("application/merge-patch+json; charset=utf-8", body); w.Code != want {
+			t.Errorf("%s: expected %d, got %d", body, want, w.Code)
+		}
+	}
+
+	if w := patch("application/merge-patch+json", `{"description":"`+strings.Repeat("x", maxMenuPatchBytes)+`"}`); w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized patch: expected 413, got %d", w.Code)
+	}
+
+	// a body that breaks off mid-read is a bad request, not a large one
+	req := httptest.NewRequest(http.MethodPatch, "/menus/m1", io.MultiReader(strings.NewReader(`{"name":`), iotest.ErrReader(io.ErrUnexpectedEOF)))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	req.Header.Set("If-Match", "*")
+	w = httptest.NewRecorder()
+	handler.PatchMenu(w, withBusiness(req, "b1"))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("truncated patch: expected 400, got %d", w.Code)
+	}
+}
+
+func TestRestoreMenuHandler(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	deleted := time.Now()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1", DeletedAt: &deleted})
+
+	handler := NewMenuHandler(service.NewMenuService(menuRepo, memory.NewBusinessRepository(), service.NewAuditService(memory.NewAuditRepository())))
+
+	req := httptest.NewRequest(http.MethodPost, "/menus/m1/restore", nil)
+	req = withBusiness(req, "b1")
+	w := httptest.NewRecorder()
+
+	handler.RestoreMenu(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", w.Code)
+	}
+}
+
+func TestListMenusHandlerPagination(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "A", BusinessID: "b1"})
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m2", Name: "B", BusinessID: "b1"})
+
+	handler := NewMenuHandler(service.NewMenuService(menuRepo, memory.NewBusinessRepository(), service.NewAuditService(memory.NewAuditRepository())))
+
+	req := httptest.NewRequest(http.MethodGet, "/menus?limit=1&sort=name", nil)
+	req = withBusiness(req, "b1")
+	w := httptest.NewRecorder()
+	handler.ListMenus(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	var menus []models.Menu
+	if err := json.NewDecoder(w.Body).Decode(&menus); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(menus) != 1 || menus[0].MenuID != "m1" {
+		t.Errorf("unexpected first page: %+v", menus)
+	}
+	cursor := w.Header().Get("X-Next-Cursor")
+	if cursor == "" {
+		t.Fatal("expected X-Next-Cursor header")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/menus?limit=1&sort=name&cursor="+cursor, nil)
+	req = withBusiness(req, "b1")
+	w = httptest.NewRecorder()
+	handler.ListMenus(w, req)
+
+	menus = nil
+	if err := json.NewDecoder(w.Body).Decode(&menus); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(menus) != 1 || menus[0].MenuID != "m2" {
+		t.Errorf("unexpected second page: %+v", menus)
+	}
+	if w.Header().Get("X-Next-Cursor") != "" {
+		t.Error("last page should not have a next cursor")
+	}
+}
+
+func TestListMenusHandlerBadQuery(t *testing.T) {
+	handler := NewMenuHandler(service.NewMenuService(memory.NewMenuRepository(), memory.NewBusinessRepository(), service.NewAuditService(memory.NewAuditRepository())))
+
+	for _, query := range []string{"limit=abc", "is_active=maybe", "created_after=yesterday", "updated_before=2026-13-01", "sort=price"} {
+		req := httptest.NewRequest(http.MethodGet, "/menus?"+query, nil)
+		req = withBusiness(req, "b1")
+		w := httptest.NewRecorder()
+
+		handler.ListMenus(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, w.Code)
+		}
+		var p Problem
+		json.Unmarshal(w.Body.Bytes(), &p)
+		param, _, _ := strings.Cut(query, "=")
+		if len(p.InvalidParams) != 1 || p.InvalidParams[0].Name != param {
+			t.Errorf("%s: expected invalid_params to name %s, got %+v", query, param, p.InvalidParams)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/custard-technology/abakcus/backend/internal/auth"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+type MenuVersionHandler struct {
+	service *service.MenuVersionService
+}
+
+func NewMenuVersionHandler(svc *service.MenuVersionService) *MenuVersionHandler {
+	return &MenuVersionHandler{service: svc}
+}
+
+// extractMenuVersionFromPath parses /menus/{menu_id}/versions[/{number}[/...]].
+// number is 0 when the path addresses the collection and -1 when it is not a
+// positive integer.
+func extractMenuVersionFromPath(path string) (menuID string, number int) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(path, "/menus/"), "/"), "/")
+	if len(parts) < 2 || parts[1] != "versions" {
+		return parts[0], 0
+	}
+	menuID = parts[0]
+	if len(parts) > 2 {
+		n, err := strconv.Atoi(parts[2])
+		if err != nil || n < 1 {
+			return menuID, -1
+		}
+		number = n
+	}
+	return menuID, number
+}
+
+// PublishMenu handles POST /menus/{menu_id}/publish and responds with the new
+// version.
+func (h *MenuVersionHandler) PublishMenu(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	id, ok := auth.FromContext(r.Context())
+	if !ok || id.BusinessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID := extractMenuIDFromPath(r.URL.Path, "/menus/")
+	if menuID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id is required")
+		return
+	}
+
+	version, err := h.service.PublishMenu(r.Context(), menuID, id.BusinessID, id.UserID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, version)
+}
+
+// ListMenuVersions handles GET /menus/{menu_id}/versions. Versions are listed
+// newest first without their sections and items.
+func (h *MenuVersionHandler) ListMenuVersions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, _ := extractMenuVersionFromPath(r.URL.Path)
+	if menuID == "" {
+		respondError(w, http.StatusBadRequest, "menu_id is required")
+		return
+	}
+
+	versions, err := h.service.ListMenuVersions(r.Context(), menuID, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, versions)
+}
+
+// GetMenuVersion handles GET /menus/{menu_id}/versions/{number} and responds
+// with the complete snapshot.
+func (h *MenuVersionHandler) GetMenuVersion(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	businessID := getBusinessIDFromContext(r)
+	if businessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, number := extractMenuVersionFromPath(r.URL.Path)
+	if menuID == "" || number < 1 {
+		respondError(w, http.StatusBadRequest, "menu_id and a positive version number are required")
+		return
+	}
+
+	version, err := h.service.GetMenuVersion(r.Context(), menuID, number, businessID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, version)
+}
+
+// RollbackMenu handles POST /menus/{menu_id}/versions/{number}/rollback. It
+// publishes a copy of that version as a new version and responds with it.
+func (h *MenuVersionHandler) RollbackMenu(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	id, ok := auth.FromContext(r.Context())
+	if !ok || id.BusinessID == "" {
+		respondError(w, http.StatusUnauthorized, "authentication required")
+		return
+	}
+
+	menuID, number := extractMenuVersionFromPath(r.URL.Path)
+	if menuID == "" || number < 1 {
+		respondError(w, http.StatusBadRequest, "menu_id and a positive version number are required")
+		return
+	}
+
+	version, err := h.service.RollbackMenu(r.Context(), menuID, number, id.BusinessID, id.UserID)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, version)
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+func newTestVersionHandler(t *testing.T) *MenuVersionHandler {
+	t.Helper()
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Dinner", BusinessID: "b1"})
+	svc := service.NewMenuVersionService(menuRepo, memory.NewMenuSectionRepository(), memory.NewMenuItemRepository(), memory.NewMenuVersionRepository(), service.NewAuditService(memory.NewAuditRepository()))
+	return NewMenuVersionHandler(svc)
+}
+
+func TestMenuVersionHandlers(t *testing.T) {
+	handler := newTestVersionHandler(t)
+
+	for i := 1; i <= 2; i++ {
+		req := withBusiness(httptest.NewRequest(http.MethodPost, "/menus/m1/publish", nil), "b1")
+		w := httptest.NewRecorder()
+		handler.PublishMenu(w, req)
+		if w.Code != http.StatusCreated {
+			t.Fatalf("publish: expected 201, got %d", w.Code)
+		}
+	}
+
+	req := withBusiness(httptest.NewRequest(http.MethodGet, "/menus/m1/versions", nil), "b1")
+	w := httptest.NewRecorder()
+	handler.ListMenuVersions(w, req)
+	var versions []models.MenuVersion
+	if err := json.NewDecoder(w.Body).Decode(&versions); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Number != 2 || versions[0].PublishedBy != "u1" {
+		t.Errorf("unexpected versions: %+v", versions)
+	}
+
+	req = withBusiness(httptest.NewRequest(http.MethodGet, "/menus/m1/versions/1", nil), "b1")
+	w = httptest.NewRecorder()
+	handler.GetMenuVersion(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("get version: expected 200, got %d", w.Code)
+	}
+
+	req = withBusiness(httptest.NewRequest(http.MethodPost, "/menus/m1/versions/1/rollback", nil), "b1")
+	w = httptest.NewRecorder()
+	handler.RollbackMenu(w, req)
+	if w.Code != http.StatusCreated {
+		t.Fatalf("rollback: expected 201, got %d", w.Code)
+	}
+	var version models.MenuVersion
+	if err := json.NewDecoder(w.Body).Decode(&version); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if version.Number != 3 || version.RestoredFrom != 1 {
+		t.Errorf("unexpected rollback version: %+v", version)
+	}
+}
+
+func TestMenuVersionHandlerErrors(t *testing.T) {
+	handler := newTestVersionHandler(t)
+
+	cases := []struct {
+		target string
+		serve  func(http.ResponseWriter, *http.Request)
+		method string
+		status int
+	}{
+		{"/menus/m1/versions/abc", handler.GetMenuVersion, http.MethodGet, http.StatusBadRequest},
+		{"/menus/m1/versions/7", handler.GetMenuVersion, http.MethodGet, http.StatusNotFound},
+		{"/menus/m1/versions/0/rollback", handler.RollbackMenu, http.MethodPost, http.StatusBadRequest},
+		{"/menus/m1/versions/7/rollback", handler.RollbackMenu, http.MethodPost, http.StatusNotFound},
+		{"/menus/m2/publish", handler.PublishMenu, http.MethodPost, http.StatusNotFound},
+		{"/menus/m1/publish", handler.PublishMenu, http.MethodGet, http.StatusMethodNotAllowed},
+	}
+	for _, c := range cases {
+		req := withBusiness(httptest.NewRequest(c.method, c.target, nil), "b1")
+		w := httptest.NewRecorder()
+		c.serve(w, req)
+		if w.Code != c.status {
+			t.Errorf("%s %s: expected %d, got %d", c.method, c.target, c.status, w.Code)
+		}
+	}
+}
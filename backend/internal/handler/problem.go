@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/imaging"
+	"github.com/custard-technology/abakcus/backend/internal/mergepatch"
+)
+
+// ProblemContentType is the media type of error responses (RFC 7807).
+const ProblemContentType = "application/problem+json"
+
+// Problem is the body of every error response. Type is always "about:blank",
+// so Title is the standard text of Status; Detail says what went wrong.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	RequestID     string         `json:"request_id,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"`
+}
+
+// InvalidParam names an input field that failed validation.
+type InvalidParam struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason"`
+}
+
+func respondProblem(w http.ResponseWriter, p Problem) {
+	p.Type = "about:blank"
+	p.Title = http.StatusText(p.Status)
+	p.RequestID = w.Header().Get(RequestIDHeader)
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	if err := json.NewEncoder(w).Encode(p); err != nil {
+		log.Printf("error encoding response: %v", err)
+	}
+}
+
+func respondError(w http.ResponseWriter, statusCode int, detail string) {
+	respondProblem(w, Problem{Status: statusCode, Detail: detail})
+}
+
+// respondServiceError writes the problem for an error returned by a service.
+// The status comes from errorStatus; the detail of a 500 is not shown to the
+// client, only logged.
+func respondServiceError(w http.ResponseWriter, err error) {
+	status := errorStatus(err)
+	if status == http.StatusInternalServerError {
+		log.Printf("internal error: %v", err)
+		respondError(w, status, "internal server error")
+		return
+	}
+
+	p := Problem{Status: status, Detail: err.Error()}
+	if status == http.StatusBadRequest || status == http.StatusUnprocessableEntity {
+		for _, f := range apperr.FieldsOf(err) {
+			p.InvalidParams = append(p.InvalidParams, InvalidParam{Name: f.Field, Reason: f.Message})
+		}
+	}
+	respondProblem(w, p)
+}
+
+// errorStatus maps an error to its HTTP status code. It is the only place
+// that does so: errors are told apart by kind, never by message.
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, apperr.ErrValidation), errors.Is(err, mergepatch.ErrInvalidJSON):
+		return http.StatusBadRequest
+	case errors.Is(err, apperr.ErrInvalidFields):
+		return http.StatusUnprocessableEntity
+	case errors.Is(err, apperr.ErrUnauthorized):
+		return http.StatusUnauthorized
+	case errors.Is(err, apperr.ErrForbidden):
+		return http.StatusForbidden
+	case errors.Is(err, apperr.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, apperr.ErrConflict):
+		return http.StatusConflict
+	case errors.Is(err, apperr.ErrPreconditionFailed):
+		return http.StatusPreconditionFailed
+	case errors.Is(err, imaging.ErrTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, imaging.ErrUnsupportedFormat):
+		return http.StatusUnsupportedMediaType
+	}
+	return http.StatusInternalServerError
+}
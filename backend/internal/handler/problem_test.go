@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+func TestRespondServiceError(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		detail string
+		field  string
+	}{
+		{apperr.Required("menu_id"), http.StatusBadRequest, "menu_id is required", "menu_id"},
+		{apperr.NotFound("menu"), http.StatusNotFound, "menu not found", ""},
+		{apperr.Conflict("menu section still has items"), http.StatusConflict, "menu section still has items", ""},
+		{apperr.New(apperr.ErrForbidden, "owners only"), http.StatusForbidden, "owners only", ""},
+		{service.ErrInvalidCredentials, http.StatusUnauthorized, service.ErrInvalidCredentials.Error(), ""},
+		{service.ErrMenuModified, http.StatusPreconditionFailed, service.ErrMenuModified.Error(), ""},
+		{errors.New("connection reset by peer"), http.StatusInternalServerError, "internal server error", ""},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		w.Header().Set(RequestIDHeader, "req-1")
+		respondServiceError(w, tt.err)
+
+		if w.Code != tt.status {
+			t.Errorf("%v: expected %d, got %d", tt.err, tt.status, w.Code)
+		}
+		if got := w.Header().Get("Content-Type"); got != ProblemContentType {
+			t.Errorf("%v: expected %s, got %q", tt.err, ProblemContentType, got)
+		}
+		var p Problem
+		if err := json.NewDecoder(w.Body).Decode(&p); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if p.Type != "about:blank" || p.Status != tt.status || p.Title != http.StatusText(tt.status) {
+			t.Errorf("%v: unexpected problem %+v", tt.err, p)
+		}
+		if p.Detail != tt.detail || p.RequestID != "req-1" {
+			t.Errorf("%v: expected detail %q and request req-1, got %+v", tt.err, tt.detail, p)
+		}
+		if tt.field == "" && len(p.InvalidParams) != 0 {
+			t.Errorf("%v: expected no invalid params, got %+v", tt.err, p.InvalidParams)
+		}
+		if tt.field != "" && (len(p.InvalidParams) != 1 || p.InvalidParams[0].Name != tt.field) {
+			t.Errorf("%v: expected invalid param %s, got %+v", tt.err, tt.field, p.InvalidParams)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+// PublicMenuHandler serves the read-only menu that diners open from a QR
+// code. Its routes are not wrapped in RequireAuth.
+type PublicMenuHandler struct {
+	service *service.PublicMenuService
+}
+
+func NewPublicMenuHandler(svc *service.PublicMenuService) *PublicMenuHandler {
+	return &PublicMenuHandler{service: svc}
+}
+
+// GetPublicMenu handles GET /public/menus/{slug}. The response carries an
+// ETag derived from the body and a Last-Modified time, and conditional
+// requests that still match are answered with 304 Not Modified.
+//
+// Query parameters: exclude_allergens and diet, each a comma-separated list
+// or repeated, e.g. ?exclude_allergens=milk,peanuts&diet=vegetarian.
+func (h *PublicMenuHandler) GetPublicMenu(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		respondError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	slug := strings.Trim(strings.TrimPrefix(r.URL.Path, "/public/menus/"), "/")
+	if slug == "" || strings.Contains(slug, "/") {
+		respondError(w, http.StatusNotFound, "menu not found")
+		return
+	}
+
+	filter := models.PublicMenuFilter{
+		ExcludeAllergens: splitQueryList(r.URL.Query()["exclude_allergens"]),
+		Diets:            splitQueryList(r.URL.Query()["diet"]),
+	}
+
+	menu, err := h.service.GetPublicMenu(r.Context(), slug, filter)
+	if err != nil {
+		respondServiceError(w, err)
+		return
+	}
+
+	body, err := json.Marshal(menu)
+	if err != nil {
+		log.Printf("error encoding response: %v", err)
+		respondError(w, http.StatusInternalServerError, "internal server error")
+		return
+	}
+	sum := sha256.Sum256(body)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, no-cache")
+	w.Header().Set("ETag", `"`+hex.EncodeToString(sum[:16])+`"`)
+	// ServeContent evaluates If-None-Match and If-Modified-Since and answers
+	// 304 when the diner's copy is still current.
+	http.ServeContent(w, r, "", menu.UpdatedAt, bytes.NewReader(body))
+}
+
+// splitQueryList flattens repeated and comma-separated query values.
+func splitQueryList(values []string) []string {
+	var list []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				list = append(list, part)
+			}
+		}
+	}
+	return list
+}
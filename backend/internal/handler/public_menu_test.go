@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/service"
+)
+
+func newTestPublicMenuHandler(t *testing.T) *PublicMenuHandler {
+	t.Helper()
+	published := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{
+		MenuID:           "m1",
+		Name:             "Dinner",
+		Slug:             "dinner",
+		BusinessID:       "b1",
+		IsActive:         true,
+		UpdatedAt:        published.Add(-time.Hour),
+		PublishedVersion: 1,
+		PublishedAt:      &published,
+	})
+	versionRepo := memory.NewMenuVersionRepository()
+	err := versionRepo.CreateMenuVersion(context.Background(), &models.MenuVersion{
+		VersionID:   "v1",
+		MenuID:      "m1",
+		BusinessID:  "b1",
+		Number:      1,
+		Name:        "Dinner",
+		PublishedAt: published,
+	})
+	if err != nil {
+		t.Fatalf("seeding version: %v", err)
+	}
+	businessRepo := memory.NewBusinessRepository()
+	seedBusinesses(t, businessRepo, &models.Business{BusinessID: "b1", Name: "Test"})
+	svc := service.NewPublicMenuService(menuRepo, versionRepo, businessRepo)
+	return NewPublicMenuHandler(svc)
+}
+
+func TestGetPublicMenuHandler(t *testing.T) {
+	handler := newTestPublicMenuHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/public/menus/dinner", nil)
+	w := httptest.NewRecorder()
+	handler.GetPublicMenu(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if strings.Contains(w.Body.String(), "business_id") || strings.Contains(w.Body.String(), "m1") {
+		t.Errorf("public menu leaks internal fields: %s", w.Body.String())
+	}
+	etag := w.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected ETag header")
+	}
+	if got := w.Header().Get("Last-Modified"); got != "Sun, 01 Mar 2026 12:00:00 GMT" {
+		t.Errorf("unexpected Last-Modified %q", got)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/public/menus/dinner", nil)
+	req.Header.Set("If-None-Match", etag)
+	w = httptest.NewRecorder()
+	handler.GetPublicMenu(w, req)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for matching ETag, got %d", w.Code)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/public/menus/dinner", nil)
+	req.Header.Set("If-Modified-Since", "Sun, 01 Mar 2026 12:00:00 GMT")
+	w = httptest.NewRecorder()
+	handler.GetPublicMenu(w, req)
+
+	if w.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for unchanged menu, got %d", w.Code)
+	}
+}
+
+func TestGetPublicMenuHandlerNotFound(t *testing.T) {
+	handler := newTestPublicMenuHandler(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/public/menus/lunch", nil)
+	w := httptest.NewRecorder()
+	handler.GetPublicMenu(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+}
+
+func TestGetPublicMenuHandlerFilters(t *testing.T) {
+	handler := newTestPublicMenuHandler(t)
+
+	cases := map[string]int{
+		"/public/menus/dinner?exclude_allergens=milk,peanuts&diet=vegan": http.StatusOK,
+		"/public/menus/dinner?diet=vegan&diet=halal":                     http.StatusOK,
+		"/public/menus/dinner?exclude_allergens=chocolate":               http.StatusBadRequest,
+	}
+	for target, want := range cases {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		handler.GetPublicMenu(w, req)
+
+		if w.Code != want {
+			t.Errorf("%s: expected %d, got %d", target, want, w.Code)
+		}
+	}
+}
+
+func TestGetPublicMenuHandlerUnavailable(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{
+		MenuID:     "m1",
+		Name:       "Dinner",
+		Slug:       "dinner",
+		BusinessID: "b1",
+		IsActive:   true,
+		Schedule: &models.MenuSchedule{Overrides: []models.DateOverride{
+			{From: "2000-01-01", To: "2999-12-31", Closed: true},
+		}},
+	})
+	businessRepo := memory.NewBusinessRepository()
+	seedBusinesses(t, businessRepo, &models.Business{BusinessID: "b1", Name: "Test"})
+	versionRepo := memory.NewMenuVersionRepository()
+	versions := service.NewMenuVersionService(menuRepo, memory.NewMenuSectionRepository(), memory.NewMenuItemRepository(), versionRepo, service.NewAuditService(memory.NewAuditRepository()))
+	if _, err := versions.PublishMenu(context.Background(), "m1", "b1", "u1"); err != nil {
+		t.Fatalf("publish: %v", err)
+	}
+	handler := NewPublicMenuHandler(service.NewPublicMenuService(menuRepo, versionRepo, businessRepo))
+
+	req := httptest.NewRequest(http.MethodGet, "/public/menus/dinner", nil)
+	w := httptest.NewRecorder()
+	handler.GetPublicMenu(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "not available") {
+		t.Errorf("expected unavailable error, got %s", w.Body.String())
+	}
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/requestid"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = requestid.From(r.Context())
+	}))
+
+	tests := []struct {
+		header string
+		reuse  bool
+	}{
+		{"abc-123", true},
+		{"", false},
+		{"bad id\r\nX-Injected: 1", false},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		if tt.header != "" {
+			req.Header.Set(RequestIDHeader, tt.header)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		got := w.Header().Get(RequestIDHeader)
+		if got == "" || got != seen {
+			t.Errorf("%q: expected the echoed ID %q to match the context %q", tt.header, got, seen)
+		}
+		if (got == tt.header) != tt.reuse {
+			t.Errorf("%q: expected reuse %v, got ID %q", tt.header, tt.reuse, got)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// The handler tests run against the in-memory repositories and seed them
+// through the repository interfaces.
+
+func seedMenus(t *testing.T, repo mongo.MenuRepositoryI, menus ...*models.Menu) {
+	t.Helper()
+	for _, menu := range menus {
+		if err := repo.CreateMenu(context.Background(), menu); err != nil {
+			t.Fatalf("seeding menu %s: %v", menu.MenuID, err)
+		}
+	}
+}
+
+func seedSections(t *testing.T, repo mongo.MenuSectionRepositoryI, sections ...*models.MenuSection) {
+	t.Helper()
+	for _, section := range sections {
+		if err := repo.CreateMenuSection(context.Background(), section); err != nil {
+			t.Fatalf("seeding section %s: %v", section.SectionID, err)
+		}
+	}
+}
+
+func seedItems(t *testing.T, repo mongo.MenuItemRepositoryI, items ...*models.MenuItem) {
+	t.Helper()
+	for _, item := range items {
+		if err := repo.CreateMenuItem(context.Background(), item); err != nil {
+			t.Fatalf("seeding item %s: %v", item.ItemID, err)
+		}
+	}
+}
+
+func seedBusinesses(t *testing.T, repo mongo.BusinessRepositoryI, businesses ...*models.Business) {
+	t.Helper()
+	for _, business := range businesses {
+		if err := repo.CreateBusiness(context.Background(), business); err != nil {
+			t.Fatalf("seeding business %s: %v", business.BusinessID, err)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Package imaging validates uploaded images and renders their thumbnails.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	"golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+)
+
+// Limits applied to uploads.
+const (
+	// MaxUploadBytes is the largest accepted upload.
+	MaxUploadBytes = 10 << 20
+	// MaxPixels bounds the decoded size so a small, highly compressed file
+	// cannot expand into gigabytes of memory.
+	MaxPixels = 40_000_000
+	// JPEGQuality is used for JPEG thumbnails.
+	JPEGQuality = 85
+)
+
+// ThumbnailWidths are the widths in pixels rendered for every upload. Widths
+// larger than the original are skipped; images are never upscaled.
+var ThumbnailWidths = []int{160, 320, 640, 1280}
+
+var (
+	// ErrTooLarge is returned for uploads over MaxUploadBytes or MaxPixels.
+	ErrTooLarge = errors.New("image is too large")
+	// ErrUnsupportedFormat is returned when the content is not a JPEG, PNG,
+	// GIF or WebP image, whatever the client claimed it was.
+	ErrUnsupportedFormat = errors.New("image must be a JPEG, PNG, GIF or WebP file")
+)
+
+var decoders = map[string]func(io.Reader) (image.Image, error){
+	"image/jpeg": jpeg.Decode,
+	"image/png":  png.Decode,
+	"image/gif":  gif.Decode,
+	"image/webp": webp.Decode,
+}
+
+var configDecoders = map[string]func(io.Reader) (image.Config, error){
+	"image/jpeg": jpeg.DecodeConfig,
+	"image/png":  png.DecodeConfig,
+	"image/gif":  gif.DecodeConfig,
+	"image/webp": webp.DecodeConfig,
+}
+
+// Image is a decoded upload.
+type Image struct {
+	// ContentType is sniffed from the data, not taken from the request.
+	ContentType string
+	Width       int
+	Height      int
+
+	img image.Image
+}
+
+// Extension returns the file extension for the image's content type.
+func (i *Image) Extension() string {
+	return extension(i.ContentType)
+}
+
+// Decode sniffs and decodes data. The dimensions are checked before the
+// pixels are decoded. Data that has an image's signature but does not decode
+// is reported as an apperr validation error on the image field.
+func Decode(data []byte) (*Image, error) {
+	if len(data) > MaxUploadBytes {
+		return nil, ErrTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	decode, ok := decoders[contentType]
+	if !ok {
+		return nil, ErrUnsupportedFormat
+	}
+
+	cfg, err := configDecoders[contentType](bytes.NewReader(data))
+	if err != nil {
+		return nil, apperr.Invalidf("image", "image must be a valid %s file: %v", contentType, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return nil, apperr.Invalid("image", "image must have a width and height")
+	}
+	if cfg.Width*cfg.Height > MaxPixels {
+		return nil, ErrTooLarge
+	}
+
+	img, err := decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, apperr.Invalidf("image", "image must be a valid %s file: %v", contentType, err)
+	}
+
+	bounds := img.Bounds()
+	return &Image{ContentType: contentType, Width: bounds.Dx(), Height: bounds.Dy(), img: img}, nil
+}
+
+// Thumbnail is an encoded, resized copy of an Image.
+type Thumbnail struct {
+	ContentType string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+// Extension returns the file extension for the thumbnail's content type.
+func (t *Thumbnail) Extension() string {
+	return extension(t.ContentType)
+}
+
+// Thumbnails renders the image at each of ThumbnailWidths that is narrower
+// than the original, keeping the aspect ratio. Opaque images are encoded as
+// JPEG and images with transparency as PNG.
+func (i *Image) Thumbnails() ([]Thumbnail, error) {
+	contentType := "image/jpeg"
+	if !isOpaque(i.img) {
+		contentType = "image/png"
+	}
+
+	var thumbs []Thumbnail
+	for _, width := range ThumbnailWidths {
+		if width >= i.Width {
+			break
+		}
+		height := max(1, (i.Height*width+i.Width/2)/i.Width)
+
+		dst := image.NewRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(dst, dst.Bounds(), i.img, i.img.Bounds(), draw.Src, nil)
+
+		var buf bytes.Buffer
+		var err error
+		if contentType == "image/jpeg" {
+			err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: JPEGQuality})
+		} else {
+			err = png.Encode(&buf, dst)
+		}
+		if err != nil {
+			return nil, err
+		}
+		thumbs = append(thumbs, Thumbnail{ContentType: contentType, Width: width, Height: height, Data: buf.Bytes()})
+	}
+
+	return thumbs, nil
+}
+
+func isOpaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+	return false
+}
+
+func extension(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return "jpg"
+	case "image/png":
+		return "png"
+	case "image/gif":
+		return "gif"
+	case "image/webp":
+		return "webp"
+	}
+	return "bin"
+}
@@ -0,0 +1,142 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeSniffsContentType(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 30))
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := Decode(buf.Bytes())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if decoded.ContentType != "image/jpeg" || decoded.Extension() != "jpg" {
+		t.Errorf("unexpected content type %q", decoded.ContentType)
+	}
+	if decoded.Width != 40 || decoded.Height != 30 {
+		t.Errorf("unexpected size %dx%d", decoded.Width, decoded.Height)
+	}
+
+	for _, data := range [][]byte{[]byte("<svg xmlns='http://www.w3.org/2000/svg'/>"), []byte("%PDF-1.7"), {}} {
+		if _, err := Decode(data); !errors.Is(err, ErrUnsupportedFormat) {
+			t.Errorf("expected ErrUnsupportedFormat for %q, got %v", data, err)
+		}
+	}
+
+	// a PNG signature followed by garbage
+	if _, err := Decode([]byte("\x89PNG\r\n\x1a\nnot really")); !errors.Is(err, apperr.ErrValidation) {
+		t.Errorf("expected a decoding error, got %v", err)
+	}
+}
+
+func TestDecodeRejectsHugeDimensions(t *testing.T) {
+	// a GIF header claiming 10000x10000 pixels, checked before decoding
+	data := []byte("GIF89a")
+	data = binary.LittleEndian.AppendUint16(data, 10000)
+	data = binary.LittleEndian.AppendUint16(data, 10000)
+	data = append(data, 0, 0, 0, ',', 0, 0, 0, 0)
+	data = binary.LittleEndian.AppendUint16(data, 10000)
+	data = binary.LittleEndian.AppendUint16(data, 10000)
+	data = append(data, 0)
+
+	if _, err := Decode(data); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expected ErrTooLarge, got %v", err)
+	}
+
+	if _, err := Decode(make([]byte, MaxUploadBytes+1)); !errors.Is(err, ErrTooLarge) {
+		t.Errorf("expected ErrTooLarge for oversized data, got %v", err)
+	}
+}
+
+func TestThumbnails(t *testing.T) {
+	opaque := image.NewRGBA(image.Rect(0, 0, 800, 600))
+	for i := range opaque.Pix {
+		opaque.Pix[i] = 0xff
+	}
+	decoded, err := Decode(encodePNG(t, opaque))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	thumbs, err := decoded.Thumbnails()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 1280 is wider than the original and skipped
+	if len(thumbs) != 3 {
+		t.Fatalf("expected 3 thumbnails, got %d", len(thumbs))
+	}
+	wantHeights := map[int]int{160: 120, 320: 240, 640: 480}
+	for _, thumb := range thumbs {
+		if thumb.Height != wantHeights[thumb.Width] {
+			t.Errorf("width %d: expected height %d, got %d", thumb.Width, wantHeights[thumb.Width], thumb.Height)
+		}
+		if thumb.ContentType != "image/jpeg" {
+			t.Errorf("expected opaque image to give JPEG thumbnails, got %s", thumb.ContentType)
+		}
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(thumb.Data))
+		if err != nil || cfg.Width != thumb.Width || cfg.Height != thumb.Height {
+			t.Errorf("thumbnail does not decode to %dx%d: %+v %v", thumb.Width, thumb.Height, cfg, err)
+		}
+	}
+}
+
+func TestThumbnailsKeepTransparency(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 400, 100))
+	img.Set(0, 0, color.NRGBA{R: 255, A: 128})
+	decoded, err := Decode(encodePNG(t, img))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	thumbs, err := decoded.Thumbnails()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(thumbs) != 2 {
+		t.Fatalf("expected 2 thumbnails, got %d", len(thumbs))
+	}
+	if thumbs[0].ContentType != "image/png" || thumbs[0].Extension() != "png" {
+		t.Errorf("expected PNG thumbnails, got %s", thumbs[0].ContentType)
+	}
+	if thumbs[0].Width != 160 || thumbs[0].Height != 40 {
+		t.Errorf("unexpected size %dx%d", thumbs[0].Width, thumbs[0].Height)
+	}
+}
+
+func TestThumbnailsNeverUpscale(t *testing.T) {
+	decoded, err := Decode(encodePNG(t, image.NewRGBA(image.Rect(0, 0, 100, 100))))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	thumbs, err := decoded.Thumbnails()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(thumbs) != 0 {
+		t.Errorf("expected no thumbnails for a small image, got %d", len(thumbs))
+	}
+}
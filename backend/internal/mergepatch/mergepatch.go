@@ -0,0 +1,71 @@
+// Package mergepatch applies JSON Merge Patches as defined by RFC 7396.
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// ContentType is the media type of a JSON Merge Patch document.
+const ContentType = "application/merge-patch+json"
+
+// ErrInvalidJSON is returned by Apply when the document or the patch is not
+// valid JSON.
+var ErrInvalidJSON = errors.New("merge patch must be valid JSON")
+
+// Apply returns doc with patch merged into it. Members of a patch object
+// replace the members of the same name in doc, objects are merged
+// recursively, and null members remove them. A patch that is not an object
+// replaces doc as a whole.
+func Apply(doc, patch []byte) ([]byte, error) {
+	target, err := decode(doc)
+	if err != nil {
+		return nil, err
+	}
+	p, err := decode(patch)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(merge(target, p))
+}
+
+// IsObject reports whether data holds a JSON object, the only kind of patch
+// that changes individual members of a document.
+func IsObject(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{' && json.Valid(data)
+}
+
+func decode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	// numbers pass through untouched instead of being rounded to float64
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, ErrInvalidJSON
+	}
+	if dec.More() {
+		return nil, ErrInvalidJSON
+	}
+	return v, nil
+}
+
+func merge(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = map[string]any{}
+	}
+	for name, value := range p {
+		if value == nil {
+			delete(t, name)
+			continue
+		}
+		t[name] = merge(t[name], value)
+	}
+	return t
+}
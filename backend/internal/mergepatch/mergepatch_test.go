@@ -0,0 +1,70 @@
+package mergepatch
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestApplyRFCExamples runs the examples of RFC 7396, Appendix A.
+func TestApplyRFCExamples(t *testing.T) {
+	tests := []struct {
+		doc, patch, want string
+	}{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+
+	for _, tt := range tests {
+		got, err := Apply([]byte(tt.doc), []byte(tt.patch))
+		if err != nil {
+			t.Errorf("%s + %s: unexpected error: %v", tt.doc, tt.patch, err)
+			continue
+		}
+		var gotValue, wantValue any
+		json.Unmarshal(got, &gotValue)
+		json.Unmarshal([]byte(tt.want), &wantValue)
+		if !reflect.DeepEqual(gotValue, wantValue) {
+			t.Errorf("%s + %s: expected %s, got %s", tt.doc, tt.patch, tt.want, got)
+		}
+	}
+}
+
+func TestApplyKeepsNumbers(t *testing.T) {
+	got, err := Apply([]byte(`{"price":12345678901234567890}`), []byte(`{"name":"x"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != `{"name":"x","price":12345678901234567890}` {
+		t.Errorf("expected the number to survive, got %s", got)
+	}
+}
+
+func TestApplyInvalidJSON(t *testing.T) {
+	for _, patch := range []string{``, `{`, `{"a":1} {"b":2}`} {
+		if _, err := Apply([]byte(`{}`), []byte(patch)); err != ErrInvalidJSON {
+			t.Errorf("%q: expected ErrInvalidJSON, got %v", patch, err)
+		}
+	}
+}
+
+func TestIsObject(t *testing.T) {
+	for data, want := range map[string]bool{` {"a":1}`: true, `{}`: true, `[]`: false, `null`: false, `{`: false} {
+		if got := IsObject([]byte(data)); got != want {
+			t.Errorf("%q: expected %v, got %v", data, want, got)
+		}
+	}
+}
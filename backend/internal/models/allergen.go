@@ -0,0 +1,58 @@
+package models
+
+// Allergens are the 14 allergens that EU Regulation 1169/2011 requires food
+// businesses to declare.
+const (
+	AllergenCelery      = "celery"
+	AllergenGluten      = "gluten"
+	AllergenCrustaceans = "crustaceans"
+	AllergenEggs        = "eggs"
+	AllergenFish        = "fish"
+	AllergenLupin       = "lupin"
+	AllergenMilk        = "milk"
+	AllergenMolluscs    = "molluscs"
+	AllergenMustard     = "mustard"
+	AllergenTreeNuts    = "tree-nuts"
+	AllergenPeanuts     = "peanuts"
+	AllergenSesame      = "sesame"
+	AllergenSoy         = "soy"
+	AllergenSulphites   = "sulphites"
+)
+
+// Dietary tags an item can carry.
+const (
+	DietVegan      = "vegan"
+	DietVegetarian = "vegetarian"
+	DietHalal      = "halal"
+	DietGlutenFree = "gluten-free"
+)
+
+// Allergens lists every valid allergen code in declaration order.
+var Allergens = []string{
+	AllergenCelery, AllergenGluten, AllergenCrustaceans, AllergenEggs,
+	AllergenFish, AllergenLupin, AllergenMilk, AllergenMolluscs,
+	AllergenMustard, AllergenTreeNuts, AllergenPeanuts, AllergenSesame,
+	AllergenSoy, AllergenSulphites,
+}
+
+// DietaryTags lists every valid dietary tag.
+var DietaryTags = []string{DietVegan, DietVegetarian, DietHalal, DietGlutenFree}
+
+// IsValidAllergen reports whether code is one of Allergens.
+func IsValidAllergen(code string) bool {
+	return contains(Allergens, code)
+}
+
+// IsValidDietaryTag reports whether tag is one of DietaryTags.
+func IsValidDietaryTag(tag string) bool {
+	return contains(DietaryTags, tag)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+)
+
+// Audit actions.
+const (
+	AuditCreate   = "create"
+	AuditUpdate   = "update"
+	AuditDelete   = "delete"
+	AuditRestore  = "restore"
+	AuditPublish  = "publish"
+	AuditRollback = "rollback"
+	AuditPurge    = "purge"
+)
+
+// Audited entity types. Entity references have the form "type:id", e.g.
+// "menu:3f2c..." or "item:9a1b...".
+const (
+	AuditEntityMenu    = "menu"
+	AuditEntitySection = "section"
+	AuditEntityItem    = "item"
+)
+
+// AuditEvent records one change made to a business's data: who made it,
+// through which request, and the fields it changed.
+type AuditEvent struct {
+	EventID    string `bson:"_id" json:"event_id"`
+	BusinessID string `bson:"business_id" json:"business_id"`
+	// ActorID is the user who made the change; it is empty for changes made
+	// by the system, such as startup migrations.
+	ActorID   string `bson:"actor_id,omitempty" json:"actor_id,omitempty"`
+	RequestID string `bson:"request_id,omitempty" json:"request_id,omitempty"`
+	Action    string `bson:"action" json:"action"`
+	Entity    string `bson:"entity" json:"entity"`
+	// MenuID is the menu the entity belongs to, so the history of a whole
+	// menu including its sections and items can be read.
+	MenuID     string        `bson:"menu_id,omitempty" json:"menu_id,omitempty"`
+	Changes    []FieldChange `bson:"changes" json:"changes"`
+	OccurredAt time.Time     `bson:"occurred_at" json:"occurred_at"`
+}
+
+// FieldChange is the value of a top-level field before and after a change,
+// in its JSON form. Before is nil for creations and After for deletions.
+type FieldChange struct {
+	Field  string `bson:"field" json:"field"`
+	Before any    `bson:"before" json:"before"`
+	After  any    `bson:"after" json:"after"`
+}
+
+// AuditListOptions filters and pages an audit listing, newest first. Entity
+// is a "type:id" reference; a menu reference also matches the events of its
+// sections and items.
+type AuditListOptions struct {
+	Entity string
+	Limit  int
+	Cursor string
+
+	// After is the decoded Cursor; the service fills it in for repositories.
+	After *AuditCursor
+}
+
+// AuditPage is one page of an audit listing. NextCursor is empty on the last
+// page.
+type AuditPage struct {
+	Events     []AuditEvent `json:"events"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+// AuditCursor is the position after the last event of a page.
+type AuditCursor struct {
+	OccurredAt time.Time `json:"t"`
+	ID         string    `json:"i"`
+}
+
+// Encode returns the opaque string form handed to clients.
+func (c AuditCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeAuditCursor parses a cursor produced by AuditCursor.Encode.
+func DecodeAuditCursor(s string) (AuditCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return AuditCursor{}, apperr.Invalid("cursor", "cursor must be a value returned by a previous page")
+	}
+	var c AuditCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return AuditCursor{}, apperr.Invalid("cursor", "cursor must be a value returned by a previous page")
+	}
+	return c, nil
+}
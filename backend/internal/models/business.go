@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Business is the tenant that owns users and menus. Its ID is the
+// business_id carried by access tokens and stored on every menu.
+type Business struct {
+	BusinessID string  `bson:"_id" json:"business_id"`
+	Name       string  `bson:"name" json:"name"`
+	Address    Address `bson:"address" json:"address"`
+	// Timezone is an IANA zone name such as "Europe/Lisbon".
+	Timezone string `bson:"timezone" json:"timezone"`
+	// DefaultCurrency is the ISO 4217 code of new item prices and modifier
+	// deltas sent without one.
+	DefaultCurrency string `bson:"default_currency" json:"default_currency"`
+	// Locale is a BCP 47 language tag such as "pt-PT".
+	Locale    string    `bson:"locale" json:"locale"`
+	LogoURL   string    `bson:"logo_url" json:"logo_url"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// Address is a postal address. Country is an ISO 3166-1 alpha-2 code.
+type Address struct {
+	Line1      string `bson:"line1" json:"line1"`
+	Line2      string `bson:"line2" json:"line2"`
+	City       string `bson:"city" json:"city"`
+	PostalCode string `bson:"postal_code" json:"postal_code"`
+	Region     string `bson:"region" json:"region"`
+	Country    string `bson:"country" json:"country"`
+}
+
+// UpdateBusinessRequest changes the caller's business. Empty fields are left
+// unchanged; Address replaces the whole address when present.
+type UpdateBusinessRequest struct {
+	Name            string   `json:"name,omitempty"`
+	Address         *Address `json:"address,omitempty"`
+	Timezone        string   `json:"timezone,omitempty"`
+	DefaultCurrency string   `json:"default_currency,omitempty"`
+	Locale          string   `json:"locale,omitempty"`
+	LogoURL         string   `json:"logo_url,omitempty"`
+}
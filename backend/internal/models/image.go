@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// ItemImage is a photo uploaded for a menu item, stored as sent together
+// with thumbnails in several widths. Storage keys stay server-side so the
+// objects can be deleted when the image is replaced.
+type ItemImage struct {
+	URL         string         `bson:"url" json:"url"`
+	Key         string         `bson:"key" json:"-"`
+	ContentType string         `bson:"content_type" json:"content_type"`
+	Width       int            `bson:"width" json:"width"`
+	Height      int            `bson:"height" json:"height"`
+	Size        int64          `bson:"size" json:"size"`
+	Thumbnails  []ImageVariant `bson:"thumbnails" json:"thumbnails"`
+	UploadedAt  time.Time      `bson:"uploaded_at" json:"uploaded_at"`
+}
+
+// ImageVariant is a resized copy of an ItemImage, narrowest first.
+type ImageVariant struct {
+	URL         string `bson:"url" json:"url"`
+	Key         string `bson:"key" json:"-"`
+	ContentType string `bson:"content_type" json:"content_type"`
+	Width       int    `bson:"width" json:"width"`
+	Height      int    `bson:"height" json:"height"`
+}
+
+// Keys returns the storage keys of the image and its thumbnails.
+func (i *ItemImage) Keys() []string {
+	keys := []string{i.Key}
+	for _, t := range i.Thumbnails {
+		keys = append(keys, t.Key)
+	}
+	return keys
+}
@@ -3,35 +3,171 @@ package models
 import "time"
 
 type Menu struct {
-	MenuID      string    `bson:"_id" json:"menu_id"`
-	Name        string    `bson:"name" json:"name"`
-	Description string    `bson:"description" json:"description"`
-	BusinessID  string    `bson:"business_id" json:"business_id"`
-	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
-	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
-	IsActive    bool      `bson:"is_active" json:"is_active"`
+	MenuID      string     `bson:"_id" json:"menu_id"`
+	Name        string     `bson:"name" json:"name"`
+	Slug        string     `bson:"slug,omitempty" json:"slug"`
+	Description string     `bson:"description,omitempty" json:"description"`
+	BusinessID  string     `bson:"business_id" json:"business_id"`
+	CreatedAt   time.Time  `bson:"created_at" json:"created_at"`
+	UpdatedAt   time.Time  `bson:"updated_at" json:"updated_at"`
+	IsActive    bool       `bson:"is_active" json:"is_active"`
+	DeletedAt   *time.Time `bson:"deleted_at,omitempty" json:"deleted_at,omitempty"`
+
+	// Schedule limits when an active menu is served; nil means always.
+	Schedule *MenuSchedule `bson:"schedule,omitempty" json:"schedule,omitempty"`
+
+	// PublishedVersion is the number of the MenuVersion diners see, or 0
+	// while the menu has never been published. The menu document, its
+	// sections and its items are the draft.
+	PublishedVersion int        `bson:"published_version" json:"published_version"`
+	PublishedAt      *time.Time `bson:"published_at,omitempty" json:"published_at,omitempty"`
+
+	// Revision counts the writes to the menu document, starting at 1. It is
+	// served as the ETag of the menu and guards updates against lost writes.
+	// It is unrelated to PublishedVersion.
+	Revision int64 `bson:"revision" json:"revision"`
 }
 
+// CreateMenuRequest creates a menu. Slug is optional; when it is empty one is
+// derived from Name.
 type CreateMenuRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string        `json:"name"`
+	Slug        string        `json:"slug,omitempty"`
+	Description string        `json:"description"`
+	Schedule    *MenuSchedule `json:"schedule,omitempty"`
 }
 
+// UpdateMenuRequest holds every field of a menu that its owner can change.
+// It replaces all of them at once: Name, Slug and IsActive are required,
+// and an omitted Description or Schedule is cleared. A nil Schedule makes
+// the menu available at all times.
+//
+// It is also the document JSON Merge Patches are applied to, so its JSON
+// form must be the editable part of the Menu representation.
 type UpdateMenuRequest struct {
-	Name        string `json:"name,omitempty"`
-	Description string `json:"description,omitempty"`
-	IsActive    *bool  `json:"is_active,omitempty"`
+	Name        string        `json:"name"`
+	Slug        string        `json:"slug"`
+	Description string        `json:"description"`
+	IsActive    *bool         `json:"is_active"`
+	Schedule    *MenuSchedule `json:"schedule,omitempty"`
+}
+
+// NewUpdateMenuRequest returns the request that would leave menu unchanged.
+func NewUpdateMenuRequest(menu *Menu) *UpdateMenuRequest {
+	active := menu.IsActive
+	return &UpdateMenuRequest{
+		Name:        menu.Name,
+		Slug:        menu.Slug,
+		Description: menu.Description,
+		IsActive:    &active,
+		Schedule:    menu.Schedule,
+	}
 }
 
 type MenuItem struct {
 	ItemID      string    `bson:"_id" json:"item_id"`
 	MenuID      string    `bson:"menu_id" json:"menu_id"`
+	BusinessID  string    `bson:"business_id" json:"business_id"`
+	SectionID   string    `bson:"section_id" json:"section_id"`
+	Position    int       `bson:"position" json:"position"`
 	Title       string    `bson:"title" json:"title"`
 	Description string    `bson:"description" json:"description"`
-	Price       float64   `bson:"price" json:"price"`
+	Price       Money     `bson:"price" json:"price"`
 	ImageURL    string    `bson:"image_url" json:"image_url"`
 	Ingredients []string  `bson:"ingredients" json:"ingredients"`
 	IsActive    bool      `bson:"is_active" json:"is_active"`
 	CreatedAt   time.Time `bson:"created_at" json:"created_at"`
 	UpdatedAt   time.Time `bson:"updated_at" json:"updated_at"`
+
+	// Allergens holds codes from Allergens and DietaryTags holds tags from
+	// DietaryTags, both sorted and without duplicates.
+	Allergens      []string        `bson:"allergens" json:"allergens"`
+	DietaryTags    []string        `bson:"dietary_tags" json:"dietary_tags"`
+	ModifierGroups []ModifierGroup `bson:"modifier_groups" json:"modifier_groups"`
+
+	// Image is set by uploading through the image endpoint, which also
+	// points ImageURL at the original. It is nil for items whose ImageURL
+	// points at an externally hosted image.
+	Image *ItemImage `bson:"image,omitempty" json:"image,omitempty"`
+}
+
+type CreateMenuItemRequest struct {
+	SectionID   string   `json:"section_id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Price       Money    `json:"price"`
+	ImageURL    string   `json:"image_url"`
+	Ingredients []string `json:"ingredients"`
+
+	Allergens      []string        `json:"allergens"`
+	DietaryTags    []string        `json:"dietary_tags"`
+	ModifierGroups []ModifierGroup `json:"modifier_groups"`
+}
+
+// UpdateMenuItemRequest changes a menu item. Allergens, DietaryTags and
+// ModifierGroups replace the stored lists when present; send an empty list to
+// clear one.
+type UpdateMenuItemRequest struct {
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Price       *Money   `json:"price,omitempty"`
+	ImageURL    string   `json:"image_url,omitempty"`
+	Ingredients []string `json:"ingredients,omitempty"`
+	IsActive    *bool    `json:"is_active,omitempty"`
+
+	Allergens      []string        `json:"allergens,omitempty"`
+	DietaryTags    []string        `json:"dietary_tags,omitempty"`
+	ModifierGroups []ModifierGroup `json:"modifier_groups,omitempty"`
+}
+
+// MenuSection groups items of a menu, e.g. "Starters" or "Drinks".
+// Sections and the items inside them are ordered by Position, starting at 0.
+type MenuSection struct {
+	SectionID  string    `bson:"_id" json:"section_id"`
+	MenuID     string    `bson:"menu_id" json:"menu_id"`
+	BusinessID string    `bson:"business_id" json:"business_id"`
+	Name       string    `bson:"name" json:"name"`
+	Position   int       `bson:"position" json:"position"`
+	CreatedAt  time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt  time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+type CreateMenuSectionRequest struct {
+	Name string `json:"name"`
+}
+
+type UpdateMenuSectionRequest struct {
+	Name string `json:"name,omitempty"`
+}
+
+// MenuOrderRequest describes the complete layout of a menu: every section in
+// display order, the items inside each section, and the items that are not in
+// any section.
+type MenuOrderRequest struct {
+	Sections           []SectionOrder `json:"sections"`
+	UnsectionedItemIDs []string       `json:"unsectioned_item_ids"`
+}
+
+type SectionOrder struct {
+	SectionID string   `json:"section_id"`
+	ItemIDs   []string `json:"item_ids"`
+}
+
+// ItemPlacement is the section and position assigned to an item by a reorder.
+type ItemPlacement struct {
+	ItemID    string
+	SectionID string
+	Position  int
+}
+
+// MenuTree is a menu with its sections and items nested in display order.
+type MenuTree struct {
+	Menu
+	Sections []MenuSectionTree `json:"sections"`
+	Items    []MenuItem        `json:"items"`
+}
+
+type MenuSectionTree struct {
+	MenuSection
+	Items []MenuItem `json:"items"`
 }
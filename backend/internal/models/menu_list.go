@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+)
+
+// Sort keys accepted by MenuListOptions.SortBy.
+const (
+	MenuSortName      = "name"
+	MenuSortCreatedAt = "created_at"
+	MenuSortUpdatedAt = "updated_at"
+)
+
+// MenuListOptions filters, sorts and pages a menu listing. Zero values mean
+// "no filter". Results are always ordered by SortBy and then by menu ID so
+// ties have a stable order for keyset pagination.
+type MenuListOptions struct {
+	Limit         int
+	Cursor        string
+	IsActive      *bool
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+	SortBy        string
+	SortDesc      bool
+
+	// After is the decoded Cursor; the service fills it in for repositories.
+	After *MenuCursor
+}
+
+// MenuPage is one page of a menu listing. NextCursor is empty on the last page.
+type MenuPage struct {
+	Menus      []Menu `json:"menus"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// MenuCursor is the position after the last menu of a page. It records the
+// sort it was issued for so it cannot be replayed against a different order.
+type MenuCursor struct {
+	SortBy string    `json:"s"`
+	Desc   bool      `json:"d,omitempty"`
+	Name   string    `json:"n,omitempty"`
+	Time   time.Time `json:"t,omitempty"`
+	ID     string    `json:"i"`
+}
+
+// NewMenuCursor returns the cursor positioned after menu.
+func NewMenuCursor(menu Menu, sortBy string, desc bool) MenuCursor {
+	c := MenuCursor{SortBy: sortBy, Desc: desc, ID: menu.MenuID}
+	switch sortBy {
+	case MenuSortName:
+		c.Name = menu.Name
+	case MenuSortUpdatedAt:
+		c.Time = menu.UpdatedAt
+	default:
+		c.Time = menu.CreatedAt
+	}
+	return c
+}
+
+// Encode returns the opaque string form handed to clients.
+func (c MenuCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeMenuCursor parses a cursor produced by MenuCursor.Encode.
+func DecodeMenuCursor(s string) (MenuCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return MenuCursor{}, apperr.Invalid("cursor", "cursor must be a value returned by a previous page")
+	}
+	var c MenuCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return MenuCursor{}, apperr.Invalid("cursor", "cursor must be a value returned by a previous page")
+	}
+	return c, nil
+}
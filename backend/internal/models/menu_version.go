@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// MenuVersion is an immutable snapshot of a menu's content, taken when its
+// draft is published. Numbers start at 1 and increase with every publish and
+// rollback, so a version number never changes meaning.
+//
+// Only the content is versioned: name, description, sections and items. The
+// slug, IsActive and Schedule of the menu apply immediately to whichever
+// version is published.
+type MenuVersion struct {
+	VersionID   string `bson:"_id" json:"version_id"`
+	MenuID      string `bson:"menu_id" json:"menu_id"`
+	BusinessID  string `bson:"business_id" json:"business_id"`
+	Number      int    `bson:"number" json:"number"`
+	Name        string `bson:"name" json:"name"`
+	Description string `bson:"description" json:"description"`
+
+	// Sections and Items are left out of version listings; the counts are
+	// stored so listings can still show them.
+	Sections     []MenuSection `bson:"sections" json:"sections,omitempty"`
+	Items        []MenuItem    `bson:"items" json:"items,omitempty"`
+	SectionCount int           `bson:"section_count" json:"section_count"`
+	ItemCount    int           `bson:"item_count" json:"item_count"`
+
+	PublishedAt time.Time `bson:"published_at" json:"published_at"`
+	PublishedBy string    `bson:"published_by,omitempty" json:"published_by,omitempty"`
+	// RestoredFrom is the number of the version a rollback copied.
+	RestoredFrom int `bson:"restored_from,omitempty" json:"restored_from,omitempty"`
+}
@@ -0,0 +1,54 @@
+package models
+
+// ModifierGroup is a set of options a diner picks from when ordering an item,
+// e.g. "Size" with S/M/L or "Extras" where up to three may be chosen. Between
+// MinSelect and MaxSelect distinct options must be selected; a group with
+// MinSelect 0 is optional.
+type ModifierGroup struct {
+	GroupID   string           `bson:"group_id" json:"group_id"`
+	Name      string           `bson:"name" json:"name"`
+	MinSelect int              `bson:"min_select" json:"min_select"`
+	MaxSelect int              `bson:"max_select" json:"max_select"`
+	Options   []ModifierOption `bson:"options" json:"options"`
+}
+
+// ModifierOption is one choice in a ModifierGroup. PriceDelta is added to the
+// item price when the option is selected and is in the item's currency; it
+// may be negative, e.g. for a removal that makes the item cheaper.
+type ModifierOption struct {
+	OptionID   string `bson:"option_id" json:"option_id"`
+	Name       string `bson:"name" json:"name"`
+	PriceDelta Money  `bson:"price_delta" json:"price_delta"`
+}
+
+// ModifierSelection lists the options chosen in one modifier group.
+type ModifierSelection struct {
+	GroupID   string   `json:"group_id"`
+	OptionIDs []string `json:"option_ids"`
+}
+
+// PriceRequest asks for the price of an item with a set of modifiers.
+// Quantity defaults to 1.
+type PriceRequest struct {
+	Selections []ModifierSelection `json:"selections"`
+	Quantity   int                 `json:"quantity,omitempty"`
+}
+
+// PriceQuote is the price of an item with its selected modifiers. UnitPrice
+// is the base price plus every line; Total is UnitPrice times Quantity.
+type PriceQuote struct {
+	ItemID    string      `json:"item_id"`
+	BasePrice Money       `json:"base_price"`
+	Lines     []PriceLine `json:"lines"`
+	UnitPrice Money       `json:"unit_price"`
+	Quantity  int         `json:"quantity"`
+	Total     Money       `json:"total"`
+}
+
+// PriceLine is one selected option and its price delta.
+type PriceLine struct {
+	GroupID    string `json:"group_id"`
+	OptionID   string `json:"option_id"`
+	Name       string `json:"name"`
+	PriceDelta Money  `json:"price_delta"`
+}
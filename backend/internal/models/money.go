@@ -0,0 +1,128 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/bsontype"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+)
+
+// Money is an amount in the minor units of an ISO 4217 currency, e.g.
+// {Amount: 950, Currency: "EUR"} is 9.50 EUR and {Amount: 950, Currency: "JPY"}
+// is 950 JPY. Integer minor units keep totals exact.
+//
+// Money is encoded as {"amount": 950, "currency": "EUR"} in both JSON and BSON.
+type Money struct {
+	Amount   int64  `bson:"amount" json:"amount"`
+	Currency string `bson:"currency" json:"currency"`
+}
+
+// isoCurrencies lists the active ISO 4217 codes.
+var isoCurrencies = map[string]bool{}
+
+func init() {
+	const codes = "AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BRL " +
+		"BSD BTN BWP BYN BZD CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF DKK DOP DZD EGP " +
+		"ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR " +
+		"IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL " +
+		"LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN NAD NGN NIO NOK NPR " +
+		"NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD " +
+		"SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX " +
+		"USD UYU UZS VES VND VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWG"
+	for _, code := range strings.Fields(codes) {
+		isoCurrencies[code] = true
+	}
+}
+
+// minorUnitExceptions holds the currencies whose minor unit is not 1/100.
+var minorUnitExceptions = map[string]int{
+	"BHD": 3, "BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3, "ISK": 0,
+	"JOD": 3, "JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3, "OMR": 3,
+	"PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "VND": 0, "VUV": 0,
+	"XAF": 0, "XOF": 0, "XPF": 0,
+}
+
+// IsValidCurrency reports whether code is an active ISO 4217 currency code.
+func IsValidCurrency(code string) bool {
+	return isoCurrencies[code]
+}
+
+// MinorUnitExponent returns the number of decimal places of the currency's
+// minor unit, e.g. 2 for EUR and 0 for JPY.
+func MinorUnitExponent(currency string) int {
+	if exp, ok := minorUnitExceptions[currency]; ok {
+		return exp
+	}
+	return 2
+}
+
+// MoneyFromFloat converts a decimal major-unit amount such as 9.5 into Money,
+// rounding to the nearest minor unit.
+func MoneyFromFloat(amount float64, currency string) Money {
+	scale := math.Pow10(MinorUnitExponent(currency))
+	return Money{Amount: int64(math.Round(amount * scale)), Currency: currency}
+}
+
+// Validate checks that the currency is known and the amount is not negative.
+func (m Money) Validate() error {
+	if m.Currency == "" {
+		return apperr.Required("currency")
+	}
+	if !IsValidCurrency(m.Currency) {
+		return apperr.Invalidf("currency", "currency %q must be an ISO 4217 code", m.Currency)
+	}
+	if m.Amount < 0 {
+		return apperr.Invalid("amount", "amount must not be negative")
+	}
+	return nil
+}
+
+// String formats the amount in major units followed by the currency code,
+// e.g. "9.50 EUR".
+func (m Money) String() string {
+	exp := MinorUnitExponent(m.Currency)
+	if exp == 0 {
+		return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+	}
+
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	scale := int64(math.Pow10(exp))
+	return fmt.Sprintf("%s%d.%0*d %s", sign, amount/scale, exp, amount%scale, m.Currency)
+}
+
+// UnmarshalBSONValue decodes Money documents and, for documents written before
+// prices carried a currency, bare numeric prices. A legacy number is read as
+// major units with two decimals and an empty currency; the startup migration
+// in the mongo repository rewrites those documents.
+func (m *Money) UnmarshalBSONValue(t bsontype.Type, data []byte) error {
+	raw := bson.RawValue{Type: t, Value: data}
+	switch t {
+	case bsontype.EmbeddedDocument:
+		type plain Money
+		var decoded plain
+		if err := raw.Unmarshal(&decoded); err != nil {
+			return err
+		}
+		*m = Money(decoded)
+	case bsontype.Double:
+		*m = Money{Amount: int64(math.Round(raw.Double() * 100))}
+	case bsontype.Int32:
+		*m = Money{Amount: int64(raw.Int32()) * 100}
+	case bsontype.Int64:
+		*m = Money{Amount: raw.Int64() * 100}
+	case bsontype.Null, bsontype.Undefined:
+		*m = Money{}
+	default:
+		return fmt.Errorf("cannot decode %s into Money", t)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestMoneyString(t *testing.T) {
+	cases := map[Money]string{
+		{Amount: 950, Currency: "EUR"}:  "9.50 EUR",
+		{Amount: 5, Currency: "USD"}:    "0.05 USD",
+		{Amount: 950, Currency: "JPY"}:  "950 JPY",
+		{Amount: 1500, Currency: "KWD"}: "1.500 KWD",
+	}
+	for m, want := range cases {
+		if got := m.String(); got != want {
+			t.Errorf("%+v: expected %s, got %s", m, want, got)
+		}
+	}
+}
+
+func TestMoneyFromFloat(t *testing.T) {
+	if got := MoneyFromFloat(0.29, "EUR"); got.Amount != 29 {
+		t.Errorf("expected 29, got %d", got.Amount)
+	}
+	if got := MoneyFromFloat(300, "JPY"); got.Amount != 300 {
+		t.Errorf("expected 300, got %d", got.Amount)
+	}
+}
+
+func TestMoneyJSONShape(t *testing.T) {
+	data, err := json.Marshal(Money{Amount: 950, Currency: "EUR"})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(data) != `{"amount":950,"currency":"EUR"}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestMoneyBSONLegacyFloat(t *testing.T) {
+	data, err := bson.Marshal(bson.M{"price": 12.99})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var doc struct {
+		Price Money `bson:"price"`
+	}
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if doc.Price.Amount != 1299 {
+		t.Errorf("expected 1299, got %d", doc.Price.Amount)
+	}
+}
+
+func TestMoneyBSONRoundTrip(t *testing.T) {
+	in := struct {
+		Price Money `bson:"price"`
+	}{Price: Money{Amount: 450, Currency: "GBP"}}
+
+	data, err := bson.Marshal(in)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var out struct {
+		Price Money `bson:"price"`
+	}
+	if err := bson.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.Price != in.Price {
+		t.Errorf("expected %+v, got %+v", in.Price, out.Price)
+	}
+}
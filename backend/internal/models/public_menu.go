@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// PublicMenu is the diner-facing view of an active menu served without
+// authentication. It carries only what a diner needs to read the menu: no
+// business, menu or item IDs and no inactive items.
+type PublicMenu struct {
+	Slug        string              `json:"slug"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	Schedule    *MenuSchedule       `json:"schedule,omitempty"`
+	Sections    []PublicMenuSection `json:"sections"`
+	Items       []PublicMenuItem    `json:"items"`
+}
+
+// PublicMenuSection is a section of a PublicMenu with its active items in
+// display order.
+type PublicMenuSection struct {
+	Name  string           `json:"name"`
+	Items []PublicMenuItem `json:"items"`
+}
+
+// PublicMenuItem is an active item of a PublicMenu, shown with its modifier
+// groups so diners see the choices and their price deltas.
+type PublicMenuItem struct {
+	Title          string          `json:"title"`
+	Description    string          `json:"description"`
+	Price          Money           `json:"price"`
+	ImageURL       string          `json:"image_url"`
+	Image          *ItemImage      `json:"image,omitempty"`
+	Ingredients    []string        `json:"ingredients"`
+	Allergens      []string        `json:"allergens"`
+	DietaryTags    []string        `json:"dietary_tags"`
+	ModifierGroups []ModifierGroup `json:"modifier_groups"`
+}
+
+// PublicMenuFilter narrows a PublicMenu down to what a diner can eat. Items
+// containing any of ExcludeAllergens are left out, as are items missing any
+// of Diets.
+type PublicMenuFilter struct {
+	ExcludeAllergens []string
+	Diets            []string
+}
@@ -0,0 +1,218 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/validate"
+)
+
+// MenuSchedule restricts when a menu is served. Times and dates are wall
+// clock values in the owning business's timezone.
+//
+// Windows are the regular weekly hours; a schedule without windows imposes no
+// weekly restriction. Overrides replace the weekly hours on the dates they
+// cover, e.g. to close for a holiday or open with special hours; when several
+// overrides cover a date the first one listed applies.
+type MenuSchedule struct {
+	Windows   []WeeklyWindow `bson:"windows" json:"windows"`
+	Overrides []DateOverride `bson:"overrides" json:"overrides"`
+}
+
+// WeeklyWindow makes a menu available on the given weekdays from Start to
+// End ("HH:MM", End exclusive). An End at or before Start crosses midnight:
+// {Days: [fri], Start: "22:00", End: "02:00"} runs until 02:00 on Saturday.
+// End may be "24:00".
+type WeeklyWindow struct {
+	Days  []string `bson:"days" json:"days"`
+	Start string   `bson:"start" json:"start"`
+	End   string   `bson:"end" json:"end"`
+}
+
+// DateOverride replaces the weekly windows from From to To inclusive
+// ("YYYY-MM-DD"). A closed override makes the menu unavailable on those
+// dates; otherwise it is available during Windows, or all day when there are
+// none.
+type DateOverride struct {
+	From    string      `bson:"from" json:"from"`
+	To      string      `bson:"to" json:"to"`
+	Closed  bool        `bson:"closed" json:"closed"`
+	Windows []TimeRange `bson:"windows" json:"windows"`
+	Note    string      `bson:"note" json:"note"`
+}
+
+// TimeRange is a time of day range within a single date, Start inclusive and
+// End exclusive, both "HH:MM".
+type TimeRange struct {
+	Start string `bson:"start" json:"start"`
+	End   string `bson:"end" json:"end"`
+}
+
+// Weekday codes accepted in WeeklyWindow.Days.
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+const dateLayout = "2006-01-02"
+
+// parseClock parses "HH:MM" into minutes after midnight. "24:00" is allowed
+// only when allowEndOfDay is set.
+func parseClock(value string, allowEndOfDay bool) (int, error) {
+	if allowEndOfDay && value == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil || len(value) != 5 {
+		return 0, fmt.Errorf("%q must be HH:MM", value)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// Validate checks every window and override and lowercases weekday codes.
+// Every problem is reported, against paths such as "windows[0].days[1]" and
+// "overrides[2].windows[0].end".
+func (s *MenuSchedule) Validate() error {
+	var v validate.Validator
+	for i := range s.Windows {
+		w := &s.Windows[i]
+		field := fmt.Sprintf("windows[%d]", i)
+		v.Check(len(w.Days) > 0, field+".days", "schedule window days are required")
+		for j, day := range w.Days {
+			day = strings.ToLower(strings.TrimSpace(day))
+			if _, ok := weekdays[day]; !ok {
+				v.Addf(fmt.Sprintf("%s.days[%d]", field, j), "schedule window day %q must be one of mon, tue, wed, thu, fri, sat, sun", day)
+				continue
+			}
+			w.Days[j] = day
+		}
+		start, startErr := parseClock(w.Start, false)
+		if startErr != nil {
+			v.Addf(field+".start", "schedule window start %v", startErr)
+		}
+		end, endErr := parseClock(w.End, true)
+		if endErr != nil {
+			v.Addf(field+".end", "schedule window end %v", endErr)
+		}
+		if startErr == nil && endErr == nil && start == end {
+			v.Add(field+".end", "schedule window start and end must differ")
+		}
+	}
+
+	for i, o := range s.Overrides {
+		field := fmt.Sprintf("overrides[%d]", i)
+		from, fromErr := time.Parse(dateLayout, o.From)
+		if fromErr != nil {
+			v.Addf(field+".from", "schedule override from %q must be YYYY-MM-DD", o.From)
+		}
+		to, toErr := time.Parse(dateLayout, o.To)
+		if toErr != nil {
+			v.Addf(field+".to", "schedule override to %q must be YYYY-MM-DD", o.To)
+		}
+		if fromErr == nil && toErr == nil && to.Before(from) {
+			v.Add(field+".to", "schedule override to must not be before from")
+		}
+		if o.Closed && len(o.Windows) > 0 {
+			v.Add(field+".windows", "schedule override must not have windows when closed")
+		}
+		for j, r := range o.Windows {
+			rangeField := fmt.Sprintf("%s.windows[%d]", field, j)
+			start, startErr := parseClock(r.Start, false)
+			if startErr != nil {
+				v.Addf(rangeField+".start", "schedule override start %v", startErr)
+			}
+			end, endErr := parseClock(r.End, true)
+			if endErr != nil {
+				v.Addf(rangeField+".end", "schedule override end %v", endErr)
+			}
+			if startErr == nil && endErr == nil && end <= start {
+				v.Add(rangeField+".end", "schedule override window end must be after start")
+			}
+		}
+	}
+
+	return v.Err()
+}
+
+// AvailableAt reports whether the schedule allows serving at t, read as wall
+// clock time in loc. The schedule must have passed Validate.
+//
+// A weekly window that crosses midnight keeps running into the next day even
+// when that day has an override, so a Friday 22:00-02:00 window still covers
+// early Saturday on a Saturday holiday. An override on the day the window
+// starts replaces it, spill-over included.
+func (s *MenuSchedule) AvailableAt(t time.Time, loc *time.Location) bool {
+	t = t.In(loc)
+	minute := t.Hour()*60 + t.Minute()
+
+	yesterday := t.AddDate(0, 0, -1)
+	if s.overrideOn(yesterday.Format(dateLayout)) == nil && s.spillsOver(yesterday.Weekday(), minute) {
+		return true
+	}
+
+	if o := s.overrideOn(t.Format(dateLayout)); o != nil {
+		if o.Closed {
+			return false
+		}
+		if len(o.Windows) == 0 {
+			return true
+		}
+		for _, r := range o.Windows {
+			start, _ := parseClock(r.Start, false)
+			end, _ := parseClock(r.End, true)
+			if minute >= start && minute < end {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(s.Windows) == 0 {
+		return true
+	}
+	for _, w := range s.Windows {
+		start, _ := parseClock(w.Start, false)
+		end, _ := parseClock(w.End, true)
+		overnight := end <= start
+		if w.runsOn(t.Weekday()) && minute >= start && (overnight || minute < end) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// overrideOn returns the override that applies on date ("YYYY-MM-DD"), or nil
+// if the weekly windows apply.
+func (s *MenuSchedule) overrideOn(date string) *DateOverride {
+	for i, o := range s.Overrides {
+		if date >= o.From && date <= o.To {
+			return &s.Overrides[i]
+		}
+	}
+	return nil
+}
+
+// spillsOver reports whether an overnight window that starts on day is still
+// running at minute past midnight of the next day.
+func (s *MenuSchedule) spillsOver(day time.Weekday, minute int) bool {
+	for _, w := range s.Windows {
+		start, _ := parseClock(w.Start, false)
+		end, _ := parseClock(w.End, true)
+		if end <= start && minute < end && w.runsOn(day) {
+			return true
+		}
+	}
+	return false
+}
+
+// runsOn reports whether the window starts on day.
+func (w WeeklyWindow) runsOn(day time.Weekday) bool {
+	for _, code := range w.Days {
+		if weekdays[code] == day {
+			return true
+		}
+	}
+	return false
+}
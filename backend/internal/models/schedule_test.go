@@ -0,0 +1,148 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+)
+
+func TestMenuScheduleAvailableAt(t *testing.T) {
+	schedule := &MenuSchedule{
+		Windows: []WeeklyWindow{
+			{Days: []string{"mon", "tue", "wed", "thu", "fri"}, Start: "12:00", End: "15:00"},
+			{Days: []string{"fri"}, Start: "22:00", End: "02:00"},
+			{Days: []string{"sun"}, Start: "18:00", End: "24:00"},
+		},
+		Overrides: []DateOverride{
+			{From: "2026-12-24", To: "2026-12-26", Closed: true, Note: "Christmas"},
+			{From: "2026-12-31", To: "2026-12-31", Windows: []TimeRange{{Start: "19:00", End: "23:00"}}},
+			{From: "2027-01-01", To: "2027-01-01"},
+		},
+	}
+	if err := schedule.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := map[string]bool{
+		"2026-10-19 12:00": true,  // Monday lunch start
+		"2026-10-19 15:00": false, // end is exclusive
+		"2026-10-20 11:59": false,
+		"2026-10-23 23:30": true, // Friday late window
+		"2026-10-24 01:59": true, // spills into Saturday
+		"2026-10-24 02:00": false,
+		"2026-10-24 13:00": false, // no Saturday lunch
+		"2026-10-25 23:59": true,  // Sunday until midnight
+		"2026-12-24 13:00": false, // closed override on a Thursday
+		"2026-12-31 13:00": false, // override hours replace lunch
+		"2026-12-31 20:00": true,
+		"2027-01-01 04:00": true, // open all day
+	}
+	for local, want := range cases {
+		at, err := time.ParseInLocation("2006-01-02 15:04", local, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := schedule.AvailableAt(at, time.UTC); got != want {
+			t.Errorf("%s: expected %v, got %v", local, want, got)
+		}
+	}
+}
+
+func TestMenuScheduleOvernightIntoOverride(t *testing.T) {
+	schedule := &MenuSchedule{
+		Windows: []WeeklyWindow{{Days: []string{"fri"}, Start: "22:00", End: "02:00"}},
+		Overrides: []DateOverride{
+			{From: "2026-11-07", To: "2026-11-07", Closed: true, Note: "Saturday holiday"},
+			{From: "2026-11-13", To: "2026-11-13", Closed: true, Note: "Friday holiday"},
+			{From: "2026-11-21", To: "2026-11-21", Windows: []TimeRange{{Start: "10:00", End: "12:00"}}},
+		},
+	}
+	if err := schedule.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := map[string]bool{
+		"2026-11-07 01:00": true,  // Friday's window runs into the closed Saturday
+		"2026-11-07 02:00": false, // and ends on time
+		"2026-11-07 23:00": false,
+		"2026-11-14 01:00": false, // a closed Friday has no spill-over
+		"2026-11-21 01:00": true,  // spill-over before the override hours
+		"2026-11-21 03:00": false,
+		"2026-11-21 11:00": true,
+	}
+	for local, want := range cases {
+		at, err := time.ParseInLocation("2006-01-02 15:04", local, time.UTC)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := schedule.AvailableAt(at, time.UTC); got != want {
+			t.Errorf("%s: expected %v, got %v", local, want, got)
+		}
+	}
+}
+
+func TestMenuScheduleUsesLocation(t *testing.T) {
+	schedule := &MenuSchedule{Windows: []WeeklyWindow{{Days: []string{"mon"}, Start: "09:00", End: "10:00"}}}
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+
+	// 09:30 in Tokyo on Monday is 00:30 UTC the same day
+	at := time.Date(2026, 10, 19, 0, 30, 0, 0, time.UTC)
+	if !schedule.AvailableAt(at, tokyo) {
+		t.Error("expected available in Tokyo time")
+	}
+	if schedule.AvailableAt(at, time.UTC) {
+		t.Error("expected unavailable in UTC")
+	}
+}
+
+func TestMenuScheduleValidate(t *testing.T) {
+	invalid := []MenuSchedule{
+		{Windows: []WeeklyWindow{{Start: "09:00", End: "10:00"}}},
+		{Windows: []WeeklyWindow{{Days: []string{"monday"}, Start: "09:00", End: "10:00"}}},
+		{Windows: []WeeklyWindow{{Days: []string{"mon"}, Start: "9:00", End: "10:00"}}},
+		{Windows: []WeeklyWindow{{Days: []string{"mon"}, Start: "10:00", End: "10:00"}}},
+		{Windows: []WeeklyWindow{{Days: []string{"mon"}, Start: "24:00", End: "10:00"}}},
+		{Overrides: []DateOverride{{From: "2026-12-25", To: "2026-12-24"}}},
+		{Overrides: []DateOverride{{From: "25/12/2026", To: "2026-12-25"}}},
+		{Overrides: []DateOverride{{From: "2026-12-25", To: "2026-12-25", Closed: true, Windows: []TimeRange{{Start: "10:00", End: "11:00"}}}}},
+		{Overrides: []DateOverride{{From: "2026-12-25", To: "2026-12-25", Windows: []TimeRange{{Start: "22:00", End: "02:00"}}}}},
+	}
+	for _, schedule := range invalid {
+		if err := schedule.Validate(); err == nil {
+			t.Errorf("expected error for %+v", schedule)
+		}
+	}
+
+	// every problem is reported, each against its own path
+	schedule := MenuSchedule{
+		Windows: []WeeklyWindow{
+			{Days: []string{"mon", "monday", "sun"}, Start: "9:00", End: "10:00"},
+			{Start: "10:00", End: "10:00"},
+		},
+		Overrides: []DateOverride{
+			{From: "2026-12-25", To: "2026-12-24"},
+			{From: "2026-12-31", To: "2026-12-31", Closed: true, Windows: []TimeRange{{Start: "22:00", End: "02:00"}}},
+		},
+	}
+	var fields []string
+	for _, f := range apperr.FieldsOf(schedule.Validate()) {
+		fields = append(fields, f.Field)
+	}
+	want := "windows[0].days[1] windows[0].start windows[1].days windows[1].end overrides[0].to overrides[1].windows overrides[1].windows[0].end"
+	if got := strings.Join(fields, " "); got != want {
+		t.Errorf("expected invalid fields %s, got %s", want, got)
+	}
+
+	valid := MenuSchedule{Windows: []WeeklyWindow{{Days: []string{" SAT "}, Start: "00:00", End: "24:00"}}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if valid.Windows[0].Days[0] != "sat" {
+		t.Errorf("expected normalized day, got %q", valid.Windows[0].Days[0])
+	}
+}
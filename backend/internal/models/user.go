@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// User is an account that can sign in and manage one business.
+type User struct {
+	UserID       string    `bson:"_id" json:"user_id"`
+	Email        string    `bson:"email" json:"email"`
+	PasswordHash string    `bson:"password_hash" json:"-"`
+	BusinessID   string    `bson:"business_id" json:"business_id"`
+	Role         string    `bson:"role" json:"role"`
+	CreatedAt    time.Time `bson:"created_at" json:"created_at"`
+	UpdatedAt    time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// RegisterRequest signs up an owner together with a new business. The
+// business fields are optional and fall back to the defaults documented on
+// AuthService.Register.
+type RegisterRequest struct {
+	Email           string `json:"email"`
+	Password        string `json:"password"`
+	BusinessName    string `json:"business_name,omitempty"`
+	Timezone        string `json:"timezone,omitempty"`
+	DefaultCurrency string `json:"default_currency,omitempty"`
+	Locale          string `json:"locale,omitempty"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// SetRoleRequest changes the role of a user: "owner" or "admin".
+type SetRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// TokenResponse is returned by register and login.
+type TokenResponse struct {
+	AccessToken string    `json:"access_token"`
+	TokenType   string    `json:"token_type"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	User        *User     `json:"user"`
+}
@@ -0,0 +1,174 @@
+// Package qr renders QR codes as PNG or SVG images.
+package qr
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+
+	qrcode "github.com/skip2/go-qrcode"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+)
+
+// Supported output formats.
+const (
+	FormatPNG = "png"
+	FormatSVG = "svg"
+)
+
+// Bounds and defaults for Options.
+const (
+	DefaultSize      = 256
+	MinSize          = 64
+	MaxSize          = 2048
+	DefaultQuietZone = 4
+	MaxQuietZone     = 16
+)
+
+// Options controls how a QR code is rendered. Zero values select the
+// defaults: PNG, 256 pixels, error-correction level M and a quiet zone of
+// four modules, the minimum the QR specification asks for.
+type Options struct {
+	Format string
+	// Size is the width and height of the image in pixels.
+	Size int
+	// Level is the error-correction level: L, M, Q or H.
+	Level string
+	// QuietZone is the blank margin around the code in modules. Nil means
+	// DefaultQuietZone; 0 disables the margin.
+	QuietZone *int
+}
+
+var levels = map[string]qrcode.RecoveryLevel{
+	"L": qrcode.Low,
+	"M": qrcode.Medium,
+	"Q": qrcode.High,
+	"H": qrcode.Highest,
+}
+
+// ContentType returns the MIME type of images rendered in format.
+func ContentType(format string) string {
+	if format == FormatSVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+// Normalize fills in defaults and validates the options.
+func (o Options) Normalize() (Options, error) {
+	if o.Format == "" {
+		o.Format = FormatPNG
+	}
+	if o.Format != FormatPNG && o.Format != FormatSVG {
+		return o, apperr.Invalid("format", "format must be png or svg")
+	}
+
+	if o.Size == 0 {
+		o.Size = DefaultSize
+	}
+	if o.Size < MinSize || o.Size > MaxSize {
+		return o, apperr.Invalidf("size", "size must be between %d and %d", MinSize, MaxSize)
+	}
+
+	o.Level = strings.ToUpper(o.Level)
+	if o.Level == "" {
+		o.Level = "M"
+	}
+	if _, ok := levels[o.Level]; !ok {
+		return o, apperr.Invalid("level", "level must be one of L, M, Q, H")
+	}
+
+	if o.QuietZone == nil {
+		zone := DefaultQuietZone
+		o.QuietZone = &zone
+	}
+	if *o.QuietZone < 0 || *o.QuietZone > MaxQuietZone {
+		return o, apperr.Invalidf("quiet_zone", "quiet_zone must be between 0 and %d", MaxQuietZone)
+	}
+
+	return o, nil
+}
+
+// Render encodes content as a QR code image. The image is exactly
+// opts.Size pixels wide; for PNG any space left after scaling the modules to
+// whole pixels is added to the quiet zone.
+func Render(content string, opts Options) ([]byte, error) {
+	opts, err := opts.Normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := qrcode.New(content, levels[opts.Level])
+	if err != nil {
+		return nil, err
+	}
+	code.DisableBorder = true
+	modules := code.Bitmap()
+
+	if opts.Format == FormatSVG {
+		return renderSVG(modules, *opts.QuietZone, opts.Size), nil
+	}
+	return renderPNG(modules, *opts.QuietZone, opts.Size)
+}
+
+func renderPNG(modules [][]bool, quietZone, size int) ([]byte, error) {
+	total := len(modules) + 2*quietZone
+	scale := size / total
+	if scale < 1 {
+		return nil, apperr.Invalidf("size", "size must be at least %d for this content", total)
+	}
+	offset := quietZone*scale + (size-scale*total)/2
+
+	img := image.NewPaletted(image.Rect(0, 0, size, size), color.Palette{color.White, color.Black})
+	for y, row := range modules {
+		for x, dark := range row {
+			if !dark {
+				continue
+			}
+			for dy := 0; dy < scale; dy++ {
+				for dx := 0; dx < scale; dx++ {
+					img.SetColorIndex(offset+x*scale+dx, offset+y*scale+dy, 1)
+				}
+			}
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderSVG draws one module per viewBox unit and lets the viewer scale it,
+// merging horizontal runs of dark modules into single path segments.
+func renderSVG(modules [][]bool, quietZone, size int) []byte {
+	total := len(modules) + 2*quietZone
+
+	var path strings.Builder
+	for y, row := range modules {
+		for x := 0; x < len(row); {
+			if !row[x] {
+				x++
+				continue
+			}
+			start := x
+			for x < len(row) && row[x] {
+				x++
+			}
+			fmt.Fprintf(&path, "M%d %dh%dv1h-%dz", start+quietZone, y+quietZone, x-start, x-start)
+		}
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, total, total)
+	fmt.Fprintf(&buf, `<rect width="%d" height="%d" fill="#fff"/>`, total, total)
+	fmt.Fprintf(&buf, `<path d="%s" fill="#000"/>`, path.String())
+	buf.WriteString("</svg>\n")
+	return buf.Bytes()
+}
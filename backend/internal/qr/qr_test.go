@@ -0,0 +1,82 @@
+package qr
+
+import (
+	"bytes"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestRenderPNG(t *testing.T) {
+	data, err := Render("https://menu.example.com/m/brunch", Options{Size: 300})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if b := img.Bounds(); b.Dx() != 300 || b.Dy() != 300 {
+		t.Errorf("expected 300x300, got %dx%d", b.Dx(), b.Dy())
+	}
+
+	// the quiet zone keeps the corner white
+	if r, _, _, _ := img.At(0, 0).RGBA(); r == 0 {
+		t.Error("expected a white corner with the default quiet zone")
+	}
+}
+
+func TestRenderPNGWithoutQuietZone(t *testing.T) {
+	zone := 0
+	data, err := Render("https://menu.example.com/m/brunch", Options{Size: 250, QuietZone: &zone})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	// without a quiet zone the finder pattern starts at the edge, offset only
+	// by the pixels left over from scaling the modules
+	first := -1
+	for i := 0; i < img.Bounds().Dx(); i++ {
+		if r, _, _, _ := img.At(i, i).RGBA(); r == 0 {
+			first = i
+			break
+		}
+	}
+	if first < 0 || first >= 16 {
+		t.Errorf("expected the finder pattern next to the edge, first dark pixel at %d", first)
+	}
+}
+
+func TestRenderSVG(t *testing.T) {
+	data, err := Render("https://menu.example.com/m/brunch", Options{Format: FormatSVG, Size: 128, Level: "h"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	svg := string(data)
+	if !strings.HasPrefix(svg, "<svg") || !strings.Contains(svg, `width="128"`) || !strings.Contains(svg, "<path d=\"M") {
+		t.Errorf("unexpected svg: %s", svg)
+	}
+}
+
+func TestNormalizeRejectsInvalidOptions(t *testing.T) {
+	negative := -1
+	cases := []Options{
+		{Format: "gif"},
+		{Size: MinSize - 1},
+		{Size: MaxSize + 1},
+		{Level: "X"},
+		{QuietZone: &negative},
+	}
+	for _, opts := range cases {
+		if _, err := opts.Normalize(); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+type AuditRepository struct {
+	mu     sync.RWMutex
+	events map[string]*models.AuditEvent
+}
+
+var _ mongo.AuditRepositoryI = (*AuditRepository)(nil)
+
+func NewAuditRepository() *AuditRepository {
+	return &AuditRepository{events: map[string]*models.AuditEvent{}}
+}
+
+func (r *AuditRepository) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	if event == nil {
+		return errors.New("audit event cannot be nil")
+	}
+	if event.EventID == "" {
+		return apperr.Required("event_id")
+	}
+	if event.BusinessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	stored, err := clone(event)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.events[event.EventID]; ok {
+		return errors.New("audit event with this ID already exists")
+	}
+	r.events[event.EventID] = stored
+
+	return nil
+}
+
+// ListAuditEvents returns up to opts.Limit events of a business, newest first,
+// starting after opts.After. A menu entity reference also matches the events
+// recorded for the menu's sections and items.
+func (r *AuditRepository) ListAuditEvents(ctx context.Context, businessID string, opts models.AuditListOptions) ([]models.AuditEvent, error) {
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	menuID, byMenu := strings.CutPrefix(opts.Entity, models.AuditEntityMenu+":")
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []*models.AuditEvent
+	for _, event := range r.events {
+		switch {
+		case event.BusinessID != businessID:
+		case opts.Entity != "" && byMenu && event.MenuID != menuID:
+		case opts.Entity != "" && !byMenu && event.Entity != opts.Entity:
+		case opts.After != nil && !newer(opts.After, event):
+		default:
+			matches = append(matches, event)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return newer(&models.AuditCursor{OccurredAt: matches[i].OccurredAt, ID: matches[i].EventID}, matches[j])
+	})
+	if opts.Limit > 0 && len(matches) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+
+	return cloneAll(matches)
+}
+
+// newer reports whether c comes before event in newest-first order, that is
+// event is older than c or as old with a smaller ID.
+func newer(c *models.AuditCursor, event *models.AuditEvent) bool {
+	if !event.OccurredAt.Equal(c.OccurredAt) {
+		return event.OccurredAt.Before(c.OccurredAt)
+	}
+	return event.EventID < c.ID
+}
@@ -0,0 +1,56 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+)
+
+func TestAuditRepositoryListsNewestFirst(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAuditRepository()
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	events := []models.AuditEvent{
+		{EventID: "e1", BusinessID: "biz-1", Entity: "menu:m1", MenuID: "m1", OccurredAt: at},
+		{EventID: "e2", BusinessID: "biz-1", Entity: "item:i1", MenuID: "m1", OccurredAt: at.Add(time.Minute)},
+		{EventID: "e3", BusinessID: "biz-1", Entity: "menu:m2", MenuID: "m2", OccurredAt: at.Add(time.Minute)},
+		{EventID: "e4", BusinessID: "biz-2", Entity: "menu:m3", MenuID: "m3", OccurredAt: at.Add(time.Hour)},
+	}
+	for i := range events {
+		if err := repo.CreateAuditEvent(ctx, &events[i]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	list := func(opts models.AuditListOptions) string {
+		t.Helper()
+		got, err := repo.ListAuditEvents(ctx, "biz-1", opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var ids []string
+		for _, e := range got {
+			ids = append(ids, e.EventID)
+		}
+		return fmt.Sprint(ids)
+	}
+
+	if got := list(models.AuditListOptions{}); got != "[e3 e2 e1]" {
+		t.Errorf("expected [e3 e2 e1], got %s", got)
+	}
+	if got := list(models.AuditListOptions{Entity: "menu:m1"}); got != "[e2 e1]" {
+		t.Errorf("expected a menu to include its items, got %s", got)
+	}
+	if got := list(models.AuditListOptions{Entity: "item:i1"}); got != "[e2]" {
+		t.Errorf("expected [e2], got %s", got)
+	}
+
+	after := &models.AuditCursor{OccurredAt: at.Add(time.Minute), ID: "e3"}
+	if got := list(models.AuditListOptions{Limit: 1, After: after}); got != "[e2]" {
+		t.Errorf("expected [e2] after the cursor, got %s", got)
+	}
+}
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+type BusinessRepository struct {
+	mu         sync.RWMutex
+	businesses map[string]*models.Business
+}
+
+var _ mongo.BusinessRepositoryI = (*BusinessRepository)(nil)
+
+func NewBusinessRepository() *BusinessRepository {
+	return &BusinessRepository{businesses: map[string]*models.Business{}}
+}
+
+func (r *BusinessRepository) CreateBusiness(ctx context.Context, business *models.Business) error {
+	if business == nil {
+		return errors.New("business cannot be nil")
+	}
+	if business.BusinessID == "" {
+		return apperr.Required("business_id")
+	}
+	if business.Name == "" {
+		return apperr.Invalid("name", "business name is required")
+	}
+
+	stored, err := clone(business)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.businesses[business.BusinessID]; ok {
+		return apperr.Conflict("business with this ID already exists")
+	}
+	r.businesses[business.BusinessID] = stored
+
+	return nil
+}
+
+func (r *BusinessRepository) GetBusinessByID(ctx context.Context, businessID string) (*models.Business, error) {
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	business, ok := r.businesses[businessID]
+	if !ok {
+		return nil, apperr.NotFound("business")
+	}
+	return clone(business)
+}
+
+// UpdateBusiness overwrites the editable fields of a business with the values
+// in updates.
+func (r *BusinessRepository) UpdateBusiness(ctx context.Context, businessID string, updates *models.Business) error {
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+	if updates == nil {
+		return errors.New("updates cannot be nil")
+	}
+
+	updates, err := clone(updates)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	business, ok := r.businesses[businessID]
+	if !ok {
+		return apperr.NotFound("business")
+	}
+	business.Name = updates.Name
+	business.Address = updates.Address
+	business.Timezone = updates.Timezone
+	business.DefaultCurrency = updates.DefaultCurrency
+	business.Locale = updates.Locale
+	business.LogoURL = updates.LogoURL
+	business.UpdatedAt = now()
+
+	return nil
+}
+
+func (r *BusinessRepository) DeleteBusiness(ctx context.Context, businessID string) error {
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.businesses[businessID]; !ok {
+		return apperr.NotFound("business")
+	}
+	delete(r.businesses, businessID)
+
+	return nil
+}
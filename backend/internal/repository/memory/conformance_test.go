@@ -0,0 +1,50 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/custard-technology/abakcus/backend/internal/repository/repotest"
+)
+
+func TestMenuRepositoryConformance(t *testing.T) {
+	repotest.TestMenuRepository(t, func(t *testing.T) mongo.MenuRepositoryI {
+		return NewMenuRepository()
+	})
+}
+
+func TestMenuItemRepositoryConformance(t *testing.T) {
+	repotest.TestMenuItemRepository(t, func(t *testing.T) mongo.MenuItemRepositoryI {
+		return NewMenuItemRepository()
+	})
+}
+
+func TestMenuSectionRepositoryConformance(t *testing.T) {
+	repotest.TestMenuSectionRepository(t, func(t *testing.T) mongo.MenuSectionRepositoryI {
+		return NewMenuSectionRepository()
+	})
+}
+
+func TestMenuVersionRepositoryConformance(t *testing.T) {
+	repotest.TestMenuVersionRepository(t, func(t *testing.T) mongo.MenuVersionRepositoryI {
+		return NewMenuVersionRepository()
+	})
+}
+
+func TestBusinessRepositoryConformance(t *testing.T) {
+	repotest.TestBusinessRepository(t, func(t *testing.T) mongo.BusinessRepositoryI {
+		return NewBusinessRepository()
+	})
+}
+
+func TestUserRepositoryConformance(t *testing.T) {
+	repotest.TestUserRepository(t, func(t *testing.T) mongo.UserRepositoryI {
+		return NewUserRepository()
+	})
+}
+
+func TestAuditRepositoryConformance(t *testing.T) {
+	repotest.TestAuditRepository(t, func(t *testing.T) mongo.AuditRepositoryI {
+		return NewAuditRepository()
+	})
+}
@@ -0,0 +1,43 @@
+// Package memory implements the repository interfaces of package mongo in
+// process memory. It backs STORAGE=memory, which runs the API without a
+// database for demos and local development, and is a faithful stand-in for
+// MongoDB in tests.
+//
+// Every repository is safe for concurrent use and returns the same errors
+// as its MongoDB counterpart. Documents are copied through a BSON round trip
+// on the way in and out, so callers never share memory with the store and
+// read back what MongoDB would return: times in UTC with millisecond
+// precision, and untyped values such as audit changes as BSON types.
+//
+// Data lives as long as the process; nothing is persisted.
+package memory
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// clone returns a deep copy of doc as stored by MongoDB.
+func clone[T any](doc *T) (*T, error) {
+	raw, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	var out T
+	if err := bson.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// cloneAll returns deep copies of docs.
+func cloneAll[T any](docs []*T) ([]T, error) {
+	var out []T
+	for _, doc := range docs {
+		c, err := clone(doc)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, *c)
+	}
+	return out, nil
+}
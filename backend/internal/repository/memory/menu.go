@@ -0,0 +1,376 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// MenuRepository is the in-memory mongo.MenuRepositoryI. Slugs are unique
+// across live and soft-deleted menus, like the slug index in MongoDB.
+type MenuRepository struct {
+	mu    sync.RWMutex
+	menus map[string]*models.Menu
+}
+
+var _ mongo.MenuRepositoryI = (*MenuRepository)(nil)
+
+func NewMenuRepository() *MenuRepository {
+	return &MenuRepository{menus: map[string]*models.Menu{}}
+}
+
+// now is the current time as MongoDB stores it, so that values written by
+// the repository compare equal to the same values read back and turned into
+// cursors.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+func (r *MenuRepository) CreateMenu(ctx context.Context, menu *models.Menu) error {
+	if menu == nil {
+		return errors.New("menu cannot be nil")
+	}
+	if menu.MenuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if menu.Name == "" {
+		return apperr.Invalid("name", "menu name is required")
+	}
+	if menu.BusinessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	stored, err := clone(menu)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.menus[menu.MenuID]; ok {
+		return apperr.Conflict("menu with this ID already exists")
+	}
+	if r.slugTaken(menu.Slug, "") {
+		return mongo.ErrMenuSlugTaken
+	}
+	r.menus[menu.MenuID] = stored
+
+	return nil
+}
+
+func (r *MenuRepository) GetMenuByID(ctx context.Context, menuID, businessID string) (*models.Menu, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	menu := r.live(menuID, businessID)
+	if menu == nil {
+		return nil, apperr.NotFound("menu")
+	}
+	return clone(menu)
+}
+
+// GetMenuBySlug returns the live menu with the given slug regardless of the
+// owning business.
+func (r *MenuRepository) GetMenuBySlug(ctx context.Context, slug string) (*models.Menu, error) {
+	if slug == "" {
+		return nil, apperr.Required("slug")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, menu := range r.menus {
+		if menu.Slug == slug && menu.DeletedAt == nil {
+			return clone(menu)
+		}
+	}
+	return nil, apperr.NotFound("menu")
+}
+
+func (r *MenuRepository) UpdateMenu(ctx context.Context, menuID, businessID string, updates *models.Menu) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+	if updates == nil {
+		return errors.New("updates cannot be nil")
+	}
+
+	var schedule *models.MenuSchedule
+	if updates.Schedule != nil {
+		var err error
+		if schedule, err = clone(updates.Schedule); err != nil {
+			return err
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	menu := r.live(menuID, businessID)
+	if menu == nil {
+		return apperr.NotFound("menu")
+	}
+	if menu.Revision != updates.Revision {
+		return mongo.ErrMenuRevisionConflict
+	}
+	if r.slugTaken(updates.Slug, menuID) {
+		return mongo.ErrMenuSlugTaken
+	}
+
+	// every editable field is written, so empty values clear fields
+	menu.Name = updates.Name
+	menu.Slug = updates.Slug
+	menu.Description = updates.Description
+	menu.IsActive = updates.IsActive
+	menu.Schedule = schedule
+	menu.UpdatedAt = now()
+	menu.Revision = updates.Revision + 1
+
+	return nil
+}
+
+func (r *MenuRepository) DeleteMenu(ctx context.Context, menuID, businessID string, revision int64) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	menu := r.live(menuID, businessID)
+	if menu == nil {
+		return apperr.NotFound("menu")
+	}
+	if menu.Revision != revision {
+		return mongo.ErrMenuRevisionConflict
+	}
+
+	deletedAt := now()
+	menu.DeletedAt = &deletedAt
+	menu.UpdatedAt = deletedAt
+	menu.Revision++
+
+	return nil
+}
+
+// RestoreMenu clears the deleted_at tombstone. Restoring a menu that is not
+// deleted is a no-op: its revision and updated_at stay as they are.
+func (r *MenuRepository) RestoreMenu(ctx context.Context, menuID, businessID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	menu, ok := r.menus[menuID]
+	if !ok || menu.BusinessID != businessID {
+		return apperr.NotFound("menu")
+	}
+	if menu.DeletedAt == nil {
+		return nil
+	}
+	menu.DeletedAt = nil
+	menu.UpdatedAt = now()
+	menu.Revision++
+
+	return nil
+}
+
+// SetMenuPublishedVersion moves the published version forward, never back,
+// and works on soft-deleted menus like its MongoDB counterpart.
+func (r *MenuRepository) SetMenuPublishedVersion(ctx context.Context, menuID, businessID string, number int, publishedAt time.Time) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	menu, ok := r.menus[menuID]
+	if !ok || menu.BusinessID != businessID {
+		return apperr.NotFound("menu")
+	}
+	if number > menu.PublishedVersion {
+		menu.PublishedVersion = number
+	}
+	publishedAt = publishedAt.UTC().Truncate(time.Millisecond)
+	if menu.PublishedAt == nil || publishedAt.After(*menu.PublishedAt) {
+		menu.PublishedAt = &publishedAt
+	}
+	menu.Revision++
+
+	return nil
+}
+
+// GetDeletedMenu returns a soft-deleted menu regardless of the owning
+// business. Live menus are reported as not found.
+func (r *MenuRepository) GetDeletedMenu(ctx context.Context, menuID string) (*models.Menu, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	menu, ok := r.menus[menuID]
+	if !ok || menu.DeletedAt == nil {
+		return nil, apperr.NotFound("menu")
+	}
+	return clone(menu)
+}
+
+// PurgeMenu permanently removes a soft-deleted menu. Menus that are not
+// deleted are reported as not found.
+func (r *MenuRepository) PurgeMenu(ctx context.Context, menuID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	menu, ok := r.menus[menuID]
+	if !ok || menu.DeletedAt == nil {
+		return apperr.NotFound("menu")
+	}
+	delete(r.menus, menuID)
+
+	return nil
+}
+
+// ListMenusByBusiness returns up to opts.Limit live menus of a business that
+// match the filters in opts, ordered by opts.SortBy and ID and starting after
+// opts.After. A zero Limit returns every match.
+func (r *MenuRepository) ListMenusByBusiness(ctx context.Context, businessID string, opts models.MenuListOptions) ([]models.Menu, error) {
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = models.MenuSortCreatedAt
+	}
+	// compare orders a before b in the requested direction
+	compare := func(a *models.Menu, b models.MenuCursor) int {
+		c := compareMenu(a, sortBy, b)
+		if opts.SortDesc {
+			return -c
+		}
+		return c
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []*models.Menu
+	for _, menu := range r.menus {
+		switch {
+		case menu.BusinessID != businessID || menu.DeletedAt != nil:
+		case opts.IsActive != nil && menu.IsActive != *opts.IsActive:
+		case !inRange(menu.CreatedAt, opts.CreatedAfter, opts.CreatedBefore):
+		case !inRange(menu.UpdatedAt, opts.UpdatedAfter, opts.UpdatedBefore):
+		case opts.After != nil && compare(menu, *opts.After) <= 0:
+		default:
+			matches = append(matches, menu)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return compare(matches[i], models.NewMenuCursor(*matches[j], sortBy, opts.SortDesc)) < 0
+	})
+	if opts.Limit > 0 && len(matches) > opts.Limit {
+		matches = matches[:opts.Limit]
+	}
+
+	return cloneAll(matches)
+}
+
+// compareMenu orders menu against the position c by the sort field and then
+// by ID, ascending.
+func compareMenu(menu *models.Menu, sortBy string, c models.MenuCursor) int {
+	var order int
+	switch sortBy {
+	case models.MenuSortName:
+		order = strings.Compare(menu.Name, c.Name)
+	case models.MenuSortUpdatedAt:
+		order = menu.UpdatedAt.Compare(c.Time)
+	default:
+		order = menu.CreatedAt.Compare(c.Time)
+	}
+	if order == 0 {
+		order = strings.Compare(menu.MenuID, c.ID)
+	}
+	return order
+}
+
+// inRange reports whether t is in the inclusive-start, exclusive-end range;
+// nil bounds are open.
+func inRange(t time.Time, after, before *time.Time) bool {
+	return (after == nil || !t.Before(*after)) && (before == nil || t.Before(*before))
+}
+
+// ListDeletedMenus returns menus of every business that were soft-deleted at
+// or before deletedBefore.
+func (r *MenuRepository) ListDeletedMenus(ctx context.Context, deletedBefore time.Time) ([]models.Menu, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []*models.Menu
+	for _, menu := range r.menus {
+		if menu.DeletedAt != nil && !menu.DeletedAt.After(deletedBefore) {
+			matches = append(matches, menu)
+		}
+	}
+	return cloneAll(matches)
+}
+
+// live returns the stored menu if it belongs to businessID and is not
+// deleted. The caller must hold the lock.
+func (r *MenuRepository) live(menuID, businessID string) *models.Menu {
+	menu, ok := r.menus[menuID]
+	if !ok || menu.BusinessID != businessID || menu.DeletedAt != nil {
+		return nil
+	}
+	return menu
+}
+
+// slugTaken reports whether a menu other than exceptID uses slug. The
+// caller must hold the lock.
+func (r *MenuRepository) slugTaken(slug, exceptID string) bool {
+	if slug == "" {
+		return false
+	}
+	for id, menu := range r.menus {
+		if id != exceptID && menu.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,237 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+type MenuItemRepository struct {
+	mu    sync.RWMutex
+	items map[string]*models.MenuItem
+}
+
+var _ mongo.MenuItemRepositoryI = (*MenuItemRepository)(nil)
+
+func NewMenuItemRepository() *MenuItemRepository {
+	return &MenuItemRepository{items: map[string]*models.MenuItem{}}
+}
+
+func (r *MenuItemRepository) CreateMenuItem(ctx context.Context, item *models.MenuItem) error {
+	if item == nil {
+		return errors.New("menu item cannot be nil")
+	}
+	if item.ItemID == "" {
+		return apperr.Required("item_id")
+	}
+	if item.MenuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if item.BusinessID == "" {
+		return apperr.Required("business_id")
+	}
+	if item.Title == "" {
+		return apperr.Invalid("title", "menu item title is required")
+	}
+
+	stored, err := clone(item)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.items[item.ItemID]; ok {
+		return apperr.Conflict("menu item with this ID already exists")
+	}
+	r.items[item.ItemID] = stored
+
+	return nil
+}
+
+func (r *MenuItemRepository) GetMenuItemByID(ctx context.Context, menuID, itemID string) (*models.MenuItem, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+	if itemID == "" {
+		return nil, apperr.Required("item_id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item := r.find(menuID, itemID)
+	if item == nil {
+		return nil, apperr.NotFound("menu item")
+	}
+	return clone(item)
+}
+
+// UpdateMenuItem writes the fields that MongoDB would: empty strings and nil
+// lists leave fields unchanged, while price, is_active and image are always
+// written. The section and position are changed only by ReorderMenuItems.
+func (r *MenuItemRepository) UpdateMenuItem(ctx context.Context, menuID, itemID string, updates *models.MenuItem) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if itemID == "" {
+		return apperr.Required("item_id")
+	}
+	if updates == nil {
+		return errors.New("updates cannot be nil")
+	}
+
+	updates, err := clone(updates)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item := r.find(menuID, itemID)
+	if item == nil {
+		return apperr.NotFound("menu item")
+	}
+	if updates.Title != "" {
+		item.Title = updates.Title
+	}
+	if updates.Description != "" {
+		item.Description = updates.Description
+	}
+	if updates.ImageURL != "" {
+		item.ImageURL = updates.ImageURL
+	}
+	if updates.Ingredients != nil {
+		item.Ingredients = updates.Ingredients
+	}
+	if updates.Allergens != nil {
+		item.Allergens = updates.Allergens
+	}
+	if updates.DietaryTags != nil {
+		item.DietaryTags = updates.DietaryTags
+	}
+	if updates.ModifierGroups != nil {
+		item.ModifierGroups = updates.ModifierGroups
+	}
+	item.Image = updates.Image
+	item.Price = updates.Price
+	item.IsActive = updates.IsActive
+	item.UpdatedAt = now()
+
+	return nil
+}
+
+func (r *MenuItemRepository) DeleteMenuItem(ctx context.Context, menuID, itemID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if itemID == "" {
+		return apperr.Required("item_id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.find(menuID, itemID) == nil {
+		return apperr.NotFound("menu item")
+	}
+	delete(r.items, itemID)
+
+	return nil
+}
+
+// DeleteMenuItemsByMenu removes every item of a menu.
+func (r *MenuItemRepository) DeleteMenuItemsByMenu(ctx context.Context, menuID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, item := range r.items {
+		if item.MenuID == menuID {
+			delete(r.items, id)
+		}
+	}
+	return nil
+}
+
+// ListMenuItemsByMenu returns the items of a menu sorted by section and position.
+func (r *MenuItemRepository) ListMenuItemsByMenu(ctx context.Context, menuID string) ([]models.MenuItem, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []*models.MenuItem
+	for _, item := range r.items {
+		if item.MenuID == menuID {
+			matches = append(matches, item)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.SectionID != b.SectionID {
+			return a.SectionID < b.SectionID
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.ItemID < b.ItemID
+	})
+
+	return cloneAll(matches)
+}
+
+// ReorderMenuItems moves items into their placed section and position. As
+// with the unordered bulk write in MongoDB, the items that exist are moved
+// even when others are not found.
+func (r *MenuItemRepository) ReorderMenuItems(ctx context.Context, menuID string, placements []models.ItemPlacement) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if len(placements) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	updatedAt := now()
+	matched := 0
+	for _, p := range placements {
+		item := r.find(menuID, p.ItemID)
+		if item == nil {
+			continue
+		}
+		item.SectionID = p.SectionID
+		item.Position = p.Position
+		item.UpdatedAt = updatedAt
+		matched++
+	}
+	if matched != len(placements) {
+		return apperr.NotFound("menu item")
+	}
+
+	return nil
+}
+
+// find returns the stored item if it belongs to menuID. The caller must hold
+// the lock.
+func (r *MenuItemRepository) find(menuID, itemID string) *models.MenuItem {
+	item, ok := r.items[itemID]
+	if !ok || item.MenuID != menuID {
+		return nil
+	}
+	return item
+}
@@ -0,0 +1,200 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+type MenuSectionRepository struct {
+	mu       sync.RWMutex
+	sections map[string]*models.MenuSection
+}
+
+var _ mongo.MenuSectionRepositoryI = (*MenuSectionRepository)(nil)
+
+func NewMenuSectionRepository() *MenuSectionRepository {
+	return &MenuSectionRepository{sections: map[string]*models.MenuSection{}}
+}
+
+func (r *MenuSectionRepository) CreateMenuSection(ctx context.Context, section *models.MenuSection) error {
+	if section == nil {
+		return errors.New("menu section cannot be nil")
+	}
+	if section.SectionID == "" {
+		return apperr.Required("section_id")
+	}
+	if section.MenuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if section.Name == "" {
+		return apperr.Invalid("name", "menu section name is required")
+	}
+
+	stored, err := clone(section)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sections[section.SectionID]; ok {
+		return apperr.Conflict("menu section with this ID already exists")
+	}
+	r.sections[section.SectionID] = stored
+
+	return nil
+}
+
+func (r *MenuSectionRepository) GetMenuSectionByID(ctx context.Context, menuID, sectionID string) (*models.MenuSection, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+	if sectionID == "" {
+		return nil, apperr.Required("section_id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	section := r.find(menuID, sectionID)
+	if section == nil {
+		return nil, apperr.NotFound("menu section")
+	}
+	return clone(section)
+}
+
+func (r *MenuSectionRepository) UpdateMenuSection(ctx context.Context, menuID, sectionID string, updates *models.MenuSection) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if sectionID == "" {
+		return apperr.Required("section_id")
+	}
+	if updates == nil {
+		return errors.New("updates cannot be nil")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	section := r.find(menuID, sectionID)
+	if section == nil {
+		return apperr.NotFound("menu section")
+	}
+	if updates.Name != "" {
+		section.Name = updates.Name
+	}
+	section.UpdatedAt = now()
+
+	return nil
+}
+
+func (r *MenuSectionRepository) DeleteMenuSection(ctx context.Context, menuID, sectionID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if sectionID == "" {
+		return apperr.Required("section_id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.find(menuID, sectionID) == nil {
+		return apperr.NotFound("menu section")
+	}
+	delete(r.sections, sectionID)
+
+	return nil
+}
+
+// DeleteMenuSectionsByMenu removes every section of a menu.
+func (r *MenuSectionRepository) DeleteMenuSectionsByMenu(ctx context.Context, menuID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, section := range r.sections {
+		if section.MenuID == menuID {
+			delete(r.sections, id)
+		}
+	}
+	return nil
+}
+
+// ListMenuSectionsByMenu returns the sections of a menu sorted by position.
+func (r *MenuSectionRepository) ListMenuSectionsByMenu(ctx context.Context, menuID string) ([]models.MenuSection, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []*models.MenuSection
+	for _, section := range r.sections {
+		if section.MenuID == menuID {
+			matches = append(matches, section)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Position != matches[j].Position {
+			return matches[i].Position < matches[j].Position
+		}
+		return matches[i].SectionID < matches[j].SectionID
+	})
+
+	return cloneAll(matches)
+}
+
+// ReorderMenuSections assigns each section its index in sectionIDs as
+// position. As with the unordered bulk write in MongoDB, the sections that
+// exist are moved even when others are not found.
+func (r *MenuSectionRepository) ReorderMenuSections(ctx context.Context, menuID string, sectionIDs []string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if len(sectionIDs) == 0 {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	updatedAt := now()
+	matched := 0
+	for i, sectionID := range sectionIDs {
+		section := r.find(menuID, sectionID)
+		if section == nil {
+			continue
+		}
+		section.Position = i
+		section.UpdatedAt = updatedAt
+		matched++
+	}
+	if matched != len(sectionIDs) {
+		return apperr.NotFound("menu section")
+	}
+
+	return nil
+}
+
+// find returns the stored section if it belongs to menuID. The caller must
+// hold the lock.
+func (r *MenuSectionRepository) find(menuID, sectionID string) *models.MenuSection {
+	section, ok := r.sections[sectionID]
+	if !ok || section.MenuID != menuID {
+		return nil
+	}
+	return section
+}
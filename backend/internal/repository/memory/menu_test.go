@@ -0,0 +1,186 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+func newMenu(id, slug string, createdAt time.Time) *models.Menu {
+	return &models.Menu{
+		MenuID:     id,
+		BusinessID: "biz-1",
+		Name:       "Menu " + id,
+		Slug:       slug,
+		IsActive:   true,
+		Revision:   1,
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}
+}
+
+func TestMenuRepositoryErrorsMatchMongo(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMenuRepository()
+	created := time.Now()
+
+	if err := repo.CreateMenu(ctx, newMenu("m1", "brunch", created)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.CreateMenu(ctx, newMenu("m1", "other", created)); !errors.Is(err, apperr.ErrConflict) {
+		t.Errorf("expected conflict for duplicate ID, got %v", err)
+	}
+	if err := repo.CreateMenu(ctx, newMenu("m2", "brunch", created)); !errors.Is(err, mongo.ErrMenuSlugTaken) {
+		t.Errorf("expected ErrMenuSlugTaken, got %v", err)
+	}
+	if err := repo.CreateMenu(ctx, &models.Menu{MenuID: "m3", BusinessID: "biz-1"}); !errors.Is(err, apperr.ErrValidation) {
+		t.Errorf("expected validation error for missing name, got %v", err)
+	}
+
+	if _, err := repo.GetMenuByID(ctx, "m1", "biz-2"); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("expected not found for another business, got %v", err)
+	}
+
+	update := newMenu("m1", "brunch-2", created)
+	update.Revision = 7
+	if err := repo.UpdateMenu(ctx, "m1", "biz-1", update); !errors.Is(err, mongo.ErrMenuRevisionConflict) {
+		t.Errorf("expected revision conflict, got %v", err)
+	}
+	update.Revision = 1
+	if err := repo.UpdateMenu(ctx, "m1", "biz-1", update); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	menu, err := repo.GetMenuBySlug(ctx, "brunch-2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if menu.Revision != 2 {
+		t.Errorf("expected revision 2, got %d", menu.Revision)
+	}
+
+	// deleted menus keep their slug and can only be purged once deleted
+	if err := repo.PurgeMenu(ctx, "m1"); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("expected not found when purging a live menu, got %v", err)
+	}
+	if err := repo.DeleteMenu(ctx, "m1", "biz-1", 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := repo.GetMenuByID(ctx, "m1", "biz-1"); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("expected deleted menu to be hidden, got %v", err)
+	}
+	if err := repo.CreateMenu(ctx, newMenu("m4", "brunch-2", created)); !errors.Is(err, mongo.ErrMenuSlugTaken) {
+		t.Errorf("expected slug of deleted menu to stay taken, got %v", err)
+	}
+	if err := repo.PurgeMenu(ctx, "m1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestMenuRepositoryCopiesDocuments(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMenuRepository()
+
+	menu := newMenu("m1", "brunch", time.Date(2026, 1, 2, 3, 4, 5, 678901234, time.FixedZone("X", 3600)))
+	menu.Schedule = &models.MenuSchedule{Windows: []models.WeeklyWindow{{Days: []string{"mon"}, Start: "08:00", End: "11:00"}}}
+	if err := repo.CreateMenu(ctx, menu); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	menu.Name = "changed"
+	menu.Schedule.Windows[0].Start = "changed"
+
+	got, err := repo.GetMenuByID(ctx, "m1", "biz-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Name != "Menu m1" || got.Schedule.Windows[0].Start != "08:00" {
+		t.Errorf("stored menu changed with the caller's copy: %+v", got)
+	}
+	if want := time.Date(2026, 1, 2, 2, 4, 5, 678000000, time.UTC); !got.CreatedAt.Equal(want) || got.CreatedAt.Location() != time.UTC {
+		t.Errorf("expected created_at %v in UTC with millisecond precision, got %v", want, got.CreatedAt)
+	}
+
+	got.Name = "changed again"
+	again, _ := repo.GetMenuByID(ctx, "m1", "biz-1")
+	if again.Name != "Menu m1" {
+		t.Errorf("stored menu changed with a returned copy: %q", again.Name)
+	}
+}
+
+func TestMenuRepositoryListPages(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMenuRepository()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// m0..m4, with m3 and m4 created at the same time
+	for i := 0; i < 5; i++ {
+		created := start.Add(time.Duration(min(i, 3)) * time.Hour)
+		if err := repo.CreateMenu(ctx, newMenu(fmt.Sprintf("m%d", i), fmt.Sprintf("s%d", i), created)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	other := newMenu("x", "x", start)
+	other.BusinessID = "biz-2"
+	if err := repo.CreateMenu(ctx, other); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var ids []string
+	opts := models.MenuListOptions{Limit: 2, SortBy: models.MenuSortCreatedAt, SortDesc: true}
+	for {
+		page, err := repo.ListMenusByBusiness(ctx, "biz-1", opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, m := range page {
+			ids = append(ids, m.MenuID)
+		}
+		if len(page) < opts.Limit {
+			break
+		}
+		c := models.NewMenuCursor(page[len(page)-1], opts.SortBy, opts.SortDesc)
+		opts.After = &c
+	}
+
+	if got, want := fmt.Sprint(ids), "[m4 m3 m2 m1 m0]"; got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestMenuRepositoryConcurrentUpdates(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMenuRepository()
+	if err := repo.CreateMenu(ctx, newMenu("m1", "brunch", time.Now())); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// every writer sends revision 1; exactly one may win
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	won := 0
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			update := newMenu("m1", fmt.Sprintf("slug-%d", i), time.Now())
+			if err := repo.UpdateMenu(ctx, "m1", "biz-1", update); err == nil {
+				mu.Lock()
+				won++
+				mu.Unlock()
+			} else if !errors.Is(err, mongo.ErrMenuRevisionConflict) {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if won != 1 {
+		t.Errorf("expected exactly one update to win, got %d", won)
+	}
+}
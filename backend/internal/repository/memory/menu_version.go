@@ -0,0 +1,129 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// MenuVersionRepository is the in-memory mongo.MenuVersionRepositoryI.
+// Version numbers are unique per menu, like the menu_number_unique index.
+type MenuVersionRepository struct {
+	mu       sync.RWMutex
+	versions map[string]*models.MenuVersion
+}
+
+var _ mongo.MenuVersionRepositoryI = (*MenuVersionRepository)(nil)
+
+func NewMenuVersionRepository() *MenuVersionRepository {
+	return &MenuVersionRepository{versions: map[string]*models.MenuVersion{}}
+}
+
+func (r *MenuVersionRepository) CreateMenuVersion(ctx context.Context, version *models.MenuVersion) error {
+	if version == nil {
+		return errors.New("menu version cannot be nil")
+	}
+	if version.VersionID == "" {
+		return apperr.Required("version_id")
+	}
+	if version.MenuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if version.Number < 1 {
+		return apperr.Invalid("number", "menu version number must be positive")
+	}
+
+	stored, err := clone(version)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.versions[version.VersionID]; ok || r.find(version.MenuID, version.Number) != nil {
+		return mongo.ErrMenuVersionExists
+	}
+	r.versions[version.VersionID] = stored
+
+	return nil
+}
+
+func (r *MenuVersionRepository) GetMenuVersion(ctx context.Context, menuID string, number int) (*models.MenuVersion, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	version := r.find(menuID, number)
+	if version == nil {
+		return nil, apperr.NotFound("menu version")
+	}
+	return clone(version)
+}
+
+// ListMenuVersions returns the versions of a menu, newest first, without
+// their sections and items.
+func (r *MenuVersionRepository) ListMenuVersions(ctx context.Context, menuID string) ([]models.MenuVersion, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var matches []*models.MenuVersion
+	for _, version := range r.versions {
+		if version.MenuID == menuID {
+			matches = append(matches, version)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		return matches[i].Number > matches[j].Number
+	})
+
+	versions, err := cloneAll(matches)
+	if err != nil {
+		return nil, err
+	}
+	for i := range versions {
+		versions[i].Sections = nil
+		versions[i].Items = nil
+	}
+	return versions, nil
+}
+
+// DeleteMenuVersionsByMenu removes every version of a menu.
+func (r *MenuVersionRepository) DeleteMenuVersionsByMenu(ctx context.Context, menuID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, version := range r.versions {
+		if version.MenuID == menuID {
+			delete(r.versions, id)
+		}
+	}
+	return nil
+}
+
+// find returns the stored version of menuID with the given number. The
+// caller must hold the lock.
+func (r *MenuVersionRepository) find(menuID string, number int) *models.MenuVersion {
+	for _, version := range r.versions {
+		if version.MenuID == menuID && version.Number == number {
+			return version
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// UserRepository is the in-memory mongo.UserRepositoryI. Emails are unique,
+// like the email index in MongoDB.
+type UserRepository struct {
+	mu    sync.RWMutex
+	users map[string]*models.User
+}
+
+var _ mongo.UserRepositoryI = (*UserRepository)(nil)
+
+func NewUserRepository() *UserRepository {
+	return &UserRepository{users: map[string]*models.User{}}
+}
+
+func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) error {
+	if user == nil {
+		return errors.New("user cannot be nil")
+	}
+	if user.UserID == "" {
+		return apperr.Required("user_id")
+	}
+	if user.Email == "" {
+		return apperr.Required("email")
+	}
+	if user.PasswordHash == "" {
+		return apperr.Required("password_hash")
+	}
+
+	stored, err := clone(user)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[user.UserID]; ok || r.byEmail(user.Email) != nil {
+		return apperr.Conflict("user with this email already exists")
+	}
+	r.users[user.UserID] = stored
+
+	return nil
+}
+
+func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	if email == "" {
+		return nil, apperr.Required("email")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user := r.byEmail(email)
+	if user == nil {
+		return nil, apperr.NotFound("user")
+	}
+	return clone(user)
+}
+
+// SetUserRole changes the role of the user with the given email.
+func (r *UserRepository) SetUserRole(ctx context.Context, email, role string, updatedAt time.Time) error {
+	if email == "" {
+		return apperr.Required("email")
+	}
+	if role == "" {
+		return apperr.Required("role")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user := r.byEmail(email)
+	if user == nil {
+		return apperr.NotFound("user")
+	}
+	user.Role = role
+	user.UpdatedAt = updatedAt
+
+	return nil
+}
+
+// byEmail returns the stored user with the given email. The caller must hold
+// the lock.
+func (r *UserRepository) byEmail(email string) *models.User {
+	for _, user := range r.users {
+		if user.Email == email {
+			return user
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+)
+
+// AuditRepositoryI defines the interface for audit log repository
+// operations. Events are append-only.
+type AuditRepositoryI interface {
+	CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error
+	ListAuditEvents(ctx context.Context, businessID string, opts models.AuditListOptions) ([]models.AuditEvent, error)
+}
+
+type AuditRepository struct {
+	store
+}
+
+func NewAuditRepository(client *mongo.Client, dbName string) *AuditRepository {
+	return &AuditRepository{store: newStore(client, dbName)}
+}
+
+func (r *AuditRepository) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	if event == nil {
+		return errors.New("audit event cannot be nil")
+	}
+	if event.EventID == "" {
+		return apperr.Required("event_id")
+	}
+	if event.BusinessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("audit_events")
+	_, err := coll.InsertOne(ctx, event)
+	return err
+}
+
+// ListAuditEvents returns up to opts.Limit events of a business, newest first,
+// starting after opts.After. A menu entity reference also matches the events
+// recorded for the menu's sections and items.
+func (r *AuditRepository) ListAuditEvents(ctx context.Context, businessID string, opts models.AuditListOptions) ([]models.AuditEvent, error) {
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"business_id": businessID}
+	if opts.Entity != "" {
+		if menuID, ok := strings.CutPrefix(opts.Entity, models.AuditEntityMenu+":"); ok {
+			filter["menu_id"] = menuID
+		} else {
+			filter["entity"] = opts.Entity
+		}
+	}
+	if c := opts.After; c != nil {
+		filter["$or"] = bson.A{
+			bson.M{"occurred_at": bson.M{"$lt": c.OccurredAt}},
+			bson.M{"occurred_at": c.OccurredAt, "_id": bson.M{"$lt": c.ID}},
+		}
+	}
+
+	findOpts := options.Find().SetSort(bson.D{{Key: "occurred_at", Value: -1}, {Key: "_id", Value: -1}})
+	if opts.Limit > 0 {
+		findOpts.SetLimit(int64(opts.Limit))
+	}
+
+	coll := r.client.Database(r.dbName).Collection("audit_events")
+	cursor, err := coll.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var events []models.AuditEvent
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// EnsureAuditIndexes creates the indexes behind ListAuditEvents: one for a
+// business's whole log, one per menu and one per entity.
+func (r *AuditRepository) EnsureAuditIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var indexes []mongo.IndexModel
+	for _, field := range []string{"", "menu_id", "entity"} {
+		keys := bson.D{{Key: "business_id", Value: 1}}
+		name := "business"
+		if field != "" {
+			keys = append(keys, bson.E{Key: field, Value: 1})
+			name += "_" + field
+		}
+		keys = append(keys, bson.E{Key: "occurred_at", Value: -1}, bson.E{Key: "_id", Value: -1})
+		indexes = append(indexes, mongo.IndexModel{Keys: keys, Options: options.Index().SetName(name + "_time")})
+	}
+
+	coll := r.client.Database(r.dbName).Collection("audit_events")
+	_, err := coll.Indexes().CreateMany(ctx, indexes)
+	return err
+}
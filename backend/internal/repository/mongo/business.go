@@ -0,0 +1,186 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+)
+
+// BusinessRepositoryI defines the interface for business repository operations.
+type BusinessRepositoryI interface {
+	CreateBusiness(ctx context.Context, business *models.Business) error
+	GetBusinessByID(ctx context.Context, businessID string) (*models.Business, error)
+	UpdateBusiness(ctx context.Context, businessID string, updates *models.Business) error
+	DeleteBusiness(ctx context.Context, businessID string) error
+}
+
+type BusinessRepository struct {
+	store
+}
+
+func NewBusinessRepository(client *mongo.Client, dbName string) *BusinessRepository {
+	return &BusinessRepository{store: newStore(client, dbName)}
+}
+
+func (r *BusinessRepository) CreateBusiness(ctx context.Context, business *models.Business) error {
+	if business == nil {
+		return errors.New("business cannot be nil")
+	}
+	if business.BusinessID == "" {
+		return apperr.Required("business_id")
+	}
+	if business.Name == "" {
+		return apperr.Invalid("name", "business name is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("businesses")
+	_, err := coll.InsertOne(ctx, business)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return apperr.Conflict("business with this ID already exists")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *BusinessRepository) GetBusinessByID(ctx context.Context, businessID string) (*models.Business, error) {
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("businesses")
+	var business models.Business
+	err := coll.FindOne(ctx, bson.M{"_id": businessID}).Decode(&business)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.NotFound("business")
+		}
+		return nil, err
+	}
+
+	return &business, nil
+}
+
+// UpdateBusiness overwrites the editable fields of a business with the values
+// in updates. The service merges a request into the stored business first.
+func (r *BusinessRepository) UpdateBusiness(ctx context.Context, businessID string, updates *models.Business) error {
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+	if updates == nil {
+		return errors.New("updates cannot be nil")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	updateFields := bson.M{
+		"name":             updates.Name,
+		"address":          updates.Address,
+		"timezone":         updates.Timezone,
+		"default_currency": updates.DefaultCurrency,
+		"locale":           updates.Locale,
+		"logo_url":         updates.LogoURL,
+		"updated_at":       time.Now(),
+	}
+
+	coll := r.client.Database(r.dbName).Collection("businesses")
+	result := coll.FindOneAndUpdate(ctx, bson.M{"_id": businessID}, bson.M{"$set": updateFields})
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return apperr.NotFound("business")
+		}
+		return result.Err()
+	}
+
+	return nil
+}
+
+// DeleteBusiness removes a business. It is only used to roll back a failed
+// registration; businesses with users or menus are never deleted.
+func (r *BusinessRepository) DeleteBusiness(ctx context.Context, businessID string) error {
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("businesses")
+	result, err := coll.DeleteOne(ctx, bson.M{"_id": businessID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return apperr.NotFound("business")
+	}
+
+	return nil
+}
+
+// BackfillBusinesses creates a business document for every business_id used
+// by a user or menu that predates the businesses collection. The documents
+// get a placeholder name and the given defaults for owners to edit. It
+// returns the number of businesses created.
+func (r *BusinessRepository) BackfillBusinesses(ctx context.Context, defaults models.Business) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	db := r.client.Database(r.dbName)
+	ids := map[string]bool{}
+	for _, name := range []string{"users", "menus"} {
+		values, err := db.Collection(name).Distinct(ctx, "business_id", bson.M{})
+		if err != nil {
+			return 0, err
+		}
+		for _, v := range values {
+			if id, ok := v.(string); ok && id != "" {
+				ids[id] = true
+			}
+		}
+	}
+
+	// the filter supplies _id on insert; it must not appear in the update too
+	defaults.CreatedAt = time.Now()
+	defaults.UpdatedAt = defaults.CreatedAt
+	raw, err := bson.Marshal(defaults)
+	if err != nil {
+		return 0, err
+	}
+	var insert bson.M
+	if err := bson.Unmarshal(raw, &insert); err != nil {
+		return 0, err
+	}
+	delete(insert, "_id")
+
+	var created int64
+	coll := db.Collection("businesses")
+	for id := range ids {
+		result, err := coll.UpdateOne(ctx,
+			bson.M{"_id": id},
+			bson.M{"$setOnInsert": insert},
+			options.Update().SetUpsert(true))
+		if err != nil {
+			return created, err
+		}
+		created += result.UpsertedCount
+	}
+
+	return created, nil
+}
@@ -0,0 +1,110 @@
+package mongo_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/custard-technology/abakcus/backend/internal/config"
+	mongopkg "github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/custard-technology/abakcus/backend/internal/repository/repotest"
+)
+
+// testClient connects to the mongod named by MONGO_TEST_URI, or to one on
+// localhost:27017 if it is running. The test is skipped when neither is
+// available; an unreachable MONGO_TEST_URI fails it.
+func testClient(t *testing.T) *mongo.Client {
+	t.Helper()
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		conn, err := net.DialTimeout("tcp", "localhost:27017", 250*time.Millisecond)
+		if err != nil {
+			t.Skip("no mongod on localhost:27017; set MONGO_TEST_URI to run against MongoDB")
+		}
+		conn.Close()
+		uri = "mongodb://localhost:27017"
+	}
+
+	client, err := mongopkg.NewClient(context.Background(), config.MongoConfig{URI: uri, Database: "test"})
+	if err != nil {
+		t.Fatalf("connecting to %s: %v", uri, err)
+	}
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+	return client
+}
+
+// testDatabase returns the name of a new database with the indexes of the
+// application, dropped when t ends.
+func testDatabase(t *testing.T, client *mongo.Client) string {
+	t.Helper()
+	name := fmt.Sprintf("abakcus_test_%d", time.Now().UnixNano())
+	t.Cleanup(func() { client.Database(name).Drop(context.Background()) })
+
+	if err := mongopkg.NewMenuRepository(client, name).EnsureMenuIndexes(context.Background()); err != nil {
+		t.Fatalf("creating menu indexes: %v", err)
+	}
+	if err := mongopkg.NewUserRepository(client, name).EnsureUserIndexes(context.Background()); err != nil {
+		t.Fatalf("creating user indexes: %v", err)
+	}
+	if err := mongopkg.NewMenuVersionRepository(client, name).EnsureMenuVersionIndexes(context.Background()); err != nil {
+		t.Fatalf("creating menu version indexes: %v", err)
+	}
+	if err := mongopkg.NewAuditRepository(client, name).EnsureAuditIndexes(context.Background()); err != nil {
+		t.Fatalf("creating audit indexes: %v", err)
+	}
+	return name
+}
+
+func TestMenuRepositoryConformance(t *testing.T) {
+	client := testClient(t)
+	repotest.TestMenuRepository(t, func(t *testing.T) mongopkg.MenuRepositoryI {
+		return mongopkg.NewMenuRepository(client, testDatabase(t, client))
+	})
+}
+
+func TestMenuItemRepositoryConformance(t *testing.T) {
+	client := testClient(t)
+	repotest.TestMenuItemRepository(t, func(t *testing.T) mongopkg.MenuItemRepositoryI {
+		return mongopkg.NewMenuItemRepository(client, testDatabase(t, client))
+	})
+}
+
+func TestMenuSectionRepositoryConformance(t *testing.T) {
+	client := testClient(t)
+	repotest.TestMenuSectionRepository(t, func(t *testing.T) mongopkg.MenuSectionRepositoryI {
+		return mongopkg.NewMenuSectionRepository(client, testDatabase(t, client))
+	})
+}
+
+func TestMenuVersionRepositoryConformance(t *testing.T) {
+	client := testClient(t)
+	repotest.TestMenuVersionRepository(t, func(t *testing.T) mongopkg.MenuVersionRepositoryI {
+		return mongopkg.NewMenuVersionRepository(client, testDatabase(t, client))
+	})
+}
+
+func TestBusinessRepositoryConformance(t *testing.T) {
+	client := testClient(t)
+	repotest.TestBusinessRepository(t, func(t *testing.T) mongopkg.BusinessRepositoryI {
+		return mongopkg.NewBusinessRepository(client, testDatabase(t, client))
+	})
+}
+
+func TestUserRepositoryConformance(t *testing.T) {
+	client := testClient(t)
+	repotest.TestUserRepository(t, func(t *testing.T) mongopkg.UserRepositoryI {
+		return mongopkg.NewUserRepository(client, testDatabase(t, client))
+	})
+}
+
+func TestAuditRepositoryConformance(t *testing.T) {
+	client := testClient(t)
+	repotest.TestAuditRepository(t, func(t *testing.T) mongopkg.AuditRepositoryI {
+		return mongopkg.NewAuditRepository(client, testDatabase(t, client))
+	})
+}
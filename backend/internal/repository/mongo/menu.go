@@ -3,30 +3,56 @@ package mongo
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
 	"github.com/custard-technology/abakcus/backend/internal/models"
 )
 
 // MenuRepositoryI defines the interface for menu repository operations.
+// Lookups by ID also take the owning business; a menu that exists under
+// another business is reported as not found.
+//
+// DeleteMenu is a soft delete: it sets deleted_at and the menu disappears from
+// every read until RestoreMenu clears it. GetDeletedMenu reads a soft-deleted
+// menu of any business and PurgeMenu removes it for good.
+//
+// Every write bumps the menu's revision. UpdateMenu and DeleteMenu only apply
+// to the revision the caller read and return ErrMenuRevisionConflict when the
+// menu has moved on since.
 type MenuRepositoryI interface {
 	CreateMenu(ctx context.Context, menu *models.Menu) error
-	GetMenuByID(ctx context.Context, menuID string) (*models.Menu, error)
-	UpdateMenu(ctx context.Context, menuID string, updates *models.Menu) error
-	DeleteMenu(ctx context.Context, menuID string) error
-	ListMenusByBusiness(ctx context.Context, businessID string) ([]models.Menu, error)
+	GetMenuByID(ctx context.Context, menuID, businessID string) (*models.Menu, error)
+	GetMenuBySlug(ctx context.Context, slug string) (*models.Menu, error)
+	UpdateMenu(ctx context.Context, menuID, businessID string, updates *models.Menu) error
+	DeleteMenu(ctx context.Context, menuID, businessID string, revision int64) error
+	RestoreMenu(ctx context.Context, menuID, businessID string) error
+	GetDeletedMenu(ctx context.Context, menuID string) (*models.Menu, error)
+	PurgeMenu(ctx context.Context, menuID string) error
+	ListMenusByBusiness(ctx context.Context, businessID string, opts models.MenuListOptions) ([]models.Menu, error)
+	ListDeletedMenus(ctx context.Context, deletedBefore time.Time) ([]models.Menu, error)
+	SetMenuPublishedVersion(ctx context.Context, menuID, businessID string, number int, publishedAt time.Time) error
 }
 
+// ErrMenuSlugTaken is returned by CreateMenu and UpdateMenu when another menu,
+// live or soft-deleted, already uses the slug.
+var ErrMenuSlugTaken = apperr.Conflict("menu slug already exists")
+
+// ErrMenuRevisionConflict is returned by UpdateMenu and DeleteMenu when the
+// menu exists but no longer has the expected revision.
+var ErrMenuRevisionConflict = apperr.New(apperr.ErrPreconditionFailed, "menu revision does not match")
+
 type MenuRepository struct {
-	client *mongo.Client
-	dbName string
+	store
 }
 
 func NewMenuRepository(client *mongo.Client, dbName string) *MenuRepository {
-	return &MenuRepository{client: client, dbName: dbName}
+	return &MenuRepository{store: newStore(client, dbName)}
 }
 
 func (r *MenuRepository) CreateMenu(ctx context.Context, menu *models.Menu) error {
@@ -34,23 +60,26 @@ func (r *MenuRepository) CreateMenu(ctx context.Context, menu *models.Menu) erro
 		return errors.New("menu cannot be nil")
 	}
 	if menu.MenuID == "" {
-		return errors.New("menu_id is required")
+		return apperr.Required("menu_id")
 	}
 	if menu.Name == "" {
-		return errors.New("menu name is required")
+		return apperr.Invalid("name", "menu name is required")
 	}
 	if menu.BusinessID == "" {
-		return errors.New("business_id is required")
+		return apperr.Required("business_id")
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	coll := r.client.Database(r.dbName).Collection("menus")
 	_, err := coll.InsertOne(ctx, menu)
 	if err != nil {
+		if isSlugConflict(err) {
+			return ErrMenuSlugTaken
+		}
 		if mongo.IsDuplicateKeyError(err) {
-			return errors.New("menu with this ID already exists")
+			return apperr.Conflict("menu with this ID already exists")
 		}
 		return err
 	}
@@ -58,20 +87,23 @@ func (r *MenuRepository) CreateMenu(ctx context.Context, menu *models.Menu) erro
 	return nil
 }
 
-func (r *MenuRepository) GetMenuByID(ctx context.Context, menuID string) (*models.Menu, error) {
+func (r *MenuRepository) GetMenuByID(ctx context.Context, menuID, businessID string) (*models.Menu, error) {
 	if menuID == "" {
-		return nil, errors.New("menu_id is required")
+		return nil, apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	coll := r.client.Database(r.dbName).Collection("menus")
 	var menu models.Menu
-	err := coll.FindOne(ctx, bson.M{"_id": menuID}).Decode(&menu)
+	err := coll.FindOne(ctx, bson.M{"_id": menuID, "business_id": businessID, "deleted_at": nil}).Decode(&menu)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, errors.New("menu not found")
+			return nil, apperr.NotFound("menu")
 		}
 		return nil, err
 	}
@@ -79,32 +111,31 @@ func (r *MenuRepository) GetMenuByID(ctx context.Context, menuID string) (*model
 	return &menu, nil
 }
 
-func (r *MenuRepository) UpdateMenu(ctx context.Context, menuID string, updates *models.Menu) error {
+func (r *MenuRepository) UpdateMenu(ctx context.Context, menuID, businessID string, updates *models.Menu) error {
 	if menuID == "" {
-		return errors.New("menu_id is required")
+		return apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return apperr.Required("business_id")
 	}
 	if updates == nil {
 		return errors.New("updates cannot be nil")
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	updateFields := bson.M{}
-	if updates.Name != "" {
-		updateFields["name"] = updates.Name
-	}
-	if updates.Description != "" {
-		updateFields["description"] = updates.Description
-	}
-	updateFields["updated_at"] = time.Now()
-	updateFields["is_active"] = updates.IsActive
-
+	// the revision in the filter makes the check and the write one operation
 	coll := r.client.Database(r.dbName).Collection("menus")
-	result := coll.FindOneAndUpdate(ctx, bson.M{"_id": menuID}, bson.M{"$set": updateFields})
+	result := coll.FindOneAndUpdate(ctx,
+		bson.M{"_id": menuID, "business_id": businessID, "deleted_at": nil, "revision": updates.Revision},
+		menuUpdate(updates, time.Now()))
 	if result.Err() != nil {
 		if result.Err() == mongo.ErrNoDocuments {
-			return errors.New("menu not found")
+			return r.missedMenuError(ctx, menuID, businessID)
+		}
+		if isSlugConflict(result.Err()) {
+			return ErrMenuSlugTaken
 		}
 		return result.Err()
 	}
@@ -112,37 +143,404 @@ func (r *MenuRepository) UpdateMenu(ctx context.Context, menuID string, updates
 	return nil
 }
 
-func (r *MenuRepository) DeleteMenu(ctx context.Context, menuID string) error {
+// menuUpdate returns the update document that writes every editable field of
+// updates. Optional fields that are empty or nil are $unset rather than
+// stored empty, so a cleared menu has the shape CreateMenu gives a menu
+// created without them; an empty slug in particular would collide in the
+// unique slug index.
+func menuUpdate(updates *models.Menu, now time.Time) bson.M {
+	set := bson.M{
+		"name":       updates.Name,
+		"is_active":  updates.IsActive,
+		"updated_at": now,
+		"revision":   updates.Revision + 1,
+	}
+	unset := bson.M{}
+	optional := func(field string, value any, empty bool) {
+		if empty {
+			unset[field] = ""
+		} else {
+			set[field] = value
+		}
+	}
+	optional("slug", updates.Slug, updates.Slug == "")
+	optional("description", updates.Description, updates.Description == "")
+	optional("schedule", updates.Schedule, updates.Schedule == nil)
+
+	update := bson.M{"$set": set}
+	if len(unset) > 0 {
+		update["$unset"] = unset
+	}
+	return update
+}
+
+// GetMenuBySlug returns the live menu with the given slug regardless of the
+// owning business. It backs the public menu endpoint.
+func (r *MenuRepository) GetMenuBySlug(ctx context.Context, slug string) (*models.Menu, error) {
+	if slug == "" {
+		return nil, apperr.Required("slug")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menus")
+	var menu models.Menu
+	err := coll.FindOne(ctx, bson.M{"slug": slug, "deleted_at": nil}).Decode(&menu)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.NotFound("menu")
+		}
+		return nil, err
+	}
+
+	return &menu, nil
+}
+
+// isSlugConflict reports whether err is a duplicate key error on the slug
+// index.
+func isSlugConflict(err error) bool {
+	return mongo.IsDuplicateKeyError(err) && strings.Contains(err.Error(), menuSlugIndex)
+}
+
+// missedMenuError explains why a conditional write matched no live menu: it
+// either does not exist for businessID or has a different revision.
+func (r *MenuRepository) missedMenuError(ctx context.Context, menuID, businessID string) error {
+	coll := r.client.Database(r.dbName).Collection("menus")
+	n, err := coll.CountDocuments(ctx, bson.M{"_id": menuID, "business_id": businessID, "deleted_at": nil})
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrMenuRevisionConflict
+	}
+	return apperr.NotFound("menu")
+}
+
+func (r *MenuRepository) DeleteMenu(ctx context.Context, menuID, businessID string, revision int64) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	now := time.Now()
+	coll := r.client.Database(r.dbName).Collection("menus")
+	result, err := coll.UpdateOne(ctx,
+		bson.M{"_id": menuID, "business_id": businessID, "deleted_at": nil, "revision": revision},
+		bson.M{"$set": bson.M{"deleted_at": now, "updated_at": now}, "$inc": bson.M{"revision": 1}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return r.missedMenuError(ctx, menuID, businessID)
+	}
+
+	return nil
+}
+
+// RestoreMenu clears the deleted_at tombstone. Restoring a menu that is not
+// deleted is a no-op: its revision and updated_at stay as they are.
+func (r *MenuRepository) RestoreMenu(ctx context.Context, menuID, businessID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menus")
+	result, err := coll.UpdateOne(ctx,
+		bson.M{"_id": menuID, "business_id": businessID, "deleted_at": bson.M{"$ne": nil}},
+		bson.M{"$unset": bson.M{"deleted_at": ""}, "$set": bson.M{"updated_at": time.Now()}, "$inc": bson.M{"revision": 1}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		// missing the filter on a live menu means there was nothing to
+		// restore
+		if err := r.missedMenuError(ctx, menuID, businessID); !errors.Is(err, ErrMenuRevisionConflict) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetMenuPublishedVersion points the menu at version number. The pointer only
+// moves forward, so a slow publish finishing after a newer one does not undo
+// it. It leaves updated_at alone because the draft did not change, and works
+// on soft-deleted menus so the versioning backfill can cover them. The
+// revision is bumped all the same because the menu's representation changed.
+func (r *MenuRepository) SetMenuPublishedVersion(ctx context.Context, menuID, businessID string, number int, publishedAt time.Time) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menus")
+	result, err := coll.UpdateOne(ctx,
+		bson.M{"_id": menuID, "business_id": businessID},
+		bson.M{"$max": bson.M{"published_version": number, "published_at": publishedAt}, "$inc": bson.M{"revision": 1}})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return apperr.NotFound("menu")
+	}
+
+	return nil
+}
+
+// GetDeletedMenu returns a soft-deleted menu regardless of the owning
+// business. Live menus are reported as not found.
+func (r *MenuRepository) GetDeletedMenu(ctx context.Context, menuID string) (*models.Menu, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menus")
+	var menu models.Menu
+	err := coll.FindOne(ctx, bson.M{"_id": menuID, "deleted_at": bson.M{"$ne": nil}}).Decode(&menu)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.NotFound("menu")
+		}
+		return nil, err
+	}
+
+	return &menu, nil
+}
+
+// PurgeMenu permanently removes a soft-deleted menu. Menus that are not
+// deleted are reported as not found so live data is never purged.
+func (r *MenuRepository) PurgeMenu(ctx context.Context, menuID string) error {
 	if menuID == "" {
-		return errors.New("menu_id is required")
+		return apperr.Required("menu_id")
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
 	coll := r.client.Database(r.dbName).Collection("menus")
-	result, err := coll.DeleteOne(ctx, bson.M{"_id": menuID})
+	result, err := coll.DeleteOne(ctx, bson.M{"_id": menuID, "deleted_at": bson.M{"$ne": nil}})
 	if err != nil {
 		return err
 	}
 
 	if result.DeletedCount == 0 {
-		return errors.New("menu not found")
+		return apperr.NotFound("menu")
 	}
 
 	return nil
 }
 
-func (r *MenuRepository) ListMenusByBusiness(ctx context.Context, businessID string) ([]models.Menu, error) {
+// ListMenusByBusiness returns up to opts.Limit live menus of a business that
+// match the filters in opts, ordered by opts.SortBy and _id and starting after
+// opts.After. A zero Limit returns every match.
+func (r *MenuRepository) ListMenusByBusiness(ctx context.Context, businessID string, opts models.MenuListOptions) ([]models.Menu, error) {
 	if businessID == "" {
-		return nil, errors.New("business_id is required")
+		return nil, apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	sortField := opts.SortBy
+	if sortField == "" {
+		sortField = models.MenuSortCreatedAt
+	}
+	direction := 1
+	if opts.SortDesc {
+		direction = -1
+	}
+
+	filter := bson.M{"business_id": businessID, "deleted_at": nil}
+	if opts.IsActive != nil {
+		filter["is_active"] = *opts.IsActive
+	}
+	if rng := timeRange(opts.CreatedAfter, opts.CreatedBefore); rng != nil {
+		filter["created_at"] = rng
+	}
+	if rng := timeRange(opts.UpdatedAfter, opts.UpdatedBefore); rng != nil {
+		filter["updated_at"] = rng
+	}
+	if opts.After != nil {
+		filter["$or"] = afterCursor(sortField, direction, opts.After)
+	}
+
+	findOpts := options.Find().SetSort(bson.D{{Key: sortField, Value: direction}, {Key: "_id", Value: direction}})
+	if opts.Limit > 0 {
+		findOpts.SetLimit(int64(opts.Limit))
+	}
+
+	coll := r.client.Database(r.dbName).Collection("menus")
+	cursor, err := coll.Find(ctx, filter, findOpts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var menus []models.Menu
+	if err := cursor.All(ctx, &menus); err != nil {
+		return nil, err
+	}
+
+	return menus, nil
+}
+
+// timeRange builds an inclusive-start, exclusive-end range filter.
+func timeRange(after, before *time.Time) bson.M {
+	if after == nil && before == nil {
+		return nil
+	}
+	r := bson.M{}
+	if after != nil {
+		r["$gte"] = *after
+	}
+	if before != nil {
+		r["$lt"] = *before
 	}
+	return r
+}
+
+// afterCursor matches the documents that sort strictly after c:
+// (field > value) OR (field == value AND _id > id), with the comparisons
+// flipped for descending order.
+func afterCursor(field string, direction int, c *models.MenuCursor) bson.A {
+	op := "$gt"
+	if direction < 0 {
+		op = "$lt"
+	}
+	var value interface{} = c.Time
+	if field == models.MenuSortName {
+		value = c.Name
+	}
+	return bson.A{
+		bson.M{field: bson.M{op: value}},
+		bson.M{field: value, "_id": bson.M{op: c.ID}},
+	}
+}
+
+const menuSlugIndex = "slug_unique"
+
+// EnsureMenuIndexes creates the indexes backing menu listings: one compound
+// index per sort key, prefixed by the business and tombstone filters every
+// listing applies. It also creates the unique slug index; slugs stay reserved
+// while a menu is soft-deleted so restoring it cannot collide.
+func (r *MenuRepository) EnsureMenuIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	var indexes []mongo.IndexModel
+	for _, field := range []string{models.MenuSortName, models.MenuSortCreatedAt, models.MenuSortUpdatedAt} {
+		indexes = append(indexes, mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "business_id", Value: 1},
+				{Key: "deleted_at", Value: 1},
+				{Key: field, Value: 1},
+				{Key: "_id", Value: 1},
+			},
+			Options: options.Index().SetName("business_" + field),
+		})
+	}
+	indexes = append(indexes, mongo.IndexModel{
+		Keys: bson.D{{Key: "slug", Value: 1}},
+		Options: options.Index().
+			SetName(menuSlugIndex).
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"slug": bson.M{"$type": "string"}}),
+	})
+
+	coll := r.client.Database(r.dbName).Collection("menus")
+	_, err := coll.Indexes().CreateMany(ctx, indexes)
+	return err
+}
+
+// ListDeletedMenus returns menus of every business that were soft-deleted at
+// or before deletedBefore.
+func (r *MenuRepository) ListDeletedMenus(ctx context.Context, deletedBefore time.Time) ([]models.Menu, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menus")
+	cursor, err := coll.Find(ctx, bson.M{"deleted_at": bson.M{"$ne": nil, "$lte": deletedBefore}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var menus []models.Menu
+	if err := cursor.All(ctx, &menus); err != nil {
+		return nil, err
+	}
+
+	return menus, nil
+}
+
+// BackfillMenuSlugs gives every menu created before slugs existed its ID as
+// slug, which is unique by construction. Owners can replace it with a
+// friendlier one through UpdateMenu. It returns the number of menus updated.
+func (r *MenuRepository) BackfillMenuSlugs(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menus")
+	result, err := coll.UpdateMany(ctx,
+		bson.M{"slug": bson.M{"$exists": false}},
+		mongo.Pipeline{{{Key: "$set", Value: bson.M{"slug": "$_id"}}}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// BackfillMenuRevisions gives menus created before revisions existed
+// revision 1, so conditional writes can match them. It returns the number of
+// menus updated.
+func (r *MenuRepository) BackfillMenuRevisions(ctx context.Context) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menus")
+	result, err := coll.UpdateMany(ctx,
+		bson.M{"revision": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revision": int64(1)}})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
 
-	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+// ListUnversionedMenus returns the menus created before versioning existed,
+// including soft-deleted ones. Menus created since always carry
+// published_version, even while it is 0.
+func (r *MenuRepository) ListUnversionedMenus(ctx context.Context) ([]models.Menu, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	coll := r.client.Database(r.dbName).Collection("menus")
-	cursor, err := coll.Find(ctx, bson.M{"business_id": businessID})
+	cursor, err := coll.Find(ctx, bson.M{"published_version": bson.M{"$exists": false}})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,283 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+)
+
+// MenuItemRepositoryI defines the interface for menu item repository operations.
+// Every lookup is scoped to the parent menu so an item ID alone is never enough
+// to reach an item that belongs to another menu.
+type MenuItemRepositoryI interface {
+	CreateMenuItem(ctx context.Context, item *models.MenuItem) error
+	GetMenuItemByID(ctx context.Context, menuID, itemID string) (*models.MenuItem, error)
+	UpdateMenuItem(ctx context.Context, menuID, itemID string, updates *models.MenuItem) error
+	DeleteMenuItem(ctx context.Context, menuID, itemID string) error
+	ListMenuItemsByMenu(ctx context.Context, menuID string) ([]models.MenuItem, error)
+	ReorderMenuItems(ctx context.Context, menuID string, placements []models.ItemPlacement) error
+	DeleteMenuItemsByMenu(ctx context.Context, menuID string) error
+}
+
+type MenuItemRepository struct {
+	store
+}
+
+func NewMenuItemRepository(client *mongo.Client, dbName string) *MenuItemRepository {
+	return &MenuItemRepository{store: newStore(client, dbName)}
+}
+
+func (r *MenuItemRepository) CreateMenuItem(ctx context.Context, item *models.MenuItem) error {
+	if item == nil {
+		return errors.New("menu item cannot be nil")
+	}
+	if item.ItemID == "" {
+		return apperr.Required("item_id")
+	}
+	if item.MenuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if item.BusinessID == "" {
+		return apperr.Required("business_id")
+	}
+	if item.Title == "" {
+		return apperr.Invalid("title", "menu item title is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_items")
+	_, err := coll.InsertOne(ctx, item)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return apperr.Conflict("menu item with this ID already exists")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *MenuItemRepository) GetMenuItemByID(ctx context.Context, menuID, itemID string) (*models.MenuItem, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+	if itemID == "" {
+		return nil, apperr.Required("item_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_items")
+	var item models.MenuItem
+	err := coll.FindOne(ctx, bson.M{"_id": itemID, "menu_id": menuID}).Decode(&item)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.NotFound("menu item")
+		}
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+func (r *MenuItemRepository) UpdateMenuItem(ctx context.Context, menuID, itemID string, updates *models.MenuItem) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if itemID == "" {
+		return apperr.Required("item_id")
+	}
+	if updates == nil {
+		return errors.New("updates cannot be nil")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	updateFields := bson.M{}
+	unsetFields := bson.M{}
+	if updates.Title != "" {
+		updateFields["title"] = updates.Title
+	}
+	if updates.Description != "" {
+		updateFields["description"] = updates.Description
+	}
+	if updates.ImageURL != "" {
+		updateFields["image_url"] = updates.ImageURL
+	}
+	if updates.Ingredients != nil {
+		updateFields["ingredients"] = updates.Ingredients
+	}
+	if updates.Allergens != nil {
+		updateFields["allergens"] = updates.Allergens
+	}
+	if updates.DietaryTags != nil {
+		updateFields["dietary_tags"] = updates.DietaryTags
+	}
+	if updates.ModifierGroups != nil {
+		updateFields["modifier_groups"] = updates.ModifierGroups
+	}
+	if updates.Image != nil {
+		updateFields["image"] = updates.Image
+	} else {
+		unsetFields["image"] = ""
+	}
+	updateFields["price"] = updates.Price
+	updateFields["is_active"] = updates.IsActive
+	updateFields["updated_at"] = time.Now()
+
+	coll := r.client.Database(r.dbName).Collection("menu_items")
+	update := bson.M{"$set": updateFields}
+	if len(unsetFields) > 0 {
+		update["$unset"] = unsetFields
+	}
+	result := coll.FindOneAndUpdate(ctx, bson.M{"_id": itemID, "menu_id": menuID}, update)
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return apperr.NotFound("menu item")
+		}
+		return result.Err()
+	}
+
+	return nil
+}
+
+func (r *MenuItemRepository) DeleteMenuItem(ctx context.Context, menuID, itemID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if itemID == "" {
+		return apperr.Required("item_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_items")
+	result, err := coll.DeleteOne(ctx, bson.M{"_id": itemID, "menu_id": menuID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return apperr.NotFound("menu item")
+	}
+
+	return nil
+}
+
+// DeleteMenuItemsByMenu removes every item of a menu. It is used when a menu
+// is purged.
+func (r *MenuItemRepository) DeleteMenuItemsByMenu(ctx context.Context, menuID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_items")
+	_, err := coll.DeleteMany(ctx, bson.M{"menu_id": menuID})
+	return err
+}
+
+// ListMenuItemsByMenu returns the items of a menu sorted by section and position.
+func (r *MenuItemRepository) ListMenuItemsByMenu(ctx context.Context, menuID string) ([]models.MenuItem, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_items")
+	opts := options.Find().SetSort(bson.D{{Key: "section_id", Value: 1}, {Key: "position", Value: 1}})
+	cursor, err := coll.Find(ctx, bson.M{"menu_id": menuID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var items []models.MenuItem
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// ReorderMenuItems moves items into their placed section and position using a
+// single bulk write.
+func (r *MenuItemRepository) ReorderMenuItems(ctx context.Context, menuID string, placements []models.ItemPlacement) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if len(placements) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(placements))
+	for _, p := range placements {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": p.ItemID, "menu_id": menuID}).
+			SetUpdate(bson.M{"$set": bson.M{"section_id": p.SectionID, "position": p.Position, "updated_at": now}}))
+	}
+
+	coll := r.client.Database(r.dbName).Collection("menu_items")
+	result, err := coll.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount != int64(len(placements)) {
+		return apperr.NotFound("menu item")
+	}
+
+	return nil
+}
+
+// MigrateLegacyPrices rewrites menu items whose price is still a bare number
+// (major units, from before prices carried a currency) into the
+// {amount, currency} shape, assuming the given currency. It is idempotent and
+// returns the number of documents rewritten.
+func (r *MenuItemRepository) MigrateLegacyPrices(ctx context.Context, currency string) (int64, error) {
+	if !models.IsValidCurrency(currency) {
+		return 0, apperr.Invalid("currency", "currency must be an ISO 4217 code")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	scale := math.Pow10(models.MinorUnitExponent(currency))
+	filter := bson.M{"price": bson.M{"$type": bson.A{"double", "int", "long", "decimal"}}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"price": bson.M{
+				"amount":   bson.M{"$toLong": bson.M{"$round": bson.A{bson.M{"$multiply": bson.A{"$price", scale}}, 0}}},
+				"currency": currency,
+			},
+		}}},
+	}
+
+	coll := r.client.Database(r.dbName).Collection("menu_items")
+	result, err := coll.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
@@ -0,0 +1,215 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+)
+
+// MenuSectionRepositoryI defines the interface for menu section repository operations.
+type MenuSectionRepositoryI interface {
+	CreateMenuSection(ctx context.Context, section *models.MenuSection) error
+	GetMenuSectionByID(ctx context.Context, menuID, sectionID string) (*models.MenuSection, error)
+	UpdateMenuSection(ctx context.Context, menuID, sectionID string, updates *models.MenuSection) error
+	DeleteMenuSection(ctx context.Context, menuID, sectionID string) error
+	ListMenuSectionsByMenu(ctx context.Context, menuID string) ([]models.MenuSection, error)
+	ReorderMenuSections(ctx context.Context, menuID string, sectionIDs []string) error
+	DeleteMenuSectionsByMenu(ctx context.Context, menuID string) error
+}
+
+type MenuSectionRepository struct {
+	store
+}
+
+func NewMenuSectionRepository(client *mongo.Client, dbName string) *MenuSectionRepository {
+	return &MenuSectionRepository{store: newStore(client, dbName)}
+}
+
+func (r *MenuSectionRepository) CreateMenuSection(ctx context.Context, section *models.MenuSection) error {
+	if section == nil {
+		return errors.New("menu section cannot be nil")
+	}
+	if section.SectionID == "" {
+		return apperr.Required("section_id")
+	}
+	if section.MenuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if section.Name == "" {
+		return apperr.Invalid("name", "menu section name is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_sections")
+	_, err := coll.InsertOne(ctx, section)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return apperr.Conflict("menu section with this ID already exists")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *MenuSectionRepository) GetMenuSectionByID(ctx context.Context, menuID, sectionID string) (*models.MenuSection, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+	if sectionID == "" {
+		return nil, apperr.Required("section_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_sections")
+	var section models.MenuSection
+	err := coll.FindOne(ctx, bson.M{"_id": sectionID, "menu_id": menuID}).Decode(&section)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.NotFound("menu section")
+		}
+		return nil, err
+	}
+
+	return &section, nil
+}
+
+func (r *MenuSectionRepository) UpdateMenuSection(ctx context.Context, menuID, sectionID string, updates *models.MenuSection) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if sectionID == "" {
+		return apperr.Required("section_id")
+	}
+	if updates == nil {
+		return errors.New("updates cannot be nil")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	updateFields := bson.M{}
+	if updates.Name != "" {
+		updateFields["name"] = updates.Name
+	}
+	updateFields["updated_at"] = time.Now()
+
+	coll := r.client.Database(r.dbName).Collection("menu_sections")
+	result := coll.FindOneAndUpdate(ctx, bson.M{"_id": sectionID, "menu_id": menuID}, bson.M{"$set": updateFields})
+	if result.Err() != nil {
+		if result.Err() == mongo.ErrNoDocuments {
+			return apperr.NotFound("menu section")
+		}
+		return result.Err()
+	}
+
+	return nil
+}
+
+func (r *MenuSectionRepository) DeleteMenuSection(ctx context.Context, menuID, sectionID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if sectionID == "" {
+		return apperr.Required("section_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_sections")
+	result, err := coll.DeleteOne(ctx, bson.M{"_id": sectionID, "menu_id": menuID})
+	if err != nil {
+		return err
+	}
+
+	if result.DeletedCount == 0 {
+		return apperr.NotFound("menu section")
+	}
+
+	return nil
+}
+
+// DeleteMenuSectionsByMenu removes every section of a menu. It is used when a
+// menu is purged.
+func (r *MenuSectionRepository) DeleteMenuSectionsByMenu(ctx context.Context, menuID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_sections")
+	_, err := coll.DeleteMany(ctx, bson.M{"menu_id": menuID})
+	return err
+}
+
+// ListMenuSectionsByMenu returns the sections of a menu sorted by position.
+func (r *MenuSectionRepository) ListMenuSectionsByMenu(ctx context.Context, menuID string) ([]models.MenuSection, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_sections")
+	opts := options.Find().SetSort(bson.D{{Key: "position", Value: 1}})
+	cursor, err := coll.Find(ctx, bson.M{"menu_id": menuID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sections []models.MenuSection
+	if err := cursor.All(ctx, &sections); err != nil {
+		return nil, err
+	}
+
+	return sections, nil
+}
+
+// ReorderMenuSections assigns each section its index in sectionIDs as position
+// using a single bulk write.
+func (r *MenuSectionRepository) ReorderMenuSections(ctx context.Context, menuID string, sectionIDs []string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if len(sectionIDs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	now := time.Now()
+	writes := make([]mongo.WriteModel, 0, len(sectionIDs))
+	for i, sectionID := range sectionIDs {
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": sectionID, "menu_id": menuID}).
+			SetUpdate(bson.M{"$set": bson.M{"position": i, "updated_at": now}}))
+	}
+
+	coll := r.client.Database(r.dbName).Collection("menu_sections")
+	result, err := coll.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount != int64(len(sectionIDs)) {
+		return apperr.NotFound("menu section")
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package mongo
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+)
+
+func TestMenuUpdateUnsetsClearedFields(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	schedule := &models.MenuSchedule{Windows: []models.WeeklyWindow{{Days: []string{"sat"}, Start: "09:00", End: "14:00"}}}
+
+	got := menuUpdate(&models.Menu{Name: "Brunch", Slug: "brunch", Description: "Weekends only", IsActive: true, Schedule: schedule, Revision: 4}, now)
+	want := bson.M{"$set": bson.M{
+		"name":        "Brunch",
+		"slug":        "brunch",
+		"description": "Weekends only",
+		"is_active":   true,
+		"schedule":    schedule,
+		"updated_at":  now,
+		"revision":    int64(5),
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected update of a full menu:\n got %v\nwant %v", got, want)
+	}
+
+	got = menuUpdate(&models.Menu{Name: "Brunch", Revision: 4}, now)
+	want = bson.M{
+		"$set":   bson.M{"name": "Brunch", "is_active": false, "updated_at": now, "revision": int64(5)},
+		"$unset": bson.M{"slug": "", "description": "", "schedule": ""},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("unexpected update of a cleared menu:\n got %v\nwant %v", got, want)
+	}
+}
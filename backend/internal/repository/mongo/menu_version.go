@@ -0,0 +1,143 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+)
+
+// MenuVersionRepositoryI defines the interface for menu version repository
+// operations. Versions are immutable, so there is no update; they are only
+// deleted together with their menu when it is purged.
+type MenuVersionRepositoryI interface {
+	CreateMenuVersion(ctx context.Context, version *models.MenuVersion) error
+	GetMenuVersion(ctx context.Context, menuID string, number int) (*models.MenuVersion, error)
+	ListMenuVersions(ctx context.Context, menuID string) ([]models.MenuVersion, error)
+	DeleteMenuVersionsByMenu(ctx context.Context, menuID string) error
+}
+
+// ErrMenuVersionExists is returned by CreateMenuVersion when the menu already
+// has a version with the same number, typically because two publishes raced.
+var ErrMenuVersionExists = apperr.Conflict("menu version already exists")
+
+type MenuVersionRepository struct {
+	store
+}
+
+func NewMenuVersionRepository(client *mongo.Client, dbName string) *MenuVersionRepository {
+	return &MenuVersionRepository{store: newStore(client, dbName)}
+}
+
+func (r *MenuVersionRepository) CreateMenuVersion(ctx context.Context, version *models.MenuVersion) error {
+	if version == nil {
+		return errors.New("menu version cannot be nil")
+	}
+	if version.VersionID == "" {
+		return apperr.Required("version_id")
+	}
+	if version.MenuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if version.Number < 1 {
+		return apperr.Invalid("number", "menu version number must be positive")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_versions")
+	_, err := coll.InsertOne(ctx, version)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return ErrMenuVersionExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *MenuVersionRepository) GetMenuVersion(ctx context.Context, menuID string, number int) (*models.MenuVersion, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_versions")
+	var version models.MenuVersion
+	err := coll.FindOne(ctx, bson.M{"menu_id": menuID, "number": number}).Decode(&version)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.NotFound("menu version")
+		}
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// ListMenuVersions returns the versions of a menu, newest first, without
+// their sections and items.
+func (r *MenuVersionRepository) ListMenuVersions(ctx context.Context, menuID string) ([]models.MenuVersion, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_versions")
+	opts := options.Find().
+		SetSort(bson.D{{Key: "number", Value: -1}}).
+		SetProjection(bson.M{"sections": 0, "items": 0})
+	cursor, err := coll.Find(ctx, bson.M{"menu_id": menuID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var versions []models.MenuVersion
+	if err := cursor.All(ctx, &versions); err != nil {
+		return nil, err
+	}
+
+	return versions, nil
+}
+
+// DeleteMenuVersionsByMenu removes every version of a menu. It is used when a
+// menu is purged.
+func (r *MenuVersionRepository) DeleteMenuVersionsByMenu(ctx context.Context, menuID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_versions")
+	_, err := coll.DeleteMany(ctx, bson.M{"menu_id": menuID})
+	return err
+}
+
+// EnsureMenuVersionIndexes creates the unique index on (menu_id, number) that
+// both serves lookups and rejects a second version with the same number.
+func (r *MenuVersionRepository) EnsureMenuVersionIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("menu_versions")
+	_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "menu_id", Value: 1}, {Key: "number", Value: 1}},
+		Options: options.Index().SetName("menu_number_unique").SetUnique(true),
+	})
+	return err
+}
@@ -0,0 +1,238 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Migration is one step of the database's schema history, such as creating
+// indexes or backfilling a field. Up must be idempotent: a runner that stops
+// between running a step and recording it runs the step again.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+}
+
+// MigrationStatus reports whether a migration has been applied. AppliedAt is
+// nil for pending migrations.
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// ErrMigrationLocked is returned by Migrator.Up when another runner held the
+// migration lock for longer than the runner was willing to wait.
+var ErrMigrationLocked = errors.New("schema migrations are locked by another runner")
+
+const (
+	migrationsCollection = "schema_migrations"
+	migrationLockID      = "lock"
+)
+
+// Migrator applies migrations in version order and records each applied one
+// in the schema_migrations collection, keyed by version.
+//
+// A lock document in the same collection keeps two runners, such as servers
+// starting together, from applying migrations at once; the second waits for
+// the first and then finds nothing left to do. The lock expires so that a
+// runner that dies holding it does not block the others forever, and it is
+// renewed before every step.
+type Migrator struct {
+	db         *mongo.Database
+	migrations []Migration
+	owner      string
+
+	// lockTTL is how long the lock lasts without being renewed, and
+	// lockWait how long Up waits for a lock held by another runner
+	lockTTL  time.Duration
+	lockWait time.Duration
+}
+
+// NewMigrator returns a Migrator for the given migrations, which must have
+// positive, strictly increasing versions and names.
+func NewMigrator(client *mongo.Client, dbName string, migrations []Migration) (*Migrator, error) {
+	if err := validateMigrations(migrations); err != nil {
+		return nil, err
+	}
+	return &Migrator{
+		db:         client.Database(dbName),
+		migrations: migrations,
+		owner:      uuid.NewString(),
+		lockTTL:    10 * time.Minute,
+		lockWait:   2 * time.Minute,
+	}, nil
+}
+
+func validateMigrations(migrations []Migration) error {
+	last := 0
+	for _, m := range migrations {
+		if m.Version <= last {
+			return fmt.Errorf("mongo: migration %d_%s is out of order", m.Version, m.Name)
+		}
+		if m.Name == "" || m.Up == nil {
+			return fmt.Errorf("mongo: migration %d needs a name and a step", m.Version)
+		}
+		last = m.Version
+	}
+	return nil
+}
+
+// Status returns every known migration in version order, followed by any
+// recorded migration this binary does not know, which a newer release
+// applied.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			status.AppliedAt = &record.AppliedAt
+			delete(applied, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range applied {
+		statuses = append(statuses, MigrationStatus{Version: record.Version, Name: record.Name, AppliedAt: &record.AppliedAt})
+	}
+	unknown := statuses[len(m.migrations):]
+	sort.Slice(unknown, func(i, j int) bool {
+		return unknown[i].Version < unknown[j].Version
+	})
+
+	return statuses, nil
+}
+
+// Up applies the pending migrations in version order and returns the ones it
+// applied. It stops at the first failure; the failed migration stays pending.
+// A failure to release the lock is returned too, since the lock then blocks
+// other runners until it expires.
+func (m *Migrator) Up(ctx context.Context) (done []MigrationStatus, err error) {
+	if err := m.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if unlockErr := m.unlock(); unlockErr != nil {
+			err = errors.Join(err, fmt.Errorf("releasing migration lock: %w", unlockErr))
+		}
+	}()
+
+	// read under the lock so that migrations applied by the runner we
+	// waited for are seen
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	coll := m.db.Collection(migrationsCollection)
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		if err := m.tryLock(ctx); err != nil {
+			return done, fmt.Errorf("renewing migration lock: %w", err)
+		}
+		if err := migration.Up(ctx, m.db); err != nil {
+			return done, fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+
+		record := migrationRecord{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now().UTC().Truncate(time.Millisecond)}
+		if _, err := coll.InsertOne(ctx, record); err != nil {
+			return done, fmt.Errorf("recording migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, MigrationStatus{Version: record.Version, Name: record.Name, AppliedAt: &record.AppliedAt})
+	}
+
+	return done, nil
+}
+
+type migrationRecord struct {
+	Version   int       `bson:"_id"`
+	Name      string    `bson:"name"`
+	AppliedAt time.Time `bson:"applied_at"`
+}
+
+// applied returns the recorded migrations by version.
+func (m *Migrator) applied(ctx context.Context) (map[int]migrationRecord, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	coll := m.db.Collection(migrationsCollection)
+	cursor, err := coll.Find(ctx, bson.M{"_id": bson.M{"$type": "number"}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []migrationRecord
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	applied := make(map[int]migrationRecord, len(records))
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// lock takes the migration lock, waiting up to lockWait for another runner
+// to release it.
+func (m *Migrator) lock(ctx context.Context) error {
+	deadline := time.Now().Add(m.lockWait)
+	for {
+		err := m.tryLock(ctx)
+		if !errors.Is(err, ErrMigrationLocked) || time.Now().After(deadline) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// tryLock takes or renews the migration lock. The upsert matches the lock
+// document only if it has expired or is ours; when another runner holds it
+// the upsert collides with the existing _id instead.
+func (m *Migrator) tryLock(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	now := time.Now()
+	coll := m.db.Collection(migrationsCollection)
+	_, err := coll.UpdateOne(ctx,
+		bson.M{"_id": migrationLockID, "$or": bson.A{
+			bson.M{"owner": m.owner},
+			bson.M{"expires_at": bson.M{"$lt": now}},
+		}},
+		bson.M{"$set": bson.M{"owner": m.owner, "locked_at": now, "expires_at": now.Add(m.lockTTL)}},
+		options.Update().SetUpsert(true))
+	if mongo.IsDuplicateKeyError(err) {
+		return ErrMigrationLocked
+	}
+	return err
+}
+
+// unlock releases the migration lock if it is still ours.
+func (m *Migrator) unlock() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	coll := m.db.Collection(migrationsCollection)
+	_, err := coll.DeleteOne(ctx, bson.M{"_id": migrationLockID, "owner": m.owner})
+	return err
+}
@@ -0,0 +1,235 @@
+package mongo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	mongopkg "github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+func TestMigrationsAreOrdered(t *testing.T) {
+	names := map[string]bool{}
+	last := 0
+	for _, m := range mongopkg.Migrations(models.Business{DefaultCurrency: "EUR"}, nil) {
+		if m.Version != last+1 {
+			t.Errorf("expected version %d after %d, got %d", last+1, last, m.Version)
+		}
+		if m.Name == "" || names[m.Name] || m.Up == nil {
+			t.Errorf("migration %d needs a unique name and a step", m.Version)
+		}
+		names[m.Name] = true
+		last = m.Version
+	}
+}
+
+func TestNewMigratorRejectsInvalidHistory(t *testing.T) {
+	step := func(ctx context.Context, db *mongo.Database) error { return nil }
+	for name, migrations := range map[string][]mongopkg.Migration{
+		"out of order": {{Version: 2, Name: "b", Up: step}, {Version: 1, Name: "a", Up: step}},
+		"duplicate":    {{Version: 1, Name: "a", Up: step}, {Version: 1, Name: "b", Up: step}},
+		"zero version": {{Version: 0, Name: "a", Up: step}},
+		"no name":      {{Version: 1, Up: step}},
+		"no step":      {{Version: 1, Name: "a"}},
+	} {
+		if _, err := mongopkg.NewMigrator(nil, "test", migrations); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestMigratorAppliesEachMigrationOnce(t *testing.T) {
+	client := testClient(t)
+	dbName := testDatabase(t, client)
+	ctx := context.Background()
+
+	var mu sync.Mutex
+	runs := map[int]int{}
+	step := func(version int) func(context.Context, *mongo.Database) error {
+		return func(ctx context.Context, db *mongo.Database) error {
+			mu.Lock()
+			runs[version]++
+			mu.Unlock()
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		}
+	}
+	migrations := []mongopkg.Migration{
+		{Version: 1, Name: "first", Up: step(1)},
+		{Version: 2, Name: "second", Up: step(2)},
+	}
+
+	// runners started together take turns; the later ones find nothing to do
+	var wg sync.WaitGroup
+	applied := make([]int, 4)
+	for i := range applied {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			migrator, err := mongopkg.NewMigrator(client, dbName, migrations)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			done, err := migrator.Up(ctx)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			applied[i] = len(done)
+		}(i)
+	}
+	wg.Wait()
+
+	if fmt.Sprint(runs) != "map[1:1 2:1]" {
+		t.Errorf("expected each migration to run once, got %v", runs)
+	}
+	total := 0
+	for _, n := range applied {
+		total += n
+	}
+	if total != 2 {
+		t.Errorf("expected 2 migrations applied in total, got %v", applied)
+	}
+
+	// a newer release adds a migration
+	migrations = append(migrations, mongopkg.Migration{Version: 3, Name: "third", Up: step(3)})
+	migrator, err := mongopkg.NewMigrator(client, dbName, migrations)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(statuses) != 3 || statuses[0].AppliedAt == nil || statuses[1].AppliedAt == nil || statuses[2].AppliedAt != nil {
+		t.Errorf("expected 1 and 2 applied and 3 pending, got %+v", statuses)
+	}
+	done, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(done) != 1 || done[0].Version != 3 {
+		t.Errorf("expected only migration 3 to be applied, got %+v", done)
+	}
+
+	// the lock is released once Up returns
+	n, err := client.Database(dbName).Collection("schema_migrations").CountDocuments(ctx, bson.M{"_id": "lock"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 0 {
+		t.Error("expected the migration lock to be released")
+	}
+}
+
+func TestMigratorStopsAtFailure(t *testing.T) {
+	client := testClient(t)
+	dbName := testDatabase(t, client)
+	ctx := context.Background()
+
+	failure := errors.New("boom")
+	ran := false
+	migrator, err := mongopkg.NewMigrator(client, dbName, []mongopkg.Migration{
+		{Version: 1, Name: "fails", Up: func(ctx context.Context, db *mongo.Database) error { return failure }},
+		{Version: 2, Name: "after", Up: func(ctx context.Context, db *mongo.Database) error { ran = true; return nil }},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := migrator.Up(ctx); !errors.Is(err, failure) {
+		t.Errorf("expected the step's error, got %v", err)
+	}
+	if ran {
+		t.Error("expected later migrations not to run after a failure")
+	}
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statuses[0].AppliedAt != nil {
+		t.Error("expected the failed migration to stay pending")
+	}
+}
+
+func TestRenameField(t *testing.T) {
+	client := testClient(t)
+	dbName := testDatabase(t, client)
+	ctx := context.Background()
+	db := client.Database(dbName)
+
+	coll := db.Collection("things")
+	if _, err := coll.InsertMany(ctx, []any{
+		bson.M{"_id": "old", "colour": "red"},
+		bson.M{"_id": "new", "color": "blue"},
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rename := mongopkg.RenameField("things", "colour", "color")
+	for i := 0; i < 2; i++ {
+		if err := rename(ctx, db); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	n, err := coll.CountDocuments(ctx, bson.M{"color": bson.M{"$exists": true}, "colour": bson.M{"$exists": false}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected both documents to use the new name, got %d", n)
+	}
+}
+
+func TestMigrationsPublishUnversionedMenusOnce(t *testing.T) {
+	client := testClient(t)
+	dbName := testDatabase(t, client)
+	ctx := context.Background()
+
+	// a menu written before versioning has no published_version
+	if _, err := client.Database(dbName).Collection("menus").InsertOne(ctx, bson.M{
+		"_id": "m1", "business_id": "b1", "name": "Lunch",
+	}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var published []string
+	publish := func(ctx context.Context, db *mongo.Database, menus []models.Menu) error {
+		repo := mongopkg.NewMenuRepository(db.Client(), db.Name())
+		for _, menu := range menus {
+			published = append(published, menu.MenuID)
+			if err := repo.SetMenuPublishedVersion(ctx, menu.MenuID, menu.BusinessID, 1, time.Now()); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	migrations := mongopkg.Migrations(models.Business{DefaultCurrency: "EUR"}, publish)
+
+	migrator, err := mongopkg.NewMigrator(client, dbName, migrations)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(published) != "[m1]" {
+		t.Errorf("expected m1 to be published, got %v", published)
+	}
+
+	// rerunning the step finds nothing left to publish
+	if err := migrations[len(migrations)-1].Up(ctx, client.Database(dbName)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(published) != 1 {
+		t.Errorf("expected no second publish, got %v", published)
+	}
+}
@@ -0,0 +1,110 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+)
+
+// PublishFunc publishes the current content of menus created before
+// versioning existed as their version 1. Publishing belongs to the service
+// layer, which this package cannot import, so the caller supplies it.
+type PublishFunc func(ctx context.Context, db *mongo.Database, menus []models.Menu) error
+
+// Migrations returns the schema history of the database, oldest first.
+// defaults fill in the businesses created for data that predates them, and
+// defaults.DefaultCurrency is assumed for prices stored as bare numbers.
+// publish is run on the menus that have never been versioned.
+//
+// Steps 1 to 7 and 11 used to run on every startup, so databases written by earlier
+// releases already have their effects; being idempotent, they are recorded
+// without changing anything the first time they run there.
+//
+// Append new steps with the next version. Never edit or reorder steps that
+// have shipped: the version is all that records them.
+func Migrations(defaults models.Business, publish PublishFunc) []Migration {
+	return []Migration{
+		{Version: 1, Name: "backfill_businesses", Up: func(ctx context.Context, db *mongo.Database) error {
+			// menus and users used to carry a bare business_id; give each
+			// one a business document so the existence check on menu
+			// creation passes
+			_, err := NewBusinessRepository(db.Client(), db.Name()).BackfillBusinesses(ctx, defaults)
+			return err
+		}},
+		{Version: 2, Name: "create_audit_indexes", Up: func(ctx context.Context, db *mongo.Database) error {
+			return NewAuditRepository(db.Client(), db.Name()).EnsureAuditIndexes(ctx)
+		}},
+		{Version: 3, Name: "backfill_menu_slugs", Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := NewMenuRepository(db.Client(), db.Name()).BackfillMenuSlugs(ctx)
+			return err
+		}},
+		{Version: 4, Name: "backfill_menu_revisions", Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := NewMenuRepository(db.Client(), db.Name()).BackfillMenuRevisions(ctx)
+			return err
+		}},
+		{Version: 5, Name: "create_menu_indexes", Up: func(ctx context.Context, db *mongo.Database) error {
+			return NewMenuRepository(db.Client(), db.Name()).EnsureMenuIndexes(ctx)
+		}},
+		{Version: 6, Name: "migrate_legacy_prices", Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := NewMenuItemRepository(db.Client(), db.Name()).MigrateLegacyPrices(ctx, defaults.DefaultCurrency)
+			return err
+		}},
+		{Version: 7, Name: "create_menu_version_indexes", Up: func(ctx context.Context, db *mongo.Database) error {
+			return NewMenuVersionRepository(db.Client(), db.Name()).EnsureMenuVersionIndexes(ctx)
+		}},
+		{Version: 8, Name: "create_user_email_index", Up: func(ctx context.Context, db *mongo.Database) error {
+			return NewUserRepository(db.Client(), db.Name()).EnsureUserIndexes(ctx)
+		}},
+		{Version: 9, Name: "create_menu_section_indexes", Up: CreateIndexes("menu_sections", mongo.IndexModel{
+			Keys:    bson.D{{Key: "menu_id", Value: 1}, {Key: "position", Value: 1}},
+			Options: options.Index().SetName("menu_position"),
+		})},
+		{Version: 10, Name: "create_menu_item_indexes", Up: CreateIndexes("menu_items", mongo.IndexModel{
+			Keys:    bson.D{{Key: "menu_id", Value: 1}, {Key: "section_id", Value: 1}, {Key: "position", Value: 1}},
+			Options: options.Index().SetName("menu_section_position"),
+		})},
+		{Version: 11, Name: "publish_unversioned_menus", Up: func(ctx context.Context, db *mongo.Database) error {
+			// menus used to be live as edited; publish what diners saw as
+			// version 1 so they keep seeing it. A published menu carries
+			// published_version, so a rerun skips it.
+			menus, err := NewMenuRepository(db.Client(), db.Name()).ListUnversionedMenus(ctx)
+			if err != nil || len(menus) == 0 {
+				return err
+			}
+			return publish(ctx, db, menus)
+		}},
+	}
+}
+
+// CreateIndexes returns a migration step that creates indexes on a
+// collection. Creating an index that already exists with the same options
+// does nothing.
+func CreateIndexes(collection string, indexes ...mongo.IndexModel) func(ctx context.Context, db *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		defer cancel()
+
+		_, err := db.Collection(collection).Indexes().CreateMany(ctx, indexes)
+		return err
+	}
+}
+
+// RenameField returns a migration step that renames a field in every
+// document of a collection that has it. Documents already renamed are left
+// alone, so the step can run again.
+func RenameField(collection, from, to string) func(ctx context.Context, db *mongo.Database) error {
+	return func(ctx context.Context, db *mongo.Database) error {
+		ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+		defer cancel()
+
+		_, err := db.Collection(collection).UpdateMany(ctx,
+			bson.M{from: bson.M{"$exists": true}},
+			bson.M{"$rename": bson.M{from: to}})
+		return err
+	}
+}
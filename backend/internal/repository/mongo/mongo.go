@@ -2,18 +2,25 @@ package mongo
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
+	"fmt"
+	"os"
+	"strconv"
 	"time"
 
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
+	"go.mongodb.org/mongo-driver/mongo/writeconcern"
 
 	"github.com/custard-technology/abakcus/backend/internal/config"
 )
 
 // NewClient creates a MongoDB client using the supplied configuration.
 // It attempts to connect and then pings the server to verify the connection.
+// Zero timeouts fall back to config.DefaultMongoConnectTimeout.
 //
 // The caller is responsible for calling Disconnect when the client is no longer
 // needed (usually via defer in main).
@@ -26,11 +33,15 @@ func NewClient(ctx context.Context, cfg config.MongoConfig) (*mongo.Client, erro
 		return nil, errors.New("mongo: empty URI")
 	}
 
+	clientOpts, err := clientOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	// create a context with timeout to avoid hanging indefinitely
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, *clientOpts.ConnectTimeout)
 	defer cancel()
 
-	clientOpts := options.Client().ApplyURI(cfg.URI)
 	client, err := mongo.Connect(ctx, clientOpts)
 	if err != nil {
 		return nil, err
@@ -45,3 +56,120 @@ func NewClient(ctx context.Context, cfg config.MongoConfig) (*mongo.Client, erro
 
 	return client, nil
 }
+
+// clientOptions turns cfg into driver options. Options set in cfg override
+// the same options in the URI.
+func clientOptions(cfg config.MongoConfig) (*options.ClientOptions, error) {
+	opts := options.Client().ApplyURI(cfg.URI)
+
+	connectTimeout := cfg.ConnectTimeout
+	if connectTimeout <= 0 {
+		connectTimeout = config.DefaultMongoConnectTimeout
+	}
+	opts.SetConnectTimeout(connectTimeout)
+
+	if cfg.AppName != "" {
+		opts.SetAppName(cfg.AppName)
+	}
+	if cfg.MaxPoolSize > 0 {
+		opts.SetMaxPoolSize(cfg.MaxPoolSize)
+	}
+	if cfg.MinPoolSize > 0 {
+		opts.SetMinPoolSize(cfg.MinPoolSize)
+	}
+
+	if cfg.TLSCAFile != "" || cfg.TLSCertFile != "" {
+		tlsConfig, err := loadTLSConfig(cfg.TLSCAFile, cfg.TLSCertFile)
+		if err != nil {
+			return nil, err
+		}
+		opts.SetTLSConfig(tlsConfig)
+	}
+
+	if cfg.ReadPreference != "" {
+		mode, err := readpref.ModeFromString(cfg.ReadPreference)
+		if err != nil {
+			return nil, fmt.Errorf("mongo: %w", err)
+		}
+		rp, err := readpref.New(mode)
+		if err != nil {
+			return nil, fmt.Errorf("mongo: %w", err)
+		}
+		opts.SetReadPreference(rp)
+	}
+
+	switch cfg.WriteConcern {
+	case "":
+	case "majority":
+		opts.SetWriteConcern(writeconcern.Majority())
+	default:
+		w, err := strconv.Atoi(cfg.WriteConcern)
+		if err != nil || w < 1 {
+			return nil, fmt.Errorf("mongo: invalid write concern %q", cfg.WriteConcern)
+		}
+		opts.SetWriteConcern(&writeconcern.WriteConcern{W: w})
+	}
+
+	// the repositories rely on acknowledged writes to see conflicts, so w=0
+	// is refused here too, whether it came from cfg or the URI
+	if opts.WriteConcern != nil && !opts.WriteConcern.Acknowledged() {
+		return nil, errors.New("mongo: unacknowledged write concern (w=0) is not supported")
+	}
+
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+// loadTLSConfig builds the TLS settings from a PEM file of trusted CAs and a
+// PEM file holding the client certificate and its key. Either may be empty:
+// without CAs the system pool is trusted, and without a certificate the
+// client does not authenticate itself.
+func loadTLSConfig(caFile, certFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("mongo: reading TLS CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("mongo: no certificates found in TLS CA file %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, certFile)
+		if err != nil {
+			return nil, fmt.Errorf("mongo: loading TLS certificate file: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// store is what every repository needs: the database it works on and how
+// long each call may take.
+type store struct {
+	client  *mongo.Client
+	dbName  string
+	timeout time.Duration
+}
+
+func newStore(client *mongo.Client, dbName string) store {
+	return store{client: client, dbName: dbName, timeout: config.DefaultMongoOperationTimeout}
+}
+
+// SetOperationTimeout sets how long each call of the repository may take;
+// the default is config.DefaultMongoOperationTimeout. Index builds and
+// backfills keep their own, longer limits. Non-positive values are ignored.
+// Call it before the repository is shared.
+func (s *store) SetOperationTimeout(timeout time.Duration) {
+	if timeout > 0 {
+		s.timeout = timeout
+	}
+}
@@ -2,9 +2,20 @@ package mongo
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+
 	"github.com/custard-technology/abakcus/backend/internal/config"
 )
 
@@ -18,3 +29,126 @@ func TestNewClient_InvalidURI(t *testing.T) {
 		t.Fatal("expected error for invalid URI")
 	}
 }
+
+func TestClientOptions(t *testing.T) {
+	opts, err := clientOptions(config.MongoConfig{
+		URI:            "mongodb://localhost:27017/?appName=from-uri&maxPoolSize=10&w=1",
+		AppName:        "abakcus-api",
+		MaxPoolSize:    50,
+		MinPoolSize:    5,
+		ConnectTimeout: 3 * time.Second,
+		ReadPreference: "secondaryPreferred",
+		WriteConcern:   "majority",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *opts.AppName != "abakcus-api" || *opts.MaxPoolSize != 50 || *opts.MinPoolSize != 5 {
+		t.Errorf("expected the configured app name and pool sizes to win over the URI")
+	}
+	if *opts.ConnectTimeout != 3*time.Second {
+		t.Errorf("expected a 3s connect timeout, got %v", *opts.ConnectTimeout)
+	}
+	if opts.ReadPreference.Mode() != readpref.SecondaryPreferredMode {
+		t.Errorf("expected secondaryPreferred, got %v", opts.ReadPreference.Mode())
+	}
+	if opts.WriteConcern.GetW() != "majority" {
+		t.Errorf("expected majority write concern, got %v", opts.WriteConcern.GetW())
+	}
+	if opts.TLSConfig != nil {
+		t.Error("expected TLS to stay off without TLS files")
+	}
+
+	// unset options keep the URI's and the default connect timeout
+	opts, err = clientOptions(config.MongoConfig{URI: "mongodb://localhost:27017/?appName=from-uri&w=2"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *opts.AppName != "from-uri" || opts.WriteConcern.GetW() != 2 {
+		t.Errorf("expected the URI options to be kept")
+	}
+	if *opts.ConnectTimeout != config.DefaultMongoConnectTimeout {
+		t.Errorf("expected the default connect timeout, got %v", *opts.ConnectTimeout)
+	}
+
+	for _, cfg := range []config.MongoConfig{
+		{URI: "mongodb://localhost:27017", WriteConcern: "0"},
+		{URI: "mongodb://localhost:27017/?w=0"},
+	} {
+		if _, err := clientOptions(cfg); err == nil {
+			t.Errorf("expected unacknowledged writes to be rejected for %+v", cfg)
+		}
+	}
+}
+
+func TestClientOptionsTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.pem")
+	writeTestCertificate(t, certFile)
+
+	// the combined certificate and key file doubles as a CA file here
+	opts, err := clientOptions(config.MongoConfig{URI: "mongodb://localhost:27017", TLSCAFile: certFile, TLSCertFile: certFile})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.TLSConfig == nil || opts.TLSConfig.RootCAs == nil || len(opts.TLSConfig.Certificates) != 1 {
+		t.Errorf("expected CAs and a client certificate, got %+v", opts.TLSConfig)
+	}
+
+	empty := filepath.Join(dir, "empty.pem")
+	if err := os.WriteFile(empty, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	for name, cfg := range map[string]config.MongoConfig{
+		"missing CA file":              {URI: "mongodb://localhost:27017", TLSCAFile: filepath.Join(dir, "missing.pem")},
+		"CA file without certificates": {URI: "mongodb://localhost:27017", TLSCAFile: empty},
+		"certificate without key":      {URI: "mongodb://localhost:27017", TLSCertFile: empty},
+	} {
+		if _, err := clientOptions(cfg); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate and its key to one
+// PEM file.
+func writeTestCertificate(t *testing.T, path string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "abakcus-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})...)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetOperationTimeout(t *testing.T) {
+	repo := NewMenuRepository(nil, "test")
+	if repo.timeout != config.DefaultMongoOperationTimeout {
+		t.Errorf("expected the default timeout, got %v", repo.timeout)
+	}
+	repo.SetOperationTimeout(time.Second)
+	repo.SetOperationTimeout(0)
+	if repo.timeout != time.Second {
+		t.Errorf("expected 1s, got %v", repo.timeout)
+	}
+}
@@ -0,0 +1,120 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+)
+
+// UserRepositoryI defines the interface for user repository operations.
+type UserRepositoryI interface {
+	CreateUser(ctx context.Context, user *models.User) error
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	SetUserRole(ctx context.Context, email, role string, updatedAt time.Time) error
+}
+
+type UserRepository struct {
+	store
+}
+
+func NewUserRepository(client *mongo.Client, dbName string) *UserRepository {
+	return &UserRepository{store: newStore(client, dbName)}
+}
+
+func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) error {
+	if user == nil {
+		return errors.New("user cannot be nil")
+	}
+	if user.UserID == "" {
+		return apperr.Required("user_id")
+	}
+	if user.Email == "" {
+		return apperr.Required("email")
+	}
+	if user.PasswordHash == "" {
+		return apperr.Required("password_hash")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	// the unique index from EnsureUserIndexes rejects a second account with
+	// the same email, even when two registrations race
+	coll := r.client.Database(r.dbName).Collection("users")
+	_, err := coll.InsertOne(ctx, user)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return apperr.Conflict("user with this email already exists")
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	if email == "" {
+		return nil, apperr.Required("email")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("users")
+	var user models.User
+	err := coll.FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, apperr.NotFound("user")
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+// SetUserRole changes the role of the user with the given email. The role
+// is read when tokens are issued, so it applies from the user's next login.
+func (r *UserRepository) SetUserRole(ctx context.Context, email, role string, updatedAt time.Time) error {
+	if email == "" {
+		return apperr.Required("email")
+	}
+	if role == "" {
+		return apperr.Required("role")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("users")
+	result, err := coll.UpdateOne(ctx, bson.M{"email": email}, bson.M{"$set": bson.M{"role": role, "updated_at": updatedAt}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return apperr.NotFound("user")
+	}
+
+	return nil
+}
+
+// EnsureUserIndexes creates the unique index on email that keeps two
+// accounts from sharing an address.
+func (r *UserRepository) EnsureUserIndexes(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	coll := r.client.Database(r.dbName).Collection("users")
+	_, err := coll.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "email", Value: 1}},
+		Options: options.Index().SetName("email_unique").SetUnique(true),
+	})
+	return err
+}
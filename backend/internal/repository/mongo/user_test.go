@@ -0,0 +1,47 @@
+package mongo_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	mongopkg "github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+func TestCreateUserRejectsDuplicateEmailConcurrently(t *testing.T) {
+	client := testClient(t)
+	repo := mongopkg.NewUserRepository(client, testDatabase(t, client))
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repo.CreateUser(ctx, &models.User{
+				UserID:       fmt.Sprintf("u%d", i),
+				Email:        "owner@example.com",
+				PasswordHash: "hash",
+			})
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, apperr.ErrConflict):
+			t.Errorf("expected a conflict, got %v", err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("expected exactly one account, got %d", created)
+	}
+}
@@ -0,0 +1,180 @@
+package repotest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// TestAuditRepository runs the mongo.AuditRepositoryI conformance suite.
+// newRepo must return an empty repository; it is called once per subtest.
+func TestAuditRepository(t *testing.T, newRepo func(t *testing.T) mongo.AuditRepositoryI) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo mongo.AuditRepositoryI)
+	}{
+		{"CreateAndList", testAuditCreateAndList},
+		{"CreateValidates", testAuditCreateValidates},
+		{"CreateRejectsDuplicates", testAuditCreateRejectsDuplicates},
+		{"ListScopingAndOrder", testAuditListScopingAndOrder},
+		{"ListEntityFilter", testAuditListEntityFilter},
+		{"ListPages", testAuditListPages},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+// fixtureEvent returns an update of entity recorded minute minutes after
+// base.
+func fixtureEvent(id, businessID, entity, menuID string, minute int) *models.AuditEvent {
+	return &models.AuditEvent{
+		EventID:    id,
+		BusinessID: businessID,
+		ActorID:    "u1",
+		Action:     "update",
+		Entity:     entity,
+		MenuID:     menuID,
+		Changes:    []models.FieldChange{{Field: "name", Before: "Lunch", After: "Brunch"}},
+		OccurredAt: base.Add(time.Duration(minute) * time.Minute),
+	}
+}
+
+func mustCreateEvent(t *testing.T, repo mongo.AuditRepositoryI, event *models.AuditEvent) {
+	t.Helper()
+	if err := repo.CreateAuditEvent(context.Background(), event); err != nil {
+		t.Fatalf("creating audit event %s: %v", event.EventID, err)
+	}
+}
+
+func mustListEvents(t *testing.T, repo mongo.AuditRepositoryI, businessID string, opts models.AuditListOptions) []models.AuditEvent {
+	t.Helper()
+	events, err := repo.ListAuditEvents(context.Background(), businessID, opts)
+	if err != nil {
+		t.Fatalf("listing audit events of %s: %v", businessID, err)
+	}
+	return events
+}
+
+func eventIDs(events []models.AuditEvent) string {
+	ids := make([]string, len(events))
+	for i, e := range events {
+		ids[i] = e.EventID
+	}
+	return strings.Join(ids, " ")
+}
+
+func testAuditCreateAndList(t *testing.T, repo mongo.AuditRepositoryI) {
+	event := fixtureEvent("e1", "b1", "menu:m1", "m1", 0)
+	event.RequestID = "req-1"
+	event.Changes = append(event.Changes, models.FieldChange{Field: "description", Before: "", After: "Weekends only"})
+	mustCreateEvent(t, repo, event)
+
+	events := mustListEvents(t, repo, "b1", models.AuditListOptions{})
+	if len(events) != 1 {
+		t.Fatalf("expected one event, got %q", eventIDs(events))
+	}
+	expectSameJSON(t, events[0], event)
+}
+
+func testAuditCreateValidates(t *testing.T, repo mongo.AuditRepositoryI) {
+	ctx := context.Background()
+	if err := repo.CreateAuditEvent(ctx, nil); err == nil {
+		t.Error("expected an error for a nil event")
+	}
+	expectError(t, repo.CreateAuditEvent(ctx, &models.AuditEvent{BusinessID: "b1"}), apperr.ErrValidation, "missing event_id")
+	expectError(t, repo.CreateAuditEvent(ctx, &models.AuditEvent{EventID: "e1"}), apperr.ErrValidation, "missing business_id")
+
+	_, err := repo.ListAuditEvents(ctx, "", models.AuditListOptions{})
+	expectError(t, err, apperr.ErrValidation, "list without business_id")
+}
+
+func testAuditCreateRejectsDuplicates(t *testing.T, repo mongo.AuditRepositoryI) {
+	mustCreateEvent(t, repo, fixtureEvent("e1", "b1", "menu:m1", "m1", 0))
+
+	// the log is append-only, so an event is never overwritten
+	other := fixtureEvent("e1", "b1", "menu:m2", "m2", 1)
+	if err := repo.CreateAuditEvent(context.Background(), other); err == nil {
+		t.Error("expected an error for a duplicate ID")
+	}
+	if events := mustListEvents(t, repo, "b1", models.AuditListOptions{}); len(events) != 1 || events[0].MenuID != "m1" {
+		t.Errorf("expected the first event to be kept, got %+v", events)
+	}
+}
+
+func testAuditListScopingAndOrder(t *testing.T, repo mongo.AuditRepositoryI) {
+	mustCreateEvent(t, repo, fixtureEvent("e1", "b1", "menu:m1", "m1", 0))
+	mustCreateEvent(t, repo, fixtureEvent("e2", "b1", "menu:m1", "m1", 2))
+	mustCreateEvent(t, repo, fixtureEvent("e3", "b1", "menu:m1", "m1", 1))
+	mustCreateEvent(t, repo, fixtureEvent("e4", "b2", "menu:m9", "m9", 3))
+	// events recorded at the same time are ordered by ID
+	mustCreateEvent(t, repo, fixtureEvent("e5", "b1", "menu:m1", "m1", 1))
+
+	if ids := eventIDs(mustListEvents(t, repo, "b1", models.AuditListOptions{})); ids != "e2 e5 e3 e1" {
+		t.Errorf("expected the events of b1 newest first, got %q", ids)
+	}
+	if ids := eventIDs(mustListEvents(t, repo, "b2", models.AuditListOptions{})); ids != "e4" {
+		t.Errorf("unexpected events of b2: %q", ids)
+	}
+	if events := mustListEvents(t, repo, "missing", models.AuditListOptions{}); len(events) != 0 {
+		t.Errorf("expected no events for an unknown business, got %q", eventIDs(events))
+	}
+}
+
+func testAuditListEntityFilter(t *testing.T, repo mongo.AuditRepositoryI) {
+	mustCreateEvent(t, repo, fixtureEvent("e1", "b1", "menu:m1", "m1", 0))
+	mustCreateEvent(t, repo, fixtureEvent("e2", "b1", "section:s1", "m1", 1))
+	mustCreateEvent(t, repo, fixtureEvent("e3", "b1", "item:i1", "m1", 2))
+	mustCreateEvent(t, repo, fixtureEvent("e4", "b1", "item:i2", "m2", 3))
+	mustCreateEvent(t, repo, fixtureEvent("e5", "b1", "business:b1", "", 4))
+	mustCreateEvent(t, repo, fixtureEvent("e6", "b2", "item:i1", "m1", 5))
+
+	for _, tt := range []struct {
+		entity string
+		want   string
+	}{
+		// a menu reference includes the events of its sections and items
+		{"menu:m1", "e3 e2 e1"},
+		{"menu:m2", "e4"},
+		{"item:i1", "e3"},
+		{"section:s1", "e2"},
+		{"business:b1", "e5"},
+		{"item:missing", ""},
+	} {
+		events := mustListEvents(t, repo, "b1", models.AuditListOptions{Entity: tt.entity})
+		if ids := eventIDs(events); ids != tt.want {
+			t.Errorf("entity %s: expected %q, got %q", tt.entity, tt.want, ids)
+		}
+	}
+}
+
+func testAuditListPages(t *testing.T, repo mongo.AuditRepositoryI) {
+	for i, id := range []string{"e1", "e2", "e3", "e4"} {
+		mustCreateEvent(t, repo, fixtureEvent(id, "b1", "menu:m1", "m1", i))
+	}
+	// two events at the time of e4, one ordered before and one after it
+	mustCreateEvent(t, repo, fixtureEvent("e3a", "b1", "menu:m1", "m1", 3))
+	mustCreateEvent(t, repo, fixtureEvent("e5", "b1", "menu:m1", "m1", 3))
+
+	opts := models.AuditListOptions{Limit: 2}
+	var pages []string
+	for range 5 {
+		events := mustListEvents(t, repo, "b1", opts)
+		if len(events) == 0 {
+			break
+		}
+		pages = append(pages, eventIDs(events))
+		last := events[len(events)-1]
+		opts.After = &models.AuditCursor{OccurredAt: last.OccurredAt, ID: last.EventID}
+	}
+	if got := strings.Join(pages, " | "); got != "e5 e4 | e3a e3 | e2 e1" {
+		t.Errorf("unexpected pages: %q", got)
+	}
+}
@@ -0,0 +1,185 @@
+package repotest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// TestBusinessRepository runs the mongo.BusinessRepositoryI conformance
+// suite. newRepo must return an empty repository; it is called once per
+// subtest.
+func TestBusinessRepository(t *testing.T, newRepo func(t *testing.T) mongo.BusinessRepositoryI) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo mongo.BusinessRepositoryI)
+	}{
+		{"CreateAndGet", testBusinessCreateAndGet},
+		{"CreateValidates", testBusinessCreateValidates},
+		{"CreateRejectsDuplicates", testBusinessCreateRejectsDuplicates},
+		{"NotFound", testBusinessNotFound},
+		{"Update", testBusinessUpdate},
+		{"Delete", testBusinessDelete},
+		{"DoesNotShareMemory", testBusinessDoesNotShareMemory},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func fixtureBusiness(id string) *models.Business {
+	return &models.Business{
+		BusinessID: id,
+		Name:       "Business " + id,
+		Address: models.Address{
+			Line1:      "1 Harbour Street",
+			City:       "Lisbon",
+			PostalCode: "1100-001",
+			Country:    "PT",
+		},
+		Timezone:        "Europe/Lisbon",
+		DefaultCurrency: "EUR",
+		Locale:          "pt-PT",
+		CreatedAt:       base,
+		UpdatedAt:       base,
+	}
+}
+
+func mustCreateBusiness(t *testing.T, repo mongo.BusinessRepositoryI, business *models.Business) {
+	t.Helper()
+	if err := repo.CreateBusiness(context.Background(), business); err != nil {
+		t.Fatalf("creating business %s: %v", business.BusinessID, err)
+	}
+}
+
+func mustGetBusiness(t *testing.T, repo mongo.BusinessRepositoryI, businessID string) *models.Business {
+	t.Helper()
+	business, err := repo.GetBusinessByID(context.Background(), businessID)
+	if err != nil {
+		t.Fatalf("getting business %s: %v", businessID, err)
+	}
+	return business
+}
+
+func testBusinessCreateAndGet(t *testing.T, repo mongo.BusinessRepositoryI) {
+	business := fixtureBusiness("b1")
+	business.Address.Line2 = "2nd floor"
+	business.Address.Region = "Lisboa"
+	business.LogoURL = "https://media.example.com/logos/b1.png"
+	mustCreateBusiness(t, repo, business)
+
+	expectSameJSON(t, mustGetBusiness(t, repo, "b1"), business)
+}
+
+func testBusinessCreateValidates(t *testing.T, repo mongo.BusinessRepositoryI) {
+	ctx := context.Background()
+	if err := repo.CreateBusiness(ctx, nil); err == nil {
+		t.Error("expected an error for a nil business")
+	}
+	expectError(t, repo.CreateBusiness(ctx, &models.Business{Name: "Cafe"}), apperr.ErrValidation, "missing business_id")
+	expectError(t, repo.CreateBusiness(ctx, &models.Business{BusinessID: "b1"}), apperr.ErrValidation, "missing name")
+
+	_, err := repo.GetBusinessByID(ctx, "")
+	expectError(t, err, apperr.ErrValidation, "get without business_id")
+}
+
+func testBusinessCreateRejectsDuplicates(t *testing.T, repo mongo.BusinessRepositoryI) {
+	mustCreateBusiness(t, repo, fixtureBusiness("b1"))
+
+	other := fixtureBusiness("b1")
+	other.Name = "Other"
+	expectError(t, repo.CreateBusiness(context.Background(), other), apperr.ErrConflict, "duplicate ID")
+	if got := mustGetBusiness(t, repo, "b1"); got.Name != "Business b1" {
+		t.Errorf("expected the first business to be kept, got %q", got.Name)
+	}
+}
+
+func testBusinessNotFound(t *testing.T, repo mongo.BusinessRepositoryI) {
+	ctx := context.Background()
+	mustCreateBusiness(t, repo, fixtureBusiness("b1"))
+
+	_, err := repo.GetBusinessByID(ctx, "missing")
+	expectError(t, err, apperr.ErrNotFound, "get unknown business")
+	expectError(t, repo.UpdateBusiness(ctx, "missing", fixtureBusiness("missing")), apperr.ErrNotFound, "update unknown business")
+	expectError(t, repo.DeleteBusiness(ctx, "missing"), apperr.ErrNotFound, "delete unknown business")
+
+	expectSameJSON(t, mustGetBusiness(t, repo, "b1"), fixtureBusiness("b1"))
+}
+
+func testBusinessUpdate(t *testing.T, repo mongo.BusinessRepositoryI) {
+	ctx := context.Background()
+	business := fixtureBusiness("b1")
+	business.LogoURL = "https://media.example.com/logos/b1.png"
+	mustCreateBusiness(t, repo, business)
+
+	// an update replaces the profile, so empty fields are cleared
+	before := time.Now().Truncate(time.Millisecond)
+	update := &models.Business{
+		Name:            "Harbour Cafe",
+		Address:         models.Address{Line1: "2 Dock Road", City: "Porto", Country: "PT"},
+		Timezone:        "Atlantic/Azores",
+		DefaultCurrency: "USD",
+		Locale:          "en-US",
+		// the ID and creation time are not editable
+		BusinessID: "b2",
+		CreatedAt:  base.Add(time.Hour),
+	}
+	if err := repo.UpdateBusiness(ctx, "b1", update); err != nil {
+		t.Fatalf("updating business: %v", err)
+	}
+
+	got := mustGetBusiness(t, repo, "b1")
+	want := fixtureBusiness("b1")
+	want.Name = update.Name
+	want.Address = update.Address
+	want.Timezone = update.Timezone
+	want.DefaultCurrency = update.DefaultCurrency
+	want.Locale = update.Locale
+	want.UpdatedAt = got.UpdatedAt
+	expectSameJSON(t, got, want)
+	if got.UpdatedAt.Before(before) {
+		t.Errorf("expected updated_at to be set by the write, got %v", got.UpdatedAt)
+	}
+	if _, err := repo.GetBusinessByID(ctx, "b2"); err == nil {
+		t.Error("expected the update not to create b2")
+	}
+}
+
+func testBusinessDelete(t *testing.T, repo mongo.BusinessRepositoryI) {
+	ctx := context.Background()
+	mustCreateBusiness(t, repo, fixtureBusiness("b1"))
+	mustCreateBusiness(t, repo, fixtureBusiness("b2"))
+
+	if err := repo.DeleteBusiness(ctx, "b1"); err != nil {
+		t.Fatalf("deleting business: %v", err)
+	}
+	_, err := repo.GetBusinessByID(ctx, "b1")
+	expectError(t, err, apperr.ErrNotFound, "get deleted business")
+	expectError(t, repo.DeleteBusiness(ctx, "b1"), apperr.ErrNotFound, "delete deleted business")
+	mustGetBusiness(t, repo, "b2")
+
+	// the ID is free again
+	mustCreateBusiness(t, repo, fixtureBusiness("b1"))
+}
+
+func testBusinessDoesNotShareMemory(t *testing.T, repo mongo.BusinessRepositoryI) {
+	business := fixtureBusiness("b1")
+	mustCreateBusiness(t, repo, business)
+	business.Address.City = "changed after create"
+
+	got := mustGetBusiness(t, repo, "b1")
+	if got.Address.City != "Lisbon" {
+		t.Errorf("stored business changed with the created value: %q", got.Address.City)
+	}
+	got.Address.City = "changed after get"
+
+	if again := mustGetBusiness(t, repo, "b1"); again.Address.City != "Lisbon" {
+		t.Errorf("stored business changed with a returned value: %q", again.Address.City)
+	}
+}
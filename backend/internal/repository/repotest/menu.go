@@ -0,0 +1,635 @@
+// Package repotest holds conformance tests that every implementation of a
+// repository interface must pass, so that MongoDB, the in-memory store and
+// the test doubles cannot drift apart. An implementation runs a suite from
+// its own tests:
+//
+//	func TestMenuRepositoryConformance(t *testing.T) {
+//		repotest.TestMenuRepository(t, func(t *testing.T) mongo.MenuRepositoryI {
+//			return memory.NewMenuRepository()
+//		})
+//	}
+package repotest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// base is the time the fixtures are created at. Like every time in the
+// fixtures it is in UTC with millisecond precision, which all
+// implementations store without loss.
+var base = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// TestMenuRepository runs the mongo.MenuRepositoryI conformance suite.
+// newRepo must return an empty repository; it is called once per subtest.
+func TestMenuRepository(t *testing.T, newRepo func(t *testing.T) mongo.MenuRepositoryI) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo mongo.MenuRepositoryI)
+	}{
+		{"CreateAndGet", testMenuCreateAndGet},
+		{"CreateValidates", testMenuCreateValidates},
+		{"CreateRejectsDuplicates", testMenuCreateRejectsDuplicates},
+		{"NotFound", testMenuNotFound},
+		{"Update", testMenuUpdate},
+		{"UpdateClearsOptionalFields", testMenuUpdateClearsOptionalFields},
+		{"UpdateConflicts", testMenuUpdateConflicts},
+		{"DeleteAndRestore", testMenuDeleteAndRestore},
+		{"Purge", testMenuPurge},
+		{"SetPublishedVersion", testMenuSetPublishedVersion},
+		{"ListScopingAndFilters", testMenuListScopingAndFilters},
+		{"ListPages", testMenuListPages},
+		{"DoesNotShareMemory", testMenuDoesNotShareMemory},
+		{"ConcurrentUpdates", testMenuConcurrentUpdates},
+		{"ConcurrentCreatesWithSameSlug", testMenuConcurrentCreates},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func fixtureMenu(id, businessID, slug string) *models.Menu {
+	return &models.Menu{
+		MenuID:     id,
+		BusinessID: businessID,
+		Name:       "Menu " + id,
+		Slug:       slug,
+		IsActive:   true,
+		CreatedAt:  base,
+		UpdatedAt:  base,
+		Revision:   1,
+	}
+}
+
+func mustCreateMenu(t *testing.T, repo mongo.MenuRepositoryI, menu *models.Menu) {
+	t.Helper()
+	if err := repo.CreateMenu(context.Background(), menu); err != nil {
+		t.Fatalf("creating menu %s: %v", menu.MenuID, err)
+	}
+}
+
+func mustGetMenu(t *testing.T, repo mongo.MenuRepositoryI, menuID, businessID string) *models.Menu {
+	t.Helper()
+	menu, err := repo.GetMenuByID(context.Background(), menuID, businessID)
+	if err != nil {
+		t.Fatalf("getting menu %s: %v", menuID, err)
+	}
+	return menu
+}
+
+// expectError fails unless err is target according to errors.Is.
+func expectError(t *testing.T, err, target error, what string) {
+	t.Helper()
+	if !errors.Is(err, target) {
+		t.Errorf("%s: expected %v, got %v", what, target, err)
+	}
+}
+
+// expectSameJSON compares values by their JSON form, which is what clients
+// see and which does not depend on how times or empty lists are held.
+func expectSameJSON(t *testing.T, got, want any) {
+	t.Helper()
+	g, _ := json.Marshal(got)
+	w, _ := json.Marshal(want)
+	if string(g) != string(w) {
+		t.Errorf("got  %s\nwant %s", g, w)
+	}
+}
+
+func menuIDs(menus []models.Menu) string {
+	ids := make([]string, len(menus))
+	for i, m := range menus {
+		ids[i] = m.MenuID
+	}
+	return strings.Join(ids, " ")
+}
+
+func testMenuCreateAndGet(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	publishedAt := base.Add(time.Hour)
+	menu := fixtureMenu("m1", "b1", "brunch")
+	menu.Description = "Weekends only"
+	menu.Schedule = &models.MenuSchedule{
+		Windows: []models.WeeklyWindow{{Days: []string{"sat", "sun"}, Start: "09:00", End: "14:00"}},
+	}
+	menu.PublishedVersion = 2
+	menu.PublishedAt = &publishedAt
+	mustCreateMenu(t, repo, menu)
+
+	expectSameJSON(t, mustGetMenu(t, repo, "m1", "b1"), menu)
+
+	bySlug, err := repo.GetMenuBySlug(ctx, "brunch")
+	if err != nil {
+		t.Fatalf("getting menu by slug: %v", err)
+	}
+	expectSameJSON(t, bySlug, menu)
+}
+
+func testMenuCreateValidates(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	for field, menu := range map[string]*models.Menu{
+		"menu_id":     {Name: "Lunch", BusinessID: "b1"},
+		"name":        {MenuID: "m1", BusinessID: "b1"},
+		"business_id": {MenuID: "m1", Name: "Lunch"},
+	} {
+		expectError(t, repo.CreateMenu(ctx, menu), apperr.ErrValidation, "missing "+field)
+	}
+
+	_, err := repo.GetMenuByID(ctx, "", "b1")
+	expectError(t, err, apperr.ErrValidation, "get without menu_id")
+	_, err = repo.ListMenusByBusiness(ctx, "", models.MenuListOptions{})
+	expectError(t, err, apperr.ErrValidation, "list without business_id")
+}
+
+func testMenuCreateRejectsDuplicates(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	mustCreateMenu(t, repo, fixtureMenu("m1", "b1", "brunch"))
+
+	expectError(t, repo.CreateMenu(ctx, fixtureMenu("m1", "b1", "other")), apperr.ErrConflict, "duplicate ID")
+	expectError(t, repo.CreateMenu(ctx, fixtureMenu("m2", "b2", "brunch")), mongo.ErrMenuSlugTaken, "slug of another business")
+
+	// menus without a slug never collide
+	mustCreateMenu(t, repo, fixtureMenu("m3", "b1", ""))
+	mustCreateMenu(t, repo, fixtureMenu("m4", "b1", ""))
+}
+
+func testMenuNotFound(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	mustCreateMenu(t, repo, fixtureMenu("m1", "b1", "brunch"))
+
+	_, err := repo.GetMenuByID(ctx, "missing", "b1")
+	expectError(t, err, apperr.ErrNotFound, "get unknown menu")
+	_, err = repo.GetMenuByID(ctx, "m1", "b2")
+	expectError(t, err, apperr.ErrNotFound, "get menu of another business")
+	_, err = repo.GetMenuBySlug(ctx, "missing")
+	expectError(t, err, apperr.ErrNotFound, "get unknown slug")
+
+	update := fixtureMenu("m1", "b1", "brunch")
+	expectError(t, repo.UpdateMenu(ctx, "missing", "b1", update), apperr.ErrNotFound, "update unknown menu")
+	expectError(t, repo.UpdateMenu(ctx, "m1", "b2", update), apperr.ErrNotFound, "update menu of another business")
+	expectError(t, repo.DeleteMenu(ctx, "m1", "b2", 1), apperr.ErrNotFound, "delete menu of another business")
+	expectError(t, repo.RestoreMenu(ctx, "m1", "b2"), apperr.ErrNotFound, "restore menu of another business")
+	expectError(t, repo.SetMenuPublishedVersion(ctx, "m1", "b2", 1, base), apperr.ErrNotFound, "publish menu of another business")
+
+	// the failed writes changed nothing
+	if menu := mustGetMenu(t, repo, "m1", "b1"); menu.Revision != 1 || menu.DeletedAt != nil || menu.PublishedVersion != 0 {
+		t.Errorf("menu changed by writes of another business: %+v", menu)
+	}
+}
+
+func testMenuUpdate(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	menu := fixtureMenu("m1", "b1", "brunch")
+	menu.Description = "Weekends only"
+	menu.Schedule = &models.MenuSchedule{Windows: []models.WeeklyWindow{{Days: []string{"sat"}, Start: "09:00", End: "14:00"}}}
+	menu.PublishedVersion = 3
+	mustCreateMenu(t, repo, menu)
+
+	before := time.Now().Truncate(time.Millisecond)
+	update := mustGetMenu(t, repo, "m1", "b1")
+	update.Name = "Late Brunch"
+	update.Slug = "late-brunch"
+	update.Description = ""
+	update.IsActive = false
+	update.Schedule = nil
+	// fields that are not editable are ignored
+	update.BusinessID = "b2"
+	update.CreatedAt = base.Add(time.Hour)
+	update.PublishedVersion = 9
+	if err := repo.UpdateMenu(ctx, "m1", "b1", update); err != nil {
+		t.Fatalf("updating menu: %v", err)
+	}
+
+	got := mustGetMenu(t, repo, "m1", "b1")
+	if got.Name != "Late Brunch" || got.Slug != "late-brunch" || got.Description != "" || got.IsActive || got.Schedule != nil {
+		t.Errorf("editable fields not written: %+v", got)
+	}
+	if got.BusinessID != "b1" || !got.CreatedAt.Equal(base) || got.PublishedVersion != 3 {
+		t.Errorf("fields that are not editable changed: %+v", got)
+	}
+	if got.Revision != 2 {
+		t.Errorf("expected revision 2, got %d", got.Revision)
+	}
+	if got.UpdatedAt.Before(before) {
+		t.Errorf("expected updated_at to be set by the write, got %v", got.UpdatedAt)
+	}
+
+	if _, err := repo.GetMenuBySlug(ctx, "brunch"); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("expected the old slug to be released, got %v", err)
+	}
+	mustCreateMenu(t, repo, fixtureMenu("m2", "b1", "brunch"))
+}
+
+func testMenuUpdateClearsOptionalFields(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	schedule := &models.MenuSchedule{Windows: []models.WeeklyWindow{{Days: []string{"sat"}, Start: "09:00", End: "14:00"}}}
+	for _, id := range []string{"m1", "m2"} {
+		menu := fixtureMenu(id, "b1", "brunch-"+id)
+		menu.Description = "Weekends only"
+		menu.Schedule = schedule
+		mustCreateMenu(t, repo, menu)
+	}
+
+	// clearing the slug of two menus must not make them collide on the
+	// empty value
+	for _, id := range []string{"m1", "m2"} {
+		update := mustGetMenu(t, repo, id, "b1")
+		update.Slug = ""
+		update.Description = ""
+		update.Schedule = nil
+		if err := repo.UpdateMenu(ctx, id, "b1", update); err != nil {
+			t.Fatalf("clearing %s: %v", id, err)
+		}
+		got := mustGetMenu(t, repo, id, "b1")
+		if got.Slug != "" || got.Description != "" || got.Schedule != nil {
+			t.Errorf("expected the optional fields of %s to be cleared, got %+v", id, got)
+		}
+	}
+	menus, err := repo.ListMenusByBusiness(ctx, "b1", models.MenuListOptions{})
+	if err != nil {
+		t.Fatalf("listing menus: %v", err)
+	}
+	for _, menu := range menus {
+		if menu.Slug != "" || menu.Description != "" || menu.Schedule != nil {
+			t.Errorf("expected listings to show the cleared fields, got %+v", menu)
+		}
+	}
+
+	// and they can be set again
+	update := mustGetMenu(t, repo, "m1", "b1")
+	update.Slug = "brunch"
+	update.Description = "Back on Sundays"
+	update.Schedule = schedule
+	if err := repo.UpdateMenu(ctx, "m1", "b1", update); err != nil {
+		t.Fatalf("refilling m1: %v", err)
+	}
+	got, err := repo.GetMenuBySlug(ctx, "brunch")
+	if err != nil {
+		t.Fatalf("getting menu by slug: %v", err)
+	}
+	if got.MenuID != "m1" || got.Description != "Back on Sundays" || got.Schedule == nil {
+		t.Errorf("expected m1 with its fields restored, got %+v", got)
+	}
+}
+
+func testMenuUpdateConflicts(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	mustCreateMenu(t, repo, fixtureMenu("m1", "b1", "brunch"))
+	mustCreateMenu(t, repo, fixtureMenu("m2", "b1", "dinner"))
+
+	stale := fixtureMenu("m1", "b1", "brunch")
+	stale.Revision = 2
+	expectError(t, repo.UpdateMenu(ctx, "m1", "b1", stale), mongo.ErrMenuRevisionConflict, "stale revision")
+
+	taken := fixtureMenu("m1", "b1", "dinner")
+	expectError(t, repo.UpdateMenu(ctx, "m1", "b1", taken), mongo.ErrMenuSlugTaken, "slug of another menu")
+
+	// keeping its own slug is not a conflict
+	own := fixtureMenu("m1", "b1", "brunch")
+	own.Name = "Brunch"
+	if err := repo.UpdateMenu(ctx, "m1", "b1", own); err != nil {
+		t.Fatalf("updating menu with its own slug: %v", err)
+	}
+	if got := mustGetMenu(t, repo, "m1", "b1"); got.Name != "Brunch" || got.Revision != 2 {
+		t.Errorf("expected the update to apply once, got %+v", got)
+	}
+}
+
+func testMenuDeleteAndRestore(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	mustCreateMenu(t, repo, fixtureMenu("m1", "b1", "brunch"))
+	mustCreateMenu(t, repo, fixtureMenu("m2", "b1", "dinner"))
+
+	expectError(t, repo.DeleteMenu(ctx, "m1", "b1", 5), mongo.ErrMenuRevisionConflict, "delete with stale revision")
+	before := time.Now().Truncate(time.Millisecond)
+	if err := repo.DeleteMenu(ctx, "m1", "b1", 1); err != nil {
+		t.Fatalf("deleting menu: %v", err)
+	}
+	after := time.Now().Add(time.Second)
+
+	_, err := repo.GetMenuByID(ctx, "m1", "b1")
+	expectError(t, err, apperr.ErrNotFound, "get deleted menu")
+	_, err = repo.GetMenuBySlug(ctx, "brunch")
+	expectError(t, err, apperr.ErrNotFound, "get deleted menu by slug")
+	expectError(t, repo.DeleteMenu(ctx, "m1", "b1", 2), apperr.ErrNotFound, "delete deleted menu")
+	expectError(t, repo.UpdateMenu(ctx, "m1", "b1", fixtureMenu("m1", "b1", "brunch")), apperr.ErrNotFound, "update deleted menu")
+	expectError(t, repo.CreateMenu(ctx, fixtureMenu("m3", "b1", "brunch")), mongo.ErrMenuSlugTaken, "slug of deleted menu")
+
+	live, err := repo.ListMenusByBusiness(ctx, "b1", models.MenuListOptions{})
+	if err != nil {
+		t.Fatalf("listing menus: %v", err)
+	}
+	if ids := menuIDs(live); ids != "m2" {
+		t.Errorf("expected only m2 to be listed, got %q", ids)
+	}
+
+	deleted, err := repo.ListDeletedMenus(ctx, after)
+	if err != nil {
+		t.Fatalf("listing deleted menus: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].MenuID != "m1" || deleted[0].DeletedAt == nil || deleted[0].DeletedAt.Before(before) {
+		t.Errorf("expected m1 with its deletion time, got %+v", deleted)
+	}
+	if deleted[0].Revision != 2 {
+		t.Errorf("expected the deletion to bump the revision to 2, got %d", deleted[0].Revision)
+	}
+	deleted, err = repo.ListDeletedMenus(ctx, before.Add(-time.Second))
+	if err != nil {
+		t.Fatalf("listing deleted menus: %v", err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("expected no menus deleted before the cutoff, got %s", menuIDs(deleted))
+	}
+
+	if err := repo.RestoreMenu(ctx, "m1", "b1"); err != nil {
+		t.Fatalf("restoring menu: %v", err)
+	}
+	restored := mustGetMenu(t, repo, "m1", "b1")
+	if restored.DeletedAt != nil || restored.Revision != 3 || restored.Slug != "brunch" {
+		t.Errorf("unexpected restored menu: %+v", restored)
+	}
+
+	// restoring a live menu changes nothing, so clients' ETags stay valid
+	if err := repo.RestoreMenu(ctx, "m1", "b1"); err != nil {
+		t.Fatalf("restoring live menu: %v", err)
+	}
+	again := mustGetMenu(t, repo, "m1", "b1")
+	if again.Revision != restored.Revision || !again.UpdatedAt.Equal(restored.UpdatedAt) {
+		t.Errorf("expected restoring a live menu to leave it unchanged, got revision %d and updated_at %v", again.Revision, again.UpdatedAt)
+	}
+	expectError(t, repo.RestoreMenu(ctx, "missing", "b1"), apperr.ErrNotFound, "restore unknown menu")
+}
+
+func testMenuPurge(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	mustCreateMenu(t, repo, fixtureMenu("m1", "b1", "brunch"))
+
+	expectError(t, repo.PurgeMenu(ctx, "m1"), apperr.ErrNotFound, "purge live menu")
+	mustGetMenu(t, repo, "m1", "b1")
+	_, err := repo.GetDeletedMenu(ctx, "m1")
+	expectError(t, err, apperr.ErrNotFound, "get live menu as deleted")
+
+	if err := repo.DeleteMenu(ctx, "m1", "b1", 1); err != nil {
+		t.Fatalf("deleting menu: %v", err)
+	}
+	deleted, err := repo.GetDeletedMenu(ctx, "m1")
+	if err != nil {
+		t.Fatalf("getting deleted menu: %v", err)
+	}
+	if deleted.BusinessID != "b1" || deleted.DeletedAt == nil {
+		t.Errorf("expected the deleted menu of b1, got %+v", deleted)
+	}
+	if err := repo.PurgeMenu(ctx, "m1"); err != nil {
+		t.Fatalf("purging menu: %v", err)
+	}
+	expectError(t, repo.PurgeMenu(ctx, "m1"), apperr.ErrNotFound, "purge purged menu")
+	_, err = repo.GetDeletedMenu(ctx, "m1")
+	expectError(t, err, apperr.ErrNotFound, "get purged menu")
+	expectError(t, repo.RestoreMenu(ctx, "m1", "b1"), apperr.ErrNotFound, "restore purged menu")
+
+	// purging frees the ID and the slug
+	mustCreateMenu(t, repo, fixtureMenu("m1", "b1", "brunch"))
+}
+
+func testMenuSetPublishedVersion(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	mustCreateMenu(t, repo, fixtureMenu("m1", "b1", "brunch"))
+
+	if err := repo.SetMenuPublishedVersion(ctx, "m1", "b1", 2, base.Add(time.Hour)); err != nil {
+		t.Fatalf("publishing version 2: %v", err)
+	}
+	// a slow publish of an older version does not move the pointer back
+	if err := repo.SetMenuPublishedVersion(ctx, "m1", "b1", 1, base.Add(time.Minute)); err != nil {
+		t.Fatalf("publishing version 1: %v", err)
+	}
+
+	menu := mustGetMenu(t, repo, "m1", "b1")
+	if menu.PublishedVersion != 2 || menu.PublishedAt == nil || !menu.PublishedAt.Equal(base.Add(time.Hour)) {
+		t.Errorf("expected version 2 published at %v, got %d at %v", base.Add(time.Hour), menu.PublishedVersion, menu.PublishedAt)
+	}
+	if menu.Revision != 3 {
+		t.Errorf("expected every publish to bump the revision, got %d", menu.Revision)
+	}
+	if !menu.UpdatedAt.Equal(base) {
+		t.Errorf("expected updated_at to be left alone, got %v", menu.UpdatedAt)
+	}
+
+	// deleted menus can be published too, for the versioning backfill
+	if err := repo.DeleteMenu(ctx, "m1", "b1", 3); err != nil {
+		t.Fatalf("deleting menu: %v", err)
+	}
+	if err := repo.SetMenuPublishedVersion(ctx, "m1", "b1", 3, base.Add(2*time.Hour)); err != nil {
+		t.Errorf("publishing a deleted menu: %v", err)
+	}
+	expectError(t, repo.SetMenuPublishedVersion(ctx, "missing", "b1", 1, base), apperr.ErrNotFound, "publish unknown menu")
+}
+
+func testMenuListScopingAndFilters(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	for i := 0; i < 4; i++ {
+		menu := fixtureMenu(fmt.Sprintf("m%d", i), "b1", fmt.Sprintf("s%d", i))
+		menu.IsActive = i%2 == 0
+		menu.CreatedAt = base.Add(time.Duration(i) * time.Hour)
+		menu.UpdatedAt = base.Add(time.Duration(4-i) * time.Hour)
+		mustCreateMenu(t, repo, menu)
+	}
+	mustCreateMenu(t, repo, fixtureMenu("x1", "b2", "x1"))
+
+	active, inactive := true, false
+	createdAfter, createdBefore := base.Add(time.Hour), base.Add(3*time.Hour)
+	updatedAfter := base.Add(3 * time.Hour)
+	for _, tt := range []struct {
+		name string
+		opts models.MenuListOptions
+		want string
+	}{
+		{"all", models.MenuListOptions{}, "m0 m1 m2 m3"},
+		{"active", models.MenuListOptions{IsActive: &active}, "m0 m2"},
+		{"inactive", models.MenuListOptions{IsActive: &inactive}, "m1 m3"},
+		{"created range", models.MenuListOptions{CreatedAfter: &createdAfter, CreatedBefore: &createdBefore}, "m1 m2"},
+		{"updated after", models.MenuListOptions{UpdatedAfter: &updatedAfter}, "m0 m1"},
+		{"limit", models.MenuListOptions{Limit: 3}, "m0 m1 m2"},
+	} {
+		menus, err := repo.ListMenusByBusiness(ctx, "b1", tt.opts)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if got := menuIDs(menus); got != tt.want {
+			t.Errorf("%s: expected %q, got %q", tt.name, tt.want, got)
+		}
+	}
+
+	menus, err := repo.ListMenusByBusiness(ctx, "b3", models.MenuListOptions{})
+	if err != nil {
+		t.Fatalf("listing menus of a business without menus: %v", err)
+	}
+	if len(menus) != 0 {
+		t.Errorf("expected no menus, got %q", menuIDs(menus))
+	}
+}
+
+func testMenuListPages(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	// names and creation times repeat so pages must break ties by ID
+	fixtures := []struct {
+		id, name string
+		hour     int
+	}{
+		{"m1", "Brunch", 2},
+		{"m2", "Apéro", 1},
+		{"m3", "Brunch", 1},
+		{"m4", "Dinner", 0},
+		{"m5", "Apéro", 2},
+	}
+	for _, f := range fixtures {
+		menu := fixtureMenu(f.id, "b1", "")
+		menu.Name = f.name
+		menu.CreatedAt = base.Add(time.Duration(f.hour) * time.Hour)
+		menu.UpdatedAt = menu.CreatedAt
+		mustCreateMenu(t, repo, menu)
+	}
+
+	for _, tt := range []struct {
+		sortBy string
+		desc   bool
+		want   string
+	}{
+		{models.MenuSortName, false, "m2 m5 m1 m3 m4"},
+		{models.MenuSortName, true, "m4 m3 m1 m5 m2"},
+		{models.MenuSortCreatedAt, false, "m4 m2 m3 m1 m5"},
+		{models.MenuSortCreatedAt, true, "m5 m1 m3 m2 m4"},
+		{models.MenuSortUpdatedAt, true, "m5 m1 m3 m2 m4"},
+	} {
+		name := tt.sortBy
+		if tt.desc {
+			name = "-" + name
+		}
+		for _, limit := range []int{1, 2, 10} {
+			opts := models.MenuListOptions{SortBy: tt.sortBy, SortDesc: tt.desc, Limit: limit}
+			var all []models.Menu
+			for pages := 0; ; pages++ {
+				if pages > len(fixtures) {
+					t.Fatalf("%s by %d: listing does not end", name, limit)
+				}
+				page, err := repo.ListMenusByBusiness(ctx, "b1", opts)
+				if err != nil {
+					t.Fatalf("%s by %d: %v", name, limit, err)
+				}
+				all = append(all, page...)
+				if len(page) < limit {
+					break
+				}
+				c := models.NewMenuCursor(page[len(page)-1], tt.sortBy, tt.desc)
+				opts.After = &c
+			}
+			if got := menuIDs(all); got != tt.want {
+				t.Errorf("%s by %d: expected %q, got %q", name, limit, tt.want, got)
+			}
+		}
+	}
+}
+
+func testMenuDoesNotShareMemory(t *testing.T, repo mongo.MenuRepositoryI) {
+	menu := fixtureMenu("m1", "b1", "brunch")
+	mustCreateMenu(t, repo, menu)
+	menu.Name = "changed after create"
+
+	got := mustGetMenu(t, repo, "m1", "b1")
+	if got.Name != "Menu m1" {
+		t.Errorf("stored menu changed with the created value: %q", got.Name)
+	}
+	got.Name = "changed after get"
+
+	if again := mustGetMenu(t, repo, "m1", "b1"); again.Name != "Menu m1" {
+		t.Errorf("stored menu changed with a returned value: %q", again.Name)
+	}
+}
+
+func testMenuConcurrentUpdates(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+	mustCreateMenu(t, repo, fixtureMenu("m1", "b1", "brunch"))
+
+	// every writer read revision 1, so exactly one may win
+	const writers = 16
+	errs := make([]error, writers)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			update := fixtureMenu("m1", "b1", "brunch")
+			update.Name = fmt.Sprintf("Writer %d", i)
+			errs[i] = repo.UpdateMenu(ctx, "m1", "b1", update)
+		}(i)
+	}
+	wg.Wait()
+
+	winner := -1
+	for i, err := range errs {
+		switch {
+		case err == nil && winner >= 0:
+			t.Errorf("writers %d and %d both won", winner, i)
+		case err == nil:
+			winner = i
+		case !errors.Is(err, mongo.ErrMenuRevisionConflict):
+			t.Errorf("writer %d: expected a revision conflict, got %v", i, err)
+		}
+	}
+	if winner < 0 {
+		t.Fatal("no writer won")
+	}
+
+	menu := mustGetMenu(t, repo, "m1", "b1")
+	if menu.Revision != 2 || menu.Name != fmt.Sprintf("Writer %d", winner) {
+		t.Errorf("expected revision 2 written by writer %d, got %d by %q", winner, menu.Revision, menu.Name)
+	}
+}
+
+func testMenuConcurrentCreates(t *testing.T, repo mongo.MenuRepositoryI) {
+	ctx := context.Background()
+
+	const writers = 16
+	errs := make([]error, writers)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repo.CreateMenu(ctx, fixtureMenu(fmt.Sprintf("m%d", i), "b1", "brunch"))
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			created++
+		case !errors.Is(err, mongo.ErrMenuSlugTaken):
+			t.Errorf("writer %d: expected ErrMenuSlugTaken, got %v", i, err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("expected exactly one menu to get the slug, got %d", created)
+	}
+
+	menus, err := repo.ListMenusByBusiness(ctx, "b1", models.MenuListOptions{})
+	if err != nil {
+		t.Fatalf("listing menus: %v", err)
+	}
+	if len(menus) != 1 {
+		t.Errorf("expected one stored menu, got %q", menuIDs(menus))
+	}
+}
@@ -0,0 +1,354 @@
+package repotest
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// TestMenuItemRepository runs the mongo.MenuItemRepositoryI conformance
+// suite. newRepo must return an empty repository; it is called once per
+// subtest.
+func TestMenuItemRepository(t *testing.T, newRepo func(t *testing.T) mongo.MenuItemRepositoryI) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo mongo.MenuItemRepositoryI)
+	}{
+		{"CreateAndGet", testItemCreateAndGet},
+		{"CreateValidates", testItemCreateValidates},
+		{"CreateRejectsDuplicates", testItemCreateRejectsDuplicates},
+		{"NotFound", testItemNotFound},
+		{"Update", testItemUpdate},
+		{"UpdateClearsImage", testItemUpdateClearsImage},
+		{"Delete", testItemDelete},
+		{"ListScopingAndOrder", testItemListScopingAndOrder},
+		{"Reorder", testItemReorder},
+		{"DeleteByMenu", testItemDeleteByMenu},
+		{"DoesNotShareMemory", testItemDoesNotShareMemory},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func fixtureItem(id, menuID, sectionID string, position int) *models.MenuItem {
+	return &models.MenuItem{
+		ItemID:     id,
+		MenuID:     menuID,
+		BusinessID: "b1",
+		SectionID:  sectionID,
+		Position:   position,
+		Title:      "Item " + id,
+		Price:      models.Money{Amount: 950, Currency: "EUR"},
+		IsActive:   true,
+		CreatedAt:  base,
+		UpdatedAt:  base,
+	}
+}
+
+func mustCreateItem(t *testing.T, repo mongo.MenuItemRepositoryI, item *models.MenuItem) {
+	t.Helper()
+	if err := repo.CreateMenuItem(context.Background(), item); err != nil {
+		t.Fatalf("creating menu item %s: %v", item.ItemID, err)
+	}
+}
+
+func mustGetItem(t *testing.T, repo mongo.MenuItemRepositoryI, menuID, itemID string) *models.MenuItem {
+	t.Helper()
+	item, err := repo.GetMenuItemByID(context.Background(), menuID, itemID)
+	if err != nil {
+		t.Fatalf("getting menu item %s: %v", itemID, err)
+	}
+	return item
+}
+
+func mustListItems(t *testing.T, repo mongo.MenuItemRepositoryI, menuID string) []models.MenuItem {
+	t.Helper()
+	items, err := repo.ListMenuItemsByMenu(context.Background(), menuID)
+	if err != nil {
+		t.Fatalf("listing items of menu %s: %v", menuID, err)
+	}
+	return items
+}
+
+// itemPlaces lists items as "id@section/position".
+func itemPlaces(items []models.MenuItem) string {
+	places := make([]string, len(items))
+	for i, item := range items {
+		places[i] = item.ItemID + "@" + item.SectionID + "/" + strconv.Itoa(item.Position)
+	}
+	return strings.Join(places, " ")
+}
+
+func fixtureImage(key string) *models.ItemImage {
+	return &models.ItemImage{
+		URL:         "https://media.example.com/" + key,
+		Key:         key,
+		ContentType: "image/jpeg",
+		Width:       1200,
+		Height:      800,
+		Size:        48213,
+		Thumbnails: []models.ImageVariant{
+			{URL: "https://media.example.com/" + key + "-400", Key: key + "-400", ContentType: "image/jpeg", Width: 400, Height: 267},
+		},
+		UploadedAt: base,
+	}
+}
+
+func testItemCreateAndGet(t *testing.T, repo mongo.MenuItemRepositoryI) {
+	item := fixtureItem("i1", "m1", "s1", 1)
+	item.Description = "Two eggs, hollandaise"
+	item.ImageURL = "https://media.example.com/items/i1.jpg"
+	item.Ingredients = []string{"eggs", "butter"}
+	item.Allergens = []string{"eggs", "milk"}
+	item.DietaryTags = []string{"vegetarian"}
+	item.ModifierGroups = []models.ModifierGroup{{
+		GroupID:   "g1",
+		Name:      "Extras",
+		MaxSelect: 2,
+		Options: []models.ModifierOption{
+			{OptionID: "o1", Name: "Bacon", PriceDelta: models.Money{Amount: 250, Currency: "EUR"}},
+			{OptionID: "o2", Name: "No butter", PriceDelta: models.Money{Amount: -50, Currency: "EUR"}},
+		},
+	}}
+	item.Image = fixtureImage("items/i1")
+	mustCreateItem(t, repo, item)
+
+	got := mustGetItem(t, repo, "m1", "i1")
+	expectSameJSON(t, got, item)
+	// storage keys are not part of the JSON form but are needed to delete
+	// the files later
+	if got.Image == nil || !slices.Equal(got.Image.Keys(), item.Image.Keys()) {
+		t.Errorf("expected image keys %v, got %+v", item.Image.Keys(), got.Image)
+	}
+}
+
+func testItemCreateValidates(t *testing.T, repo mongo.MenuItemRepositoryI) {
+	ctx := context.Background()
+	if err := repo.CreateMenuItem(ctx, nil); err == nil {
+		t.Error("expected an error for a nil item")
+	}
+	for field, item := range map[string]*models.MenuItem{
+		"item_id":     {MenuID: "m1", BusinessID: "b1", Title: "Eggs"},
+		"menu_id":     {ItemID: "i1", BusinessID: "b1", Title: "Eggs"},
+		"business_id": {ItemID: "i1", MenuID: "m1", Title: "Eggs"},
+		"title":       {ItemID: "i1", MenuID: "m1", BusinessID: "b1"},
+	} {
+		expectError(t, repo.CreateMenuItem(ctx, item), apperr.ErrValidation, "missing "+field)
+	}
+
+	_, err := repo.GetMenuItemByID(ctx, "", "i1")
+	expectError(t, err, apperr.ErrValidation, "get without menu_id")
+	_, err = repo.GetMenuItemByID(ctx, "m1", "")
+	expectError(t, err, apperr.ErrValidation, "get without item_id")
+	_, err = repo.ListMenuItemsByMenu(ctx, "")
+	expectError(t, err, apperr.ErrValidation, "list without menu_id")
+	expectError(t, repo.DeleteMenuItemsByMenu(ctx, ""), apperr.ErrValidation, "delete by menu without menu_id")
+}
+
+func testItemCreateRejectsDuplicates(t *testing.T, repo mongo.MenuItemRepositoryI) {
+	mustCreateItem(t, repo, fixtureItem("i1", "m1", "s1", 1))
+
+	expectError(t, repo.CreateMenuItem(context.Background(), fixtureItem("i1", "m1", "s1", 2)), apperr.ErrConflict, "duplicate ID")
+	if got := mustGetItem(t, repo, "m1", "i1"); got.Position != 1 {
+		t.Errorf("expected the first item to be kept, got position %d", got.Position)
+	}
+}
+
+func testItemNotFound(t *testing.T, repo mongo.MenuItemRepositoryI) {
+	ctx := context.Background()
+	mustCreateItem(t, repo, fixtureItem("i1", "m1", "s1", 1))
+
+	_, err := repo.GetMenuItemByID(ctx, "m1", "missing")
+	expectError(t, err, apperr.ErrNotFound, "get unknown item")
+	_, err = repo.GetMenuItemByID(ctx, "m2", "i1")
+	expectError(t, err, apperr.ErrNotFound, "get item of another menu")
+
+	update := fixtureItem("i1", "m1", "s1", 1)
+	update.Title = "Changed"
+	update.Price.Amount = 1
+	expectError(t, repo.UpdateMenuItem(ctx, "m1", "missing", update), apperr.ErrNotFound, "update unknown item")
+	expectError(t, repo.UpdateMenuItem(ctx, "m2", "i1", update), apperr.ErrNotFound, "update item of another menu")
+	expectError(t, repo.DeleteMenuItem(ctx, "m1", "missing"), apperr.ErrNotFound, "delete unknown item")
+	expectError(t, repo.DeleteMenuItem(ctx, "m2", "i1"), apperr.ErrNotFound, "delete item of another menu")
+
+	// the failed writes changed nothing
+	expectSameJSON(t, mustGetItem(t, repo, "m1", "i1"), fixtureItem("i1", "m1", "s1", 1))
+}
+
+func testItemUpdate(t *testing.T, repo mongo.MenuItemRepositoryI) {
+	ctx := context.Background()
+	item := fixtureItem("i1", "m1", "s1", 1)
+	item.Description = "Two eggs"
+	item.Ingredients = []string{"eggs"}
+	item.Allergens = []string{"eggs"}
+	mustCreateItem(t, repo, item)
+
+	// empty strings and nil lists leave a field unchanged, while price,
+	// availability and image are always written
+	before := time.Now().Truncate(time.Millisecond)
+	update := &models.MenuItem{
+		Title:       "Eggs Royale",
+		DietaryTags: []string{"pescatarian"},
+		Price:       models.Money{Amount: 1200, Currency: "EUR"},
+		Image:       fixtureImage("items/i1"),
+		// placement and ownership are not editable through an update
+		SectionID:  "s2",
+		Position:   7,
+		BusinessID: "b2",
+	}
+	if err := repo.UpdateMenuItem(ctx, "m1", "i1", update); err != nil {
+		t.Fatalf("updating menu item: %v", err)
+	}
+
+	got := mustGetItem(t, repo, "m1", "i1")
+	if got.Title != "Eggs Royale" || !slices.Equal(got.DietaryTags, []string{"pescatarian"}) {
+		t.Errorf("written fields not updated: %+v", got)
+	}
+	if got.Description != "Two eggs" || !slices.Equal(got.Ingredients, []string{"eggs"}) || !slices.Equal(got.Allergens, []string{"eggs"}) {
+		t.Errorf("omitted fields changed: %+v", got)
+	}
+	if got.Price.Amount != 1200 || got.IsActive || got.Image == nil || got.Image.Key != "items/i1" {
+		t.Errorf("price, availability and image not written: %+v", got)
+	}
+	if got.SectionID != "s1" || got.Position != 1 || got.BusinessID != "b1" || !got.CreatedAt.Equal(base) {
+		t.Errorf("fields that are not editable changed: %+v", got)
+	}
+	if got.UpdatedAt.Before(before) {
+		t.Errorf("expected updated_at to be set by the write, got %v", got.UpdatedAt)
+	}
+}
+
+func testItemUpdateClearsImage(t *testing.T, repo mongo.MenuItemRepositoryI) {
+	item := fixtureItem("i1", "m1", "s1", 1)
+	item.Image = fixtureImage("items/i1")
+	mustCreateItem(t, repo, item)
+
+	update := mustGetItem(t, repo, "m1", "i1")
+	update.Image = nil
+	if err := repo.UpdateMenuItem(context.Background(), "m1", "i1", update); err != nil {
+		t.Fatalf("updating menu item: %v", err)
+	}
+	if got := mustGetItem(t, repo, "m1", "i1"); got.Image != nil {
+		t.Errorf("expected the image to be cleared, got %+v", got.Image)
+	}
+}
+
+func testItemDelete(t *testing.T, repo mongo.MenuItemRepositoryI) {
+	ctx := context.Background()
+	mustCreateItem(t, repo, fixtureItem("i1", "m1", "s1", 1))
+	mustCreateItem(t, repo, fixtureItem("i2", "m1", "s1", 2))
+
+	if err := repo.DeleteMenuItem(ctx, "m1", "i1"); err != nil {
+		t.Fatalf("deleting menu item: %v", err)
+	}
+	_, err := repo.GetMenuItemByID(ctx, "m1", "i1")
+	expectError(t, err, apperr.ErrNotFound, "get deleted item")
+	expectError(t, repo.DeleteMenuItem(ctx, "m1", "i1"), apperr.ErrNotFound, "delete deleted item")
+
+	if places := itemPlaces(mustListItems(t, repo, "m1")); places != "i2@s1/2" {
+		t.Errorf("expected only i2 to be left, got %q", places)
+	}
+}
+
+func testItemListScopingAndOrder(t *testing.T, repo mongo.MenuItemRepositoryI) {
+	mustCreateItem(t, repo, fixtureItem("i1", "m1", "s2", 1))
+	mustCreateItem(t, repo, fixtureItem("i2", "m1", "s1", 2))
+	mustCreateItem(t, repo, fixtureItem("i3", "m1", "s1", 1))
+	mustCreateItem(t, repo, fixtureItem("i4", "m2", "s1", 0))
+
+	// items are ordered by section, then position
+	if places := itemPlaces(mustListItems(t, repo, "m1")); places != "i3@s1/1 i2@s1/2 i1@s2/1" {
+		t.Errorf("unexpected items of m1: %q", places)
+	}
+	if places := itemPlaces(mustListItems(t, repo, "m2")); places != "i4@s1/0" {
+		t.Errorf("unexpected items of m2: %q", places)
+	}
+	if items := mustListItems(t, repo, "missing"); len(items) != 0 {
+		t.Errorf("expected no items for an unknown menu, got %q", itemPlaces(items))
+	}
+}
+
+func testItemReorder(t *testing.T, repo mongo.MenuItemRepositoryI) {
+	ctx := context.Background()
+	mustCreateItem(t, repo, fixtureItem("i1", "m1", "s1", 1))
+	mustCreateItem(t, repo, fixtureItem("i2", "m1", "s1", 2))
+	mustCreateItem(t, repo, fixtureItem("i3", "m2", "s1", 1))
+
+	before := time.Now().Truncate(time.Millisecond)
+	err := repo.ReorderMenuItems(ctx, "m1", []models.ItemPlacement{
+		{ItemID: "i1", SectionID: "s2", Position: 1},
+		{ItemID: "i2", SectionID: "s1", Position: 1},
+	})
+	if err != nil {
+		t.Fatalf("reordering items: %v", err)
+	}
+	if places := itemPlaces(mustListItems(t, repo, "m1")); places != "i2@s1/1 i1@s2/1" {
+		t.Errorf("unexpected order after reorder: %q", places)
+	}
+	if got := mustGetItem(t, repo, "m1", "i1"); got.UpdatedAt.Before(before) {
+		t.Errorf("expected updated_at to be set by the reorder, got %v", got.UpdatedAt)
+	}
+
+	// an item of another menu is not moved, and the reorder reports it
+	err = repo.ReorderMenuItems(ctx, "m1", []models.ItemPlacement{
+		{ItemID: "i2", SectionID: "s1", Position: 2},
+		{ItemID: "i3", SectionID: "s1", Position: 1},
+	})
+	expectError(t, err, apperr.ErrNotFound, "reorder with an item of another menu")
+	if got := mustGetItem(t, repo, "m2", "i3"); got.Position != 1 {
+		t.Errorf("item of another menu moved: %+v", got)
+	}
+
+	if err := repo.ReorderMenuItems(ctx, "m1", nil); err != nil {
+		t.Errorf("empty reorder: %v", err)
+	}
+}
+
+func testItemDeleteByMenu(t *testing.T, repo mongo.MenuItemRepositoryI) {
+	ctx := context.Background()
+	mustCreateItem(t, repo, fixtureItem("i1", "m1", "s1", 1))
+	mustCreateItem(t, repo, fixtureItem("i2", "m1", "s2", 1))
+	mustCreateItem(t, repo, fixtureItem("i3", "m2", "s1", 1))
+
+	if err := repo.DeleteMenuItemsByMenu(ctx, "m1"); err != nil {
+		t.Fatalf("deleting items of menu: %v", err)
+	}
+	if items := mustListItems(t, repo, "m1"); len(items) != 0 {
+		t.Errorf("expected the items of m1 to be deleted, got %q", itemPlaces(items))
+	}
+	if places := itemPlaces(mustListItems(t, repo, "m2")); places != "i3@s1/1" {
+		t.Errorf("expected the items of m2 to be kept, got %q", places)
+	}
+
+	// a menu without items is not an error, so a purge can be retried
+	if err := repo.DeleteMenuItemsByMenu(ctx, "m1"); err != nil {
+		t.Errorf("deleting items of an empty menu: %v", err)
+	}
+}
+
+func testItemDoesNotShareMemory(t *testing.T, repo mongo.MenuItemRepositoryI) {
+	item := fixtureItem("i1", "m1", "s1", 1)
+	item.Allergens = []string{"eggs"}
+	mustCreateItem(t, repo, item)
+	item.Allergens[0] = "changed after create"
+
+	got := mustGetItem(t, repo, "m1", "i1")
+	if got.Allergens[0] != "eggs" {
+		t.Errorf("stored item changed with the created value: %q", got.Allergens)
+	}
+	got.Allergens[0] = "changed after get"
+
+	if again := mustGetItem(t, repo, "m1", "i1"); again.Allergens[0] != "eggs" {
+		t.Errorf("stored item changed with a returned value: %q", again.Allergens)
+	}
+}
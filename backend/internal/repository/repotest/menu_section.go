@@ -0,0 +1,267 @@
+package repotest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// TestMenuSectionRepository runs the mongo.MenuSectionRepositoryI
+// conformance suite. newRepo must return an empty repository; it is called
+// once per subtest.
+func TestMenuSectionRepository(t *testing.T, newRepo func(t *testing.T) mongo.MenuSectionRepositoryI) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo mongo.MenuSectionRepositoryI)
+	}{
+		{"CreateAndGet", testSectionCreateAndGet},
+		{"CreateValidates", testSectionCreateValidates},
+		{"CreateRejectsDuplicates", testSectionCreateRejectsDuplicates},
+		{"NotFound", testSectionNotFound},
+		{"Update", testSectionUpdate},
+		{"Delete", testSectionDelete},
+		{"ListScopingAndOrder", testSectionListScopingAndOrder},
+		{"Reorder", testSectionReorder},
+		{"DeleteByMenu", testSectionDeleteByMenu},
+		{"DoesNotShareMemory", testSectionDoesNotShareMemory},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func fixtureSection(id, menuID string, position int) *models.MenuSection {
+	return &models.MenuSection{
+		SectionID:  id,
+		MenuID:     menuID,
+		BusinessID: "b1",
+		Name:       "Section " + id,
+		Position:   position,
+		CreatedAt:  base,
+		UpdatedAt:  base,
+	}
+}
+
+func mustCreateSection(t *testing.T, repo mongo.MenuSectionRepositoryI, section *models.MenuSection) {
+	t.Helper()
+	if err := repo.CreateMenuSection(context.Background(), section); err != nil {
+		t.Fatalf("creating menu section %s: %v", section.SectionID, err)
+	}
+}
+
+func mustGetSection(t *testing.T, repo mongo.MenuSectionRepositoryI, menuID, sectionID string) *models.MenuSection {
+	t.Helper()
+	section, err := repo.GetMenuSectionByID(context.Background(), menuID, sectionID)
+	if err != nil {
+		t.Fatalf("getting menu section %s: %v", sectionID, err)
+	}
+	return section
+}
+
+func mustListSections(t *testing.T, repo mongo.MenuSectionRepositoryI, menuID string) []models.MenuSection {
+	t.Helper()
+	sections, err := repo.ListMenuSectionsByMenu(context.Background(), menuID)
+	if err != nil {
+		t.Fatalf("listing sections of menu %s: %v", menuID, err)
+	}
+	return sections
+}
+
+func sectionIDs(sections []models.MenuSection) string {
+	ids := make([]string, len(sections))
+	for i, s := range sections {
+		ids[i] = s.SectionID
+	}
+	return strings.Join(ids, " ")
+}
+
+func testSectionCreateAndGet(t *testing.T, repo mongo.MenuSectionRepositoryI) {
+	section := fixtureSection("s1", "m1", 2)
+	mustCreateSection(t, repo, section)
+
+	expectSameJSON(t, mustGetSection(t, repo, "m1", "s1"), section)
+}
+
+func testSectionCreateValidates(t *testing.T, repo mongo.MenuSectionRepositoryI) {
+	ctx := context.Background()
+	if err := repo.CreateMenuSection(ctx, nil); err == nil {
+		t.Error("expected an error for a nil section")
+	}
+	for field, section := range map[string]*models.MenuSection{
+		"section_id": {MenuID: "m1", BusinessID: "b1", Name: "Mains"},
+		"menu_id":    {SectionID: "s1", BusinessID: "b1", Name: "Mains"},
+		"name":       {SectionID: "s1", MenuID: "m1", BusinessID: "b1"},
+	} {
+		expectError(t, repo.CreateMenuSection(ctx, section), apperr.ErrValidation, "missing "+field)
+	}
+
+	_, err := repo.GetMenuSectionByID(ctx, "", "s1")
+	expectError(t, err, apperr.ErrValidation, "get without menu_id")
+	_, err = repo.ListMenuSectionsByMenu(ctx, "")
+	expectError(t, err, apperr.ErrValidation, "list without menu_id")
+	expectError(t, repo.DeleteMenuSectionsByMenu(ctx, ""), apperr.ErrValidation, "delete by menu without menu_id")
+}
+
+func testSectionCreateRejectsDuplicates(t *testing.T, repo mongo.MenuSectionRepositoryI) {
+	mustCreateSection(t, repo, fixtureSection("s1", "m1", 1))
+
+	expectError(t, repo.CreateMenuSection(context.Background(), fixtureSection("s1", "m1", 2)), apperr.ErrConflict, "duplicate ID")
+	if got := mustGetSection(t, repo, "m1", "s1"); got.Position != 1 {
+		t.Errorf("expected the first section to be kept, got position %d", got.Position)
+	}
+}
+
+func testSectionNotFound(t *testing.T, repo mongo.MenuSectionRepositoryI) {
+	ctx := context.Background()
+	mustCreateSection(t, repo, fixtureSection("s1", "m1", 1))
+
+	_, err := repo.GetMenuSectionByID(ctx, "m1", "missing")
+	expectError(t, err, apperr.ErrNotFound, "get unknown section")
+	_, err = repo.GetMenuSectionByID(ctx, "m2", "s1")
+	expectError(t, err, apperr.ErrNotFound, "get section of another menu")
+
+	update := &models.MenuSection{Name: "Changed"}
+	expectError(t, repo.UpdateMenuSection(ctx, "m1", "missing", update), apperr.ErrNotFound, "update unknown section")
+	expectError(t, repo.UpdateMenuSection(ctx, "m2", "s1", update), apperr.ErrNotFound, "update section of another menu")
+	expectError(t, repo.DeleteMenuSection(ctx, "m1", "missing"), apperr.ErrNotFound, "delete unknown section")
+	expectError(t, repo.DeleteMenuSection(ctx, "m2", "s1"), apperr.ErrNotFound, "delete section of another menu")
+
+	// the failed writes changed nothing
+	expectSameJSON(t, mustGetSection(t, repo, "m1", "s1"), fixtureSection("s1", "m1", 1))
+}
+
+func testSectionUpdate(t *testing.T, repo mongo.MenuSectionRepositoryI) {
+	ctx := context.Background()
+	mustCreateSection(t, repo, fixtureSection("s1", "m1", 1))
+
+	before := time.Now().Truncate(time.Millisecond)
+	// only the name is editable; position changes through a reorder
+	if err := repo.UpdateMenuSection(ctx, "m1", "s1", &models.MenuSection{Name: "Mains", Position: 5}); err != nil {
+		t.Fatalf("updating menu section: %v", err)
+	}
+	got := mustGetSection(t, repo, "m1", "s1")
+	if got.Name != "Mains" || got.Position != 1 || !got.CreatedAt.Equal(base) {
+		t.Errorf("unexpected updated section: %+v", got)
+	}
+	if got.UpdatedAt.Before(before) {
+		t.Errorf("expected updated_at to be set by the write, got %v", got.UpdatedAt)
+	}
+
+	// an empty name leaves the name unchanged
+	if err := repo.UpdateMenuSection(ctx, "m1", "s1", &models.MenuSection{}); err != nil {
+		t.Fatalf("updating menu section: %v", err)
+	}
+	if got := mustGetSection(t, repo, "m1", "s1"); got.Name != "Mains" {
+		t.Errorf("expected the name to be kept, got %q", got.Name)
+	}
+}
+
+func testSectionDelete(t *testing.T, repo mongo.MenuSectionRepositoryI) {
+	ctx := context.Background()
+	mustCreateSection(t, repo, fixtureSection("s1", "m1", 1))
+	mustCreateSection(t, repo, fixtureSection("s2", "m1", 2))
+
+	if err := repo.DeleteMenuSection(ctx, "m1", "s1"); err != nil {
+		t.Fatalf("deleting menu section: %v", err)
+	}
+	_, err := repo.GetMenuSectionByID(ctx, "m1", "s1")
+	expectError(t, err, apperr.ErrNotFound, "get deleted section")
+	expectError(t, repo.DeleteMenuSection(ctx, "m1", "s1"), apperr.ErrNotFound, "delete deleted section")
+
+	if ids := sectionIDs(mustListSections(t, repo, "m1")); ids != "s2" {
+		t.Errorf("expected only s2 to be left, got %q", ids)
+	}
+}
+
+func testSectionListScopingAndOrder(t *testing.T, repo mongo.MenuSectionRepositoryI) {
+	mustCreateSection(t, repo, fixtureSection("s1", "m1", 3))
+	mustCreateSection(t, repo, fixtureSection("s2", "m1", 1))
+	mustCreateSection(t, repo, fixtureSection("s3", "m1", 2))
+	mustCreateSection(t, repo, fixtureSection("s4", "m2", 0))
+
+	if ids := sectionIDs(mustListSections(t, repo, "m1")); ids != "s2 s3 s1" {
+		t.Errorf("expected the sections of m1 by position, got %q", ids)
+	}
+	if ids := sectionIDs(mustListSections(t, repo, "m2")); ids != "s4" {
+		t.Errorf("unexpected sections of m2: %q", ids)
+	}
+	if sections := mustListSections(t, repo, "missing"); len(sections) != 0 {
+		t.Errorf("expected no sections for an unknown menu, got %q", sectionIDs(sections))
+	}
+}
+
+func testSectionReorder(t *testing.T, repo mongo.MenuSectionRepositoryI) {
+	ctx := context.Background()
+	mustCreateSection(t, repo, fixtureSection("s1", "m1", 0))
+	mustCreateSection(t, repo, fixtureSection("s2", "m1", 1))
+	mustCreateSection(t, repo, fixtureSection("s3", "m1", 2))
+	mustCreateSection(t, repo, fixtureSection("s4", "m2", 0))
+
+	before := time.Now().Truncate(time.Millisecond)
+	if err := repo.ReorderMenuSections(ctx, "m1", []string{"s3", "s1", "s2"}); err != nil {
+		t.Fatalf("reordering sections: %v", err)
+	}
+	sections := mustListSections(t, repo, "m1")
+	if ids := sectionIDs(sections); ids != "s3 s1 s2" {
+		t.Errorf("unexpected order after reorder: %q", ids)
+	}
+	for i, s := range sections {
+		if s.Position != i || s.UpdatedAt.Before(before) {
+			t.Errorf("expected %s at position %d updated by the reorder, got %d at %v", s.SectionID, i, s.Position, s.UpdatedAt)
+		}
+	}
+
+	// a section of another menu is not moved, and the reorder reports it
+	expectError(t, repo.ReorderMenuSections(ctx, "m1", []string{"s4", "s1"}), apperr.ErrNotFound, "reorder with a section of another menu")
+	if got := mustGetSection(t, repo, "m2", "s4"); got.Position != 0 {
+		t.Errorf("section of another menu moved: %+v", got)
+	}
+
+	if err := repo.ReorderMenuSections(ctx, "m1", nil); err != nil {
+		t.Errorf("empty reorder: %v", err)
+	}
+}
+
+func testSectionDeleteByMenu(t *testing.T, repo mongo.MenuSectionRepositoryI) {
+	ctx := context.Background()
+	mustCreateSection(t, repo, fixtureSection("s1", "m1", 0))
+	mustCreateSection(t, repo, fixtureSection("s2", "m1", 1))
+	mustCreateSection(t, repo, fixtureSection("s3", "m2", 0))
+
+	if err := repo.DeleteMenuSectionsByMenu(ctx, "m1"); err != nil {
+		t.Fatalf("deleting sections of menu: %v", err)
+	}
+	if sections := mustListSections(t, repo, "m1"); len(sections) != 0 {
+		t.Errorf("expected the sections of m1 to be deleted, got %q", sectionIDs(sections))
+	}
+	if ids := sectionIDs(mustListSections(t, repo, "m2")); ids != "s3" {
+		t.Errorf("expected the sections of m2 to be kept, got %q", ids)
+	}
+
+	if err := repo.DeleteMenuSectionsByMenu(ctx, "m1"); err != nil {
+		t.Errorf("deleting sections of an empty menu: %v", err)
+	}
+}
+
+func testSectionDoesNotShareMemory(t *testing.T, repo mongo.MenuSectionRepositoryI) {
+	section := fixtureSection("s1", "m1", 0)
+	mustCreateSection(t, repo, section)
+	section.Name = "changed after create"
+
+	got := mustGetSection(t, repo, "m1", "s1")
+	if got.Name != "Section s1" {
+		t.Errorf("stored section changed with the created value: %q", got.Name)
+	}
+	got.Name = "changed after get"
+
+	if again := mustGetSection(t, repo, "m1", "s1"); again.Name != "Section s1" {
+		t.Errorf("stored section changed with a returned value: %q", again.Name)
+	}
+}
@@ -0,0 +1,220 @@
+package repotest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// TestMenuVersionRepository runs the mongo.MenuVersionRepositoryI
+// conformance suite. newRepo must return an empty repository; it is called
+// once per subtest.
+func TestMenuVersionRepository(t *testing.T, newRepo func(t *testing.T) mongo.MenuVersionRepositoryI) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo mongo.MenuVersionRepositoryI)
+	}{
+		{"CreateAndGet", testVersionCreateAndGet},
+		{"CreateValidates", testVersionCreateValidates},
+		{"CreateRejectsDuplicates", testVersionCreateRejectsDuplicates},
+		{"NotFound", testVersionNotFound},
+		{"ListScopingAndOrder", testVersionListScopingAndOrder},
+		{"DeleteByMenu", testVersionDeleteByMenu},
+		{"DoesNotShareMemory", testVersionDoesNotShareMemory},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+// fixtureVersion returns a snapshot of menuID with one section and one item.
+func fixtureVersion(menuID string, number int) *models.MenuVersion {
+	section := fixtureSection("s1", menuID, 0)
+	item := fixtureItem("i1", menuID, "s1", 0)
+	return &models.MenuVersion{
+		VersionID:    fmt.Sprintf("%s-v%d", menuID, number),
+		MenuID:       menuID,
+		BusinessID:   "b1",
+		Number:       number,
+		Name:         fmt.Sprintf("Version %d", number),
+		Sections:     []models.MenuSection{*section},
+		Items:        []models.MenuItem{*item},
+		SectionCount: 1,
+		ItemCount:    1,
+		PublishedAt:  base.Add(time.Duration(number) * time.Hour),
+		PublishedBy:  "u1",
+	}
+}
+
+func mustCreateVersion(t *testing.T, repo mongo.MenuVersionRepositoryI, version *models.MenuVersion) {
+	t.Helper()
+	if err := repo.CreateMenuVersion(context.Background(), version); err != nil {
+		t.Fatalf("creating menu version %s: %v", version.VersionID, err)
+	}
+}
+
+func mustGetVersion(t *testing.T, repo mongo.MenuVersionRepositoryI, menuID string, number int) *models.MenuVersion {
+	t.Helper()
+	version, err := repo.GetMenuVersion(context.Background(), menuID, number)
+	if err != nil {
+		t.Fatalf("getting version %d of menu %s: %v", number, menuID, err)
+	}
+	return version
+}
+
+func mustListVersions(t *testing.T, repo mongo.MenuVersionRepositoryI, menuID string) []models.MenuVersion {
+	t.Helper()
+	versions, err := repo.ListMenuVersions(context.Background(), menuID)
+	if err != nil {
+		t.Fatalf("listing versions of menu %s: %v", menuID, err)
+	}
+	return versions
+}
+
+func versionIDs(versions []models.MenuVersion) string {
+	ids := make([]string, len(versions))
+	for i, v := range versions {
+		ids[i] = v.VersionID
+	}
+	return strings.Join(ids, " ")
+}
+
+func testVersionCreateAndGet(t *testing.T, repo mongo.MenuVersionRepositoryI) {
+	version := fixtureVersion("m1", 2)
+	version.Description = "Summer prices"
+	version.RestoredFrom = 1
+	mustCreateVersion(t, repo, version)
+
+	expectSameJSON(t, mustGetVersion(t, repo, "m1", 2), version)
+}
+
+func testVersionCreateValidates(t *testing.T, repo mongo.MenuVersionRepositoryI) {
+	ctx := context.Background()
+	if err := repo.CreateMenuVersion(ctx, nil); err == nil {
+		t.Error("expected an error for a nil version")
+	}
+	for field, version := range map[string]*models.MenuVersion{
+		"version_id": {MenuID: "m1", BusinessID: "b1", Number: 1},
+		"menu_id":    {VersionID: "v1", BusinessID: "b1", Number: 1},
+		"number":     {VersionID: "v1", MenuID: "m1", BusinessID: "b1"},
+	} {
+		expectError(t, repo.CreateMenuVersion(ctx, version), apperr.ErrValidation, "missing "+field)
+	}
+
+	_, err := repo.GetMenuVersion(ctx, "", 1)
+	expectError(t, err, apperr.ErrValidation, "get without menu_id")
+	_, err = repo.ListMenuVersions(ctx, "")
+	expectError(t, err, apperr.ErrValidation, "list without menu_id")
+	expectError(t, repo.DeleteMenuVersionsByMenu(ctx, ""), apperr.ErrValidation, "delete by menu without menu_id")
+}
+
+func testVersionCreateRejectsDuplicates(t *testing.T, repo mongo.MenuVersionRepositoryI) {
+	ctx := context.Background()
+	mustCreateVersion(t, repo, fixtureVersion("m1", 1))
+
+	// two publishes that raced for the same number
+	sameNumber := fixtureVersion("m1", 1)
+	sameNumber.VersionID = "other"
+	expectError(t, repo.CreateMenuVersion(ctx, sameNumber), mongo.ErrMenuVersionExists, "duplicate number")
+
+	sameID := fixtureVersion("m1", 2)
+	sameID.VersionID = "m1-v1"
+	expectError(t, repo.CreateMenuVersion(ctx, sameID), mongo.ErrMenuVersionExists, "duplicate ID")
+
+	// numbers are per menu
+	mustCreateVersion(t, repo, fixtureVersion("m2", 1))
+
+	if ids := versionIDs(mustListVersions(t, repo, "m1")); ids != "m1-v1" {
+		t.Errorf("expected only the first version of m1 to be stored, got %q", ids)
+	}
+}
+
+func testVersionNotFound(t *testing.T, repo mongo.MenuVersionRepositoryI) {
+	ctx := context.Background()
+	mustCreateVersion(t, repo, fixtureVersion("m1", 1))
+
+	_, err := repo.GetMenuVersion(ctx, "m1", 2)
+	expectError(t, err, apperr.ErrNotFound, "get unknown number")
+	_, err = repo.GetMenuVersion(ctx, "m2", 1)
+	expectError(t, err, apperr.ErrNotFound, "get version of another menu")
+}
+
+func testVersionListScopingAndOrder(t *testing.T, repo mongo.MenuVersionRepositoryI) {
+	mustCreateVersion(t, repo, fixtureVersion("m1", 2))
+	mustCreateVersion(t, repo, fixtureVersion("m1", 1))
+	mustCreateVersion(t, repo, fixtureVersion("m1", 3))
+	mustCreateVersion(t, repo, fixtureVersion("m2", 1))
+
+	versions := mustListVersions(t, repo, "m1")
+	if ids := versionIDs(versions); ids != "m1-v3 m1-v2 m1-v1" {
+		t.Errorf("expected the versions of m1 newest first, got %q", ids)
+	}
+	// listings leave out the snapshot but keep its counts
+	for _, v := range versions {
+		if len(v.Sections) != 0 || len(v.Items) != 0 {
+			t.Errorf("version %s listed with its snapshot", v.VersionID)
+		}
+		if v.SectionCount != 1 || v.ItemCount != 1 || v.PublishedBy != "u1" {
+			t.Errorf("version %s listed without its summary: %+v", v.VersionID, v)
+		}
+	}
+
+	if ids := versionIDs(mustListVersions(t, repo, "m2")); ids != "m2-v1" {
+		t.Errorf("unexpected versions of m2: %q", ids)
+	}
+	if versions := mustListVersions(t, repo, "missing"); len(versions) != 0 {
+		t.Errorf("expected no versions for an unknown menu, got %q", versionIDs(versions))
+	}
+
+	// a listing does not strip the stored snapshot
+	if got := mustGetVersion(t, repo, "m1", 3); len(got.Sections) != 1 || len(got.Items) != 1 {
+		t.Errorf("expected the snapshot to be kept, got %d sections and %d items", len(got.Sections), len(got.Items))
+	}
+}
+
+func testVersionDeleteByMenu(t *testing.T, repo mongo.MenuVersionRepositoryI) {
+	ctx := context.Background()
+	mustCreateVersion(t, repo, fixtureVersion("m1", 1))
+	mustCreateVersion(t, repo, fixtureVersion("m1", 2))
+	mustCreateVersion(t, repo, fixtureVersion("m2", 1))
+
+	if err := repo.DeleteMenuVersionsByMenu(ctx, "m1"); err != nil {
+		t.Fatalf("deleting versions of menu: %v", err)
+	}
+	if versions := mustListVersions(t, repo, "m1"); len(versions) != 0 {
+		t.Errorf("expected the versions of m1 to be deleted, got %q", versionIDs(versions))
+	}
+	if ids := versionIDs(mustListVersions(t, repo, "m2")); ids != "m2-v1" {
+		t.Errorf("expected the versions of m2 to be kept, got %q", ids)
+	}
+
+	if err := repo.DeleteMenuVersionsByMenu(ctx, "m1"); err != nil {
+		t.Errorf("deleting versions of an empty menu: %v", err)
+	}
+	// the numbers of a deleted menu are free again
+	mustCreateVersion(t, repo, fixtureVersion("m1", 1))
+}
+
+func testVersionDoesNotShareMemory(t *testing.T, repo mongo.MenuVersionRepositoryI) {
+	version := fixtureVersion("m1", 1)
+	mustCreateVersion(t, repo, version)
+	version.Items[0].Title = "changed after create"
+
+	got := mustGetVersion(t, repo, "m1", 1)
+	if got.Items[0].Title != "Item i1" {
+		t.Errorf("stored version changed with the created value: %q", got.Items[0].Title)
+	}
+	got.Items[0].Title = "changed after get"
+
+	if again := mustGetVersion(t, repo, "m1", 1); again.Items[0].Title != "Item i1" {
+		t.Errorf("stored version changed with a returned value: %q", again.Items[0].Title)
+	}
+}
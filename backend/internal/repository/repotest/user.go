@@ -0,0 +1,168 @@
+package repotest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// TestUserRepository runs the mongo.UserRepositoryI conformance suite.
+// newRepo must return an empty repository; it is called once per subtest.
+func TestUserRepository(t *testing.T, newRepo func(t *testing.T) mongo.UserRepositoryI) {
+	tests := []struct {
+		name string
+		fn   func(t *testing.T, repo mongo.UserRepositoryI)
+	}{
+		{"CreateAndGet", testUserCreateAndGet},
+		{"CreateValidates", testUserCreateValidates},
+		{"CreateRejectsDuplicates", testUserCreateRejectsDuplicates},
+		{"NotFound", testUserNotFound},
+		{"SetRole", testUserSetRole},
+		{"ConcurrentCreatesWithSameEmail", testUserConcurrentCreates},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.fn(t, newRepo(t))
+		})
+	}
+}
+
+func fixtureUser(id, email string) *models.User {
+	return &models.User{
+		UserID:       id,
+		Email:        email,
+		PasswordHash: "$2a$10$hash-of-" + id,
+		BusinessID:   "b1",
+		Role:         "owner",
+		CreatedAt:    base,
+		UpdatedAt:    base,
+	}
+}
+
+func mustCreateUser(t *testing.T, repo mongo.UserRepositoryI, user *models.User) {
+	t.Helper()
+	if err := repo.CreateUser(context.Background(), user); err != nil {
+		t.Fatalf("creating user %s: %v", user.UserID, err)
+	}
+}
+
+func mustGetUser(t *testing.T, repo mongo.UserRepositoryI, email string) *models.User {
+	t.Helper()
+	user, err := repo.GetUserByEmail(context.Background(), email)
+	if err != nil {
+		t.Fatalf("getting user %s: %v", email, err)
+	}
+	return user
+}
+
+func testUserCreateAndGet(t *testing.T, repo mongo.UserRepositoryI) {
+	user := fixtureUser("u1", "owner@example.com")
+	mustCreateUser(t, repo, user)
+
+	got := mustGetUser(t, repo, "owner@example.com")
+	expectSameJSON(t, got, user)
+	// the hash is not part of the JSON form but is what logins check
+	if got.PasswordHash != user.PasswordHash {
+		t.Errorf("expected password hash %q, got %q", user.PasswordHash, got.PasswordHash)
+	}
+}
+
+func testUserCreateValidates(t *testing.T, repo mongo.UserRepositoryI) {
+	ctx := context.Background()
+	if err := repo.CreateUser(ctx, nil); err == nil {
+		t.Error("expected an error for a nil user")
+	}
+	for field, user := range map[string]*models.User{
+		"user_id":       {Email: "owner@example.com", PasswordHash: "hash"},
+		"email":         {UserID: "u1", PasswordHash: "hash"},
+		"password_hash": {UserID: "u1", Email: "owner@example.com"},
+	} {
+		expectError(t, repo.CreateUser(ctx, user), apperr.ErrValidation, "missing "+field)
+	}
+
+	_, err := repo.GetUserByEmail(ctx, "")
+	expectError(t, err, apperr.ErrValidation, "get without email")
+}
+
+func testUserCreateRejectsDuplicates(t *testing.T, repo mongo.UserRepositoryI) {
+	ctx := context.Background()
+	mustCreateUser(t, repo, fixtureUser("u1", "owner@example.com"))
+
+	expectError(t, repo.CreateUser(ctx, fixtureUser("u2", "owner@example.com")), apperr.ErrConflict, "duplicate email")
+	expectError(t, repo.CreateUser(ctx, fixtureUser("u1", "other@example.com")), apperr.ErrConflict, "duplicate ID")
+
+	if got := mustGetUser(t, repo, "owner@example.com"); got.UserID != "u1" {
+		t.Errorf("expected the first user to be kept, got %s", got.UserID)
+	}
+	_, err := repo.GetUserByEmail(ctx, "other@example.com")
+	expectError(t, err, apperr.ErrNotFound, "get user rejected for its ID")
+}
+
+func testUserNotFound(t *testing.T, repo mongo.UserRepositoryI) {
+	mustCreateUser(t, repo, fixtureUser("u1", "owner@example.com"))
+
+	_, err := repo.GetUserByEmail(context.Background(), "missing@example.com")
+	expectError(t, err, apperr.ErrNotFound, "get unknown email")
+}
+
+func testUserSetRole(t *testing.T, repo mongo.UserRepositoryI) {
+	ctx := context.Background()
+	mustCreateUser(t, repo, fixtureUser("u1", "owner@example.com"))
+	mustCreateUser(t, repo, fixtureUser("u2", "other@example.com"))
+
+	later := base.Add(time.Hour)
+	if err := repo.SetUserRole(ctx, "owner@example.com", "admin", later); err != nil {
+		t.Fatalf("setting role: %v", err)
+	}
+	got := mustGetUser(t, repo, "owner@example.com")
+	if got.Role != "admin" || !got.UpdatedAt.Equal(later) {
+		t.Errorf("expected role admin updated at %v, got %s at %v", later, got.Role, got.UpdatedAt)
+	}
+	if other := mustGetUser(t, repo, "other@example.com"); other.Role != "owner" {
+		t.Errorf("expected other users to keep their role, got %s", other.Role)
+	}
+
+	expectError(t, repo.SetUserRole(ctx, "missing@example.com", "admin", later), apperr.ErrNotFound, "set role of unknown email")
+	expectError(t, repo.SetUserRole(ctx, "", "admin", later), apperr.ErrValidation, "set role without email")
+	expectError(t, repo.SetUserRole(ctx, "owner@example.com", "", later), apperr.ErrValidation, "set role without role")
+}
+
+func testUserConcurrentCreates(t *testing.T, repo mongo.UserRepositoryI) {
+	// two sign-ups with the same email may race; only one account may exist
+	const writers = 16
+	errs := make([]error, writers)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = repo.CreateUser(context.Background(), fixtureUser(fmt.Sprintf("u%d", i), "owner@example.com"))
+		}(i)
+	}
+	wg.Wait()
+
+	winner := -1
+	for i, err := range errs {
+		switch {
+		case err == nil && winner >= 0:
+			t.Errorf("writers %d and %d both created the account", winner, i)
+		case err == nil:
+			winner = i
+		case !errors.Is(err, apperr.ErrConflict):
+			t.Errorf("writer %d: expected a conflict, got %v", i, err)
+		}
+	}
+	if winner < 0 {
+		t.Fatal("no writer created the account")
+	}
+	if got := mustGetUser(t, repo, "owner@example.com"); got.UserID != fmt.Sprintf("u%d", winner) {
+		t.Errorf("expected the account of writer %d, got %s", winner, got.UserID)
+	}
+}
@@ -0,0 +1,110 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// AuditRepository is the SQL mongo.AuditRepositoryI. The before and after
+// values of changes are read back as the BSON types MongoDB returns.
+type AuditRepository struct {
+	db *DB
+}
+
+var _ mongo.AuditRepositoryI = (*AuditRepository)(nil)
+
+func NewAuditRepository(db *DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) CreateAuditEvent(ctx context.Context, event *models.AuditEvent) error {
+	if event == nil {
+		return errors.New("audit event cannot be nil")
+	}
+	if event.EventID == "" {
+		return apperr.Required("event_id")
+	}
+	if event.BusinessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	changes, err := encodeDoc(event.Changes)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err = r.db.exec(ctx, `INSERT INTO audit_events
+		(id, business_id, actor_id, request_id, action, entity, menu_id, changes, occurred_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		event.EventID, event.BusinessID, event.ActorID, event.RequestID, event.Action, event.Entity, event.MenuID,
+		changes, millis(event.OccurredAt))
+	return err
+}
+
+// ListAuditEvents returns up to opts.Limit events of a business, newest first,
+// starting after opts.After. A menu entity reference also matches the events
+// recorded for the menu's sections and items.
+func (r *AuditRepository) ListAuditEvents(ctx context.Context, businessID string, opts models.AuditListOptions) ([]models.AuditEvent, error) {
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	where := []string{"business_id = ?"}
+	args := []any{businessID}
+	if opts.Entity != "" {
+		if menuID, ok := strings.CutPrefix(opts.Entity, models.AuditEntityMenu+":"); ok {
+			where = append(where, "menu_id = ?")
+			args = append(args, menuID)
+		} else {
+			where = append(where, "entity = ?")
+			args = append(args, opts.Entity)
+		}
+	}
+	id := r.db.binary("id")
+	if c := opts.After; c != nil {
+		where = append(where, "(occurred_at < ? OR (occurred_at = ? AND "+id+" < ?))")
+		args = append(args, millis(c.OccurredAt), millis(c.OccurredAt), c.ID)
+	}
+	query := `SELECT id, business_id, actor_id, request_id, action, entity, menu_id, changes, occurred_at
+		FROM audit_events WHERE ` + strings.Join(where, " AND ") + ` ORDER BY occurred_at DESC, ` + id + ` DESC`
+	if opts.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, opts.Limit)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []models.AuditEvent
+	for rows.Next() {
+		var event models.AuditEvent
+		var changes string
+		var occurredAt int64
+		err := rows.Scan(&event.EventID, &event.BusinessID, &event.ActorID, &event.RequestID, &event.Action,
+			&event.Entity, &event.MenuID, &changes, &occurredAt)
+		if err != nil {
+			return nil, err
+		}
+		if err := decodeDoc(changes, &event.Changes); err != nil {
+			return nil, err
+		}
+		event.OccurredAt = fromMillis(occurredAt)
+		events = append(events, event)
+	}
+	return events, rows.Err()
+}
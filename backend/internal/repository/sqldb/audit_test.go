@@ -0,0 +1,64 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+)
+
+func TestAuditRepositoryListsNewestFirst(t *testing.T) {
+	ctx := context.Background()
+	repo := NewAuditRepository(testSQLite(t))
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	events := []models.AuditEvent{
+		{EventID: "e1", BusinessID: "biz-1", Entity: "menu:m1", MenuID: "m1", OccurredAt: at},
+		{EventID: "e2", BusinessID: "biz-1", Entity: "item:i1", MenuID: "m1", OccurredAt: at.Add(time.Minute)},
+		{EventID: "e3", BusinessID: "biz-1", Entity: "menu:m2", MenuID: "m2", OccurredAt: at.Add(time.Minute)},
+		{EventID: "e4", BusinessID: "biz-2", Entity: "menu:m3", MenuID: "m3", OccurredAt: at.Add(time.Hour)},
+	}
+	events[0].Changes = []models.FieldChange{{Field: "name", Before: "Lunch", After: "Brunch"}}
+	for i := range events {
+		if err := repo.CreateAuditEvent(ctx, &events[i]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	list := func(opts models.AuditListOptions) []models.AuditEvent {
+		t.Helper()
+		got, err := repo.ListAuditEvents(ctx, "biz-1", opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return got
+	}
+	ids := func(events []models.AuditEvent) string {
+		var ids []string
+		for _, e := range events {
+			ids = append(ids, e.EventID)
+		}
+		return fmt.Sprint(ids)
+	}
+
+	all := list(models.AuditListOptions{})
+	if got := ids(all); got != "[e3 e2 e1]" {
+		t.Errorf("expected [e3 e2 e1], got %s", got)
+	}
+	if c := all[2].Changes; len(c) != 1 || c[0].Field != "name" || c[0].Before != "Lunch" || c[0].After != "Brunch" {
+		t.Errorf("unexpected changes: %+v", c)
+	}
+	if got := ids(list(models.AuditListOptions{Entity: "menu:m1"})); got != "[e2 e1]" {
+		t.Errorf("expected a menu to include its items, got %s", got)
+	}
+	if got := ids(list(models.AuditListOptions{Entity: "item:i1"})); got != "[e2]" {
+		t.Errorf("expected [e2], got %s", got)
+	}
+
+	after := &models.AuditCursor{OccurredAt: at.Add(time.Minute), ID: "e3"}
+	if got := ids(list(models.AuditListOptions{Limit: 1, After: after})); got != "[e2]" {
+		t.Errorf("expected [e2] after the cursor, got %s", got)
+	}
+}
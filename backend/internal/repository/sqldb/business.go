@@ -0,0 +1,122 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+type BusinessRepository struct {
+	db *DB
+}
+
+var _ mongo.BusinessRepositoryI = (*BusinessRepository)(nil)
+
+func NewBusinessRepository(db *DB) *BusinessRepository {
+	return &BusinessRepository{db: db}
+}
+
+func (r *BusinessRepository) CreateBusiness(ctx context.Context, business *models.Business) error {
+	if business == nil {
+		return errors.New("business cannot be nil")
+	}
+	if business.BusinessID == "" {
+		return apperr.Required("business_id")
+	}
+	if business.Name == "" {
+		return apperr.Invalid("name", "business name is required")
+	}
+
+	address, err := encodeDoc(business.Address)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err = r.db.exec(ctx, `INSERT INTO businesses
+		(id, name, address, timezone, default_currency, locale, logo_url, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		business.BusinessID, business.Name, address, business.Timezone, business.DefaultCurrency, business.Locale,
+		business.LogoURL, millis(business.CreatedAt), millis(business.UpdatedAt))
+	if _, ok := duplicateKey(err); ok {
+		return apperr.Conflict("business with this ID already exists")
+	}
+	return err
+}
+
+func (r *BusinessRepository) GetBusinessByID(ctx context.Context, businessID string) (*models.Business, error) {
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var business models.Business
+	var address string
+	var createdAt, updatedAt int64
+	err := r.db.queryRow(ctx, `SELECT id, name, address, timezone, default_currency, locale, logo_url, created_at, updated_at
+		FROM businesses WHERE id = ?`, businessID).
+		Scan(&business.BusinessID, &business.Name, &address, &business.Timezone, &business.DefaultCurrency,
+			&business.Locale, &business.LogoURL, &createdAt, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("business")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeDoc(address, &business.Address); err != nil {
+		return nil, err
+	}
+	business.CreatedAt = fromMillis(createdAt)
+	business.UpdatedAt = fromMillis(updatedAt)
+
+	return &business, nil
+}
+
+// UpdateBusiness overwrites the editable fields of a business with the values
+// in updates. The service merges a request into the stored business first.
+func (r *BusinessRepository) UpdateBusiness(ctx context.Context, businessID string, updates *models.Business) error {
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+	if updates == nil {
+		return errors.New("updates cannot be nil")
+	}
+
+	address, err := encodeDoc(updates.Address)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.exec(ctx, `UPDATE businesses
+		SET name = ?, address = ?, timezone = ?, default_currency = ?, locale = ?, logo_url = ?, updated_at = ?
+		WHERE id = ?`,
+		updates.Name, address, updates.Timezone, updates.DefaultCurrency, updates.Locale, updates.LogoURL,
+		millis(now()), businessID)
+	return expectRows(result, err, "business")
+}
+
+// DeleteBusiness removes a business. It is only used to roll back a failed
+// registration.
+func (r *BusinessRepository) DeleteBusiness(ctx context.Context, businessID string) error {
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.exec(ctx, `DELETE FROM businesses WHERE id = ?`, businessID)
+	return expectRows(result, err, "business")
+}
@@ -0,0 +1,166 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/custard-technology/abakcus/backend/internal/repository/repotest"
+)
+
+// testSQLite returns a migrated SQLite database in a file that is removed
+// when t ends.
+func testSQLite(t *testing.T) *DB {
+	t.Helper()
+	db, err := Open(context.Background(), SQLite, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("opening SQLite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := Migrate(context.Background(), db); err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+	return db
+}
+
+// postgresDSN returns POSTGRES_TEST_DSN, a postgres:// URL, and skips t
+// when it is not set.
+func postgresDSN(t *testing.T) string {
+	t.Helper()
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("set POSTGRES_TEST_DSN to run against PostgreSQL")
+	}
+	return dsn
+}
+
+// testPostgres returns a migrated database in a new schema of the server
+// named by dsn, dropped when t ends.
+func testPostgres(t *testing.T, dsn string) *DB {
+	t.Helper()
+	ctx := context.Background()
+
+	admin, err := Open(ctx, Postgres, dsn)
+	if err != nil {
+		t.Fatalf("connecting to PostgreSQL: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("abakcus_test_%d", time.Now().UnixNano())
+	if _, err := admin.exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("creating schema: %v", err)
+	}
+	t.Cleanup(func() { admin.exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE") })
+
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	db, err := Open(ctx, Postgres, dsn+sep+"search_path="+schema)
+	if err != nil {
+		t.Fatalf("connecting to PostgreSQL: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if _, err := Migrate(ctx, db); err != nil {
+		t.Fatalf("migrating: %v", err)
+	}
+	return db
+}
+
+func TestMenuRepositoryConformance(t *testing.T) {
+	repotest.TestMenuRepository(t, func(t *testing.T) mongo.MenuRepositoryI {
+		return NewMenuRepository(testSQLite(t))
+	})
+}
+
+func TestMenuRepositoryConformancePostgres(t *testing.T) {
+	dsn := postgresDSN(t)
+	repotest.TestMenuRepository(t, func(t *testing.T) mongo.MenuRepositoryI {
+		return NewMenuRepository(testPostgres(t, dsn))
+	})
+}
+
+func TestMenuItemRepositoryConformance(t *testing.T) {
+	repotest.TestMenuItemRepository(t, func(t *testing.T) mongo.MenuItemRepositoryI {
+		return NewMenuItemRepository(testSQLite(t))
+	})
+}
+
+func TestMenuItemRepositoryConformancePostgres(t *testing.T) {
+	dsn := postgresDSN(t)
+	repotest.TestMenuItemRepository(t, func(t *testing.T) mongo.MenuItemRepositoryI {
+		return NewMenuItemRepository(testPostgres(t, dsn))
+	})
+}
+
+func TestMenuSectionRepositoryConformance(t *testing.T) {
+	repotest.TestMenuSectionRepository(t, func(t *testing.T) mongo.MenuSectionRepositoryI {
+		return NewMenuSectionRepository(testSQLite(t))
+	})
+}
+
+func TestMenuSectionRepositoryConformancePostgres(t *testing.T) {
+	dsn := postgresDSN(t)
+	repotest.TestMenuSectionRepository(t, func(t *testing.T) mongo.MenuSectionRepositoryI {
+		return NewMenuSectionRepository(testPostgres(t, dsn))
+	})
+}
+
+func TestMenuVersionRepositoryConformance(t *testing.T) {
+	repotest.TestMenuVersionRepository(t, func(t *testing.T) mongo.MenuVersionRepositoryI {
+		return NewMenuVersionRepository(testSQLite(t))
+	})
+}
+
+func TestMenuVersionRepositoryConformancePostgres(t *testing.T) {
+	dsn := postgresDSN(t)
+	repotest.TestMenuVersionRepository(t, func(t *testing.T) mongo.MenuVersionRepositoryI {
+		return NewMenuVersionRepository(testPostgres(t, dsn))
+	})
+}
+
+func TestBusinessRepositoryConformance(t *testing.T) {
+	repotest.TestBusinessRepository(t, func(t *testing.T) mongo.BusinessRepositoryI {
+		return NewBusinessRepository(testSQLite(t))
+	})
+}
+
+func TestBusinessRepositoryConformancePostgres(t *testing.T) {
+	dsn := postgresDSN(t)
+	repotest.TestBusinessRepository(t, func(t *testing.T) mongo.BusinessRepositoryI {
+		return NewBusinessRepository(testPostgres(t, dsn))
+	})
+}
+
+func TestUserRepositoryConformance(t *testing.T) {
+	repotest.TestUserRepository(t, func(t *testing.T) mongo.UserRepositoryI {
+		return NewUserRepository(testSQLite(t))
+	})
+}
+
+func TestUserRepositoryConformancePostgres(t *testing.T) {
+	dsn := postgresDSN(t)
+	repotest.TestUserRepository(t, func(t *testing.T) mongo.UserRepositoryI {
+		return NewUserRepository(testPostgres(t, dsn))
+	})
+}
+
+func TestAuditRepositoryConformance(t *testing.T) {
+	repotest.TestAuditRepository(t, func(t *testing.T) mongo.AuditRepositoryI {
+		return NewAuditRepository(testSQLite(t))
+	})
+}
+
+func TestAuditRepositoryConformancePostgres(t *testing.T) {
+	dsn := postgresDSN(t)
+	repotest.TestAuditRepository(t, func(t *testing.T) mongo.AuditRepositoryI {
+		return NewAuditRepository(testPostgres(t, dsn))
+	})
+}
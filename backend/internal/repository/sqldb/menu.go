@@ -0,0 +1,403 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// MenuRepository is the SQL mongo.MenuRepositoryI. Conditional writes put
+// the revision in the WHERE clause, so the check and the write are one
+// statement as in MongoDB.
+type MenuRepository struct {
+	db *DB
+}
+
+var _ mongo.MenuRepositoryI = (*MenuRepository)(nil)
+
+func NewMenuRepository(db *DB) *MenuRepository {
+	return &MenuRepository{db: db}
+}
+
+const menuColumns = `id, business_id, name, slug, description, is_active, schedule,
+	published_version, published_at, revision, created_at, updated_at, deleted_at`
+
+func scanMenu(row rowScanner) (*models.Menu, error) {
+	var menu models.Menu
+	var slug, schedule sql.NullString
+	var publishedAt, deletedAt sql.NullInt64
+	var createdAt, updatedAt int64
+	err := row.Scan(&menu.MenuID, &menu.BusinessID, &menu.Name, &slug, &menu.Description, &menu.IsActive, &schedule,
+		&menu.PublishedVersion, &publishedAt, &menu.Revision, &createdAt, &updatedAt, &deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	menu.Slug = slug.String
+	if menu.Schedule, err = decodeNullDoc[models.MenuSchedule](schedule); err != nil {
+		return nil, err
+	}
+	menu.PublishedAt = timePtr(publishedAt)
+	menu.CreatedAt = fromMillis(createdAt)
+	menu.UpdatedAt = fromMillis(updatedAt)
+	menu.DeletedAt = timePtr(deletedAt)
+	return &menu, nil
+}
+
+func scanMenus(rows *sql.Rows) ([]models.Menu, error) {
+	defer rows.Close()
+
+	var menus []models.Menu
+	for rows.Next() {
+		menu, err := scanMenu(rows)
+		if err != nil {
+			return nil, err
+		}
+		menus = append(menus, *menu)
+	}
+	return menus, rows.Err()
+}
+
+// menuWriteError translates a unique violation on insert or update.
+func menuWriteError(err error) error {
+	if key, ok := duplicateKey(err); ok {
+		if strings.Contains(key, "slug") {
+			return mongo.ErrMenuSlugTaken
+		}
+		return apperr.Conflict("menu with this ID already exists")
+	}
+	return err
+}
+
+func (r *MenuRepository) CreateMenu(ctx context.Context, menu *models.Menu) error {
+	if menu == nil {
+		return errors.New("menu cannot be nil")
+	}
+	if menu.MenuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if menu.Name == "" {
+		return apperr.Invalid("name", "menu name is required")
+	}
+	if menu.BusinessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	schedule, err := encodeNullDoc(menu.Schedule)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err = r.db.exec(ctx, `INSERT INTO menus (`+menuColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		menu.MenuID, menu.BusinessID, menu.Name, nullString(menu.Slug), menu.Description, menu.IsActive, schedule,
+		menu.PublishedVersion, nullMillis(menu.PublishedAt), menu.Revision,
+		millis(menu.CreatedAt), millis(menu.UpdatedAt), nullMillis(menu.DeletedAt))
+	return menuWriteError(err)
+}
+
+func (r *MenuRepository) GetMenuByID(ctx context.Context, menuID, businessID string) (*models.Menu, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	menu, err := scanMenu(r.db.queryRow(ctx,
+		`SELECT `+menuColumns+` FROM menus WHERE id = ? AND business_id = ? AND deleted_at IS NULL`, menuID, businessID))
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("menu")
+	}
+	return menu, err
+}
+
+// GetMenuBySlug returns the live menu with the given slug regardless of the
+// owning business.
+func (r *MenuRepository) GetMenuBySlug(ctx context.Context, slug string) (*models.Menu, error) {
+	if slug == "" {
+		return nil, apperr.Required("slug")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	menu, err := scanMenu(r.db.queryRow(ctx,
+		`SELECT `+menuColumns+` FROM menus WHERE slug = ? AND deleted_at IS NULL`, slug))
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("menu")
+	}
+	return menu, err
+}
+
+func (r *MenuRepository) UpdateMenu(ctx context.Context, menuID, businessID string, updates *models.Menu) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+	if updates == nil {
+		return errors.New("updates cannot be nil")
+	}
+
+	schedule, err := encodeNullDoc(updates.Schedule)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	// every editable field is written, so empty values clear fields
+	result, err := r.db.exec(ctx, `UPDATE menus
+		SET name = ?, slug = ?, description = ?, is_active = ?, schedule = ?, updated_at = ?, revision = ?
+		WHERE id = ? AND business_id = ? AND deleted_at IS NULL AND revision = ?`,
+		updates.Name, nullString(updates.Slug), updates.Description, updates.IsActive, schedule,
+		millis(now()), updates.Revision+1,
+		menuID, businessID, updates.Revision)
+	if err != nil {
+		return menuWriteError(err)
+	}
+
+	return r.checkConditionalWrite(ctx, result, menuID, businessID)
+}
+
+// checkConditionalWrite explains why a conditional write matched no live
+// menu: it either does not exist for businessID or has a different revision.
+func (r *MenuRepository) checkConditionalWrite(ctx context.Context, result sql.Result, menuID, businessID string) error {
+	n, err := result.RowsAffected()
+	if err != nil || n > 0 {
+		return err
+	}
+
+	var live int
+	err = r.db.queryRow(ctx, `SELECT COUNT(*) FROM menus WHERE id = ? AND business_id = ? AND deleted_at IS NULL`,
+		menuID, businessID).Scan(&live)
+	if err != nil {
+		return err
+	}
+	if live > 0 {
+		return mongo.ErrMenuRevisionConflict
+	}
+	return apperr.NotFound("menu")
+}
+
+func (r *MenuRepository) DeleteMenu(ctx context.Context, menuID, businessID string, revision int64) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	deletedAt := millis(now())
+	result, err := r.db.exec(ctx, `UPDATE menus SET deleted_at = ?, updated_at = ?, revision = revision + 1
+		WHERE id = ? AND business_id = ? AND deleted_at IS NULL AND revision = ?`,
+		deletedAt, deletedAt, menuID, businessID, revision)
+	if err != nil {
+		return err
+	}
+
+	return r.checkConditionalWrite(ctx, result, menuID, businessID)
+}
+
+// RestoreMenu clears the deleted_at tombstone. Restoring a menu that is not
+// deleted is a no-op: its revision and updated_at stay as they are.
+func (r *MenuRepository) RestoreMenu(ctx context.Context, menuID, businessID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.exec(ctx, `UPDATE menus SET deleted_at = NULL, updated_at = ?, revision = revision + 1
+		WHERE id = ? AND business_id = ? AND deleted_at IS NOT NULL`,
+		millis(now()), menuID, businessID)
+	if err != nil {
+		return err
+	}
+	// as in MongoDB, missing the filter on a live menu means there was
+	// nothing to restore
+	if err := r.checkConditionalWrite(ctx, result, menuID, businessID); !errors.Is(err, mongo.ErrMenuRevisionConflict) {
+		return err
+	}
+	return nil
+}
+
+// SetMenuPublishedVersion moves the published version and time forward,
+// never back, without touching updated_at. Like its MongoDB counterpart it
+// works on soft-deleted menus.
+func (r *MenuRepository) SetMenuPublishedVersion(ctx context.Context, menuID, businessID string, number int, publishedAt time.Time) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return apperr.Required("business_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	at := millis(publishedAt)
+	result, err := r.db.exec(ctx, `UPDATE menus SET
+		published_version = CASE WHEN published_version < ? THEN ? ELSE published_version END,
+		published_at = CASE WHEN published_at IS NULL OR published_at < ? THEN ? ELSE published_at END,
+		revision = revision + 1
+		WHERE id = ? AND business_id = ?`,
+		number, number, at, at, menuID, businessID)
+	return expectRows(result, err, "menu")
+}
+
+// GetDeletedMenu returns a soft-deleted menu regardless of the owning
+// business. Live menus are reported as not found.
+func (r *MenuRepository) GetDeletedMenu(ctx context.Context, menuID string) (*models.Menu, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	menu, err := scanMenu(r.db.queryRow(ctx,
+		`SELECT `+menuColumns+` FROM menus WHERE id = ? AND deleted_at IS NOT NULL`, menuID))
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("menu")
+	}
+	return menu, err
+}
+
+// PurgeMenu permanently removes a soft-deleted menu. Menus that are not
+// deleted are reported as not found so live data is never purged.
+func (r *MenuRepository) PurgeMenu(ctx context.Context, menuID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.exec(ctx, `DELETE FROM menus WHERE id = ? AND deleted_at IS NOT NULL`, menuID)
+	return expectRows(result, err, "menu")
+}
+
+// ListMenusByBusiness returns up to opts.Limit live menus of a business that
+// match the filters in opts, ordered by opts.SortBy and id and starting after
+// opts.After. A zero Limit returns every match.
+func (r *MenuRepository) ListMenusByBusiness(ctx context.Context, businessID string, opts models.MenuListOptions) ([]models.Menu, error) {
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	sortBy := opts.SortBy
+	if sortBy == "" {
+		sortBy = models.MenuSortCreatedAt
+	}
+	column := "created_at"
+	switch sortBy {
+	case models.MenuSortName:
+		column = r.db.binary("name")
+	case models.MenuSortUpdatedAt:
+		column = "updated_at"
+	}
+	id := r.db.binary("id")
+	op, direction := ">", "ASC"
+	if opts.SortDesc {
+		op, direction = "<", "DESC"
+	}
+
+	where := []string{"business_id = ?", "deleted_at IS NULL"}
+	args := []any{businessID}
+	if opts.IsActive != nil {
+		where = append(where, "is_active = ?")
+		args = append(args, *opts.IsActive)
+	}
+	for _, bound := range []struct {
+		column string
+		op     string
+		t      *time.Time
+	}{
+		{"created_at", ">=", opts.CreatedAfter},
+		{"created_at", "<", opts.CreatedBefore},
+		{"updated_at", ">=", opts.UpdatedAfter},
+		{"updated_at", "<", opts.UpdatedBefore},
+	} {
+		if bound.t != nil {
+			where = append(where, bound.column+" "+bound.op+" ?")
+			args = append(args, millis(*bound.t))
+		}
+	}
+	if c := opts.After; c != nil {
+		// (column > value) OR (column = value AND id > c.ID), flipped for
+		// descending order
+		var value any = millis(c.Time)
+		if sortBy == models.MenuSortName {
+			value = c.Name
+		}
+		where = append(where, "("+column+" "+op+" ? OR ("+column+" = ? AND "+id+" "+op+" ?))")
+		args = append(args, value, value, c.ID)
+	}
+
+	query := `SELECT ` + menuColumns + ` FROM menus WHERE ` + strings.Join(where, " AND ") +
+		` ORDER BY ` + column + ` ` + direction + `, ` + id + ` ` + direction
+	if opts.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, opts.Limit)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanMenus(rows)
+}
+
+// ListDeletedMenus returns menus of every business that were soft-deleted at
+// or before deletedBefore.
+func (r *MenuRepository) ListDeletedMenus(ctx context.Context, deletedBefore time.Time) ([]models.Menu, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.query(ctx, `SELECT `+menuColumns+` FROM menus WHERE deleted_at IS NOT NULL AND deleted_at <= ?`,
+		millis(deletedBefore))
+	if err != nil {
+		return nil, err
+	}
+	return scanMenus(rows)
+}
+
+// expectRows turns the result of a write that must change a row into
+// apperr.NotFound for entity when it changed none.
+func expectRows(result sql.Result, err error, entity string) error {
+	if err != nil {
+		return err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return apperr.NotFound(entity)
+	}
+	return nil
+}
@@ -0,0 +1,297 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+type MenuItemRepository struct {
+	db *DB
+}
+
+var _ mongo.MenuItemRepositoryI = (*MenuItemRepository)(nil)
+
+func NewMenuItemRepository(db *DB) *MenuItemRepository {
+	return &MenuItemRepository{db: db}
+}
+
+const menuItemColumns = `id, menu_id, business_id, section_id, position, title, description,
+	price_amount, price_currency, image_url, ingredients, is_active,
+	allergens, dietary_tags, modifier_groups, image, created_at, updated_at`
+
+func scanMenuItem(row rowScanner) (*models.MenuItem, error) {
+	var item models.MenuItem
+	var ingredients, allergens, dietaryTags, modifierGroups string
+	var image sql.NullString
+	var createdAt, updatedAt int64
+	err := row.Scan(&item.ItemID, &item.MenuID, &item.BusinessID, &item.SectionID, &item.Position, &item.Title, &item.Description,
+		&item.Price.Amount, &item.Price.Currency, &item.ImageURL, &ingredients, &item.IsActive,
+		&allergens, &dietaryTags, &modifierGroups, &image, &createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, field := range []struct {
+		data string
+		dst  *[]string
+	}{
+		{ingredients, &item.Ingredients},
+		{allergens, &item.Allergens},
+		{dietaryTags, &item.DietaryTags},
+	} {
+		if err := decodeDoc(field.data, field.dst); err != nil {
+			return nil, err
+		}
+	}
+	if err := decodeDoc(modifierGroups, &item.ModifierGroups); err != nil {
+		return nil, err
+	}
+	if item.Image, err = decodeNullDoc[models.ItemImage](image); err != nil {
+		return nil, err
+	}
+	item.CreatedAt = fromMillis(createdAt)
+	item.UpdatedAt = fromMillis(updatedAt)
+	return &item, nil
+}
+
+func (r *MenuItemRepository) CreateMenuItem(ctx context.Context, item *models.MenuItem) error {
+	if item == nil {
+		return errors.New("menu item cannot be nil")
+	}
+	if item.ItemID == "" {
+		return apperr.Required("item_id")
+	}
+	if item.MenuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if item.BusinessID == "" {
+		return apperr.Required("business_id")
+	}
+	if item.Title == "" {
+		return apperr.Invalid("title", "menu item title is required")
+	}
+
+	var docs [4]string
+	var err error
+	for i, list := range [][]string{item.Ingredients, item.Allergens, item.DietaryTags} {
+		if docs[i], err = encodeDoc(list); err != nil {
+			return err
+		}
+	}
+	if docs[3], err = encodeDoc(item.ModifierGroups); err != nil {
+		return err
+	}
+	image, err := encodeNullDoc(item.Image)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err = r.db.exec(ctx, `INSERT INTO menu_items (`+menuItemColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		item.ItemID, item.MenuID, item.BusinessID, item.SectionID, item.Position, item.Title, item.Description,
+		item.Price.Amount, item.Price.Currency, item.ImageURL, docs[0], item.IsActive,
+		docs[1], docs[2], docs[3], image, millis(item.CreatedAt), millis(item.UpdatedAt))
+	if _, ok := duplicateKey(err); ok {
+		return apperr.Conflict("menu item with this ID already exists")
+	}
+	return err
+}
+
+func (r *MenuItemRepository) GetMenuItemByID(ctx context.Context, menuID, itemID string) (*models.MenuItem, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+	if itemID == "" {
+		return nil, apperr.Required("item_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	item, err := scanMenuItem(r.db.queryRow(ctx,
+		`SELECT `+menuItemColumns+` FROM menu_items WHERE id = ? AND menu_id = ?`, itemID, menuID))
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("menu item")
+	}
+	return item, err
+}
+
+// UpdateMenuItem writes the fields that MongoDB would: empty strings and nil
+// lists leave columns unchanged, while price, is_active and image are always
+// written. The section and position are changed only by ReorderMenuItems.
+func (r *MenuItemRepository) UpdateMenuItem(ctx context.Context, menuID, itemID string, updates *models.MenuItem) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if itemID == "" {
+		return apperr.Required("item_id")
+	}
+	if updates == nil {
+		return errors.New("updates cannot be nil")
+	}
+
+	var set []string
+	var args []any
+	for _, field := range []struct {
+		column string
+		value  string
+	}{
+		{"title", updates.Title},
+		{"description", updates.Description},
+		{"image_url", updates.ImageURL},
+	} {
+		if field.value != "" {
+			set = append(set, field.column+" = ?")
+			args = append(args, field.value)
+		}
+	}
+	for _, field := range []struct {
+		column string
+		list   []string
+	}{
+		{"ingredients", updates.Ingredients},
+		{"allergens", updates.Allergens},
+		{"dietary_tags", updates.DietaryTags},
+	} {
+		if field.list != nil {
+			data, err := encodeDoc(field.list)
+			if err != nil {
+				return err
+			}
+			set = append(set, field.column+" = ?")
+			args = append(args, data)
+		}
+	}
+	if updates.ModifierGroups != nil {
+		data, err := encodeDoc(updates.ModifierGroups)
+		if err != nil {
+			return err
+		}
+		set = append(set, "modifier_groups = ?")
+		args = append(args, data)
+	}
+	image, err := encodeNullDoc(updates.Image)
+	if err != nil {
+		return err
+	}
+	set = append(set, "image = ?", "price_amount = ?", "price_currency = ?", "is_active = ?", "updated_at = ?")
+	args = append(args, image, updates.Price.Amount, updates.Price.Currency, updates.IsActive, millis(now()), itemID, menuID)
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.exec(ctx, `UPDATE menu_items SET `+strings.Join(set, ", ")+` WHERE id = ? AND menu_id = ?`, args...)
+	return expectRows(result, err, "menu item")
+}
+
+func (r *MenuItemRepository) DeleteMenuItem(ctx context.Context, menuID, itemID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if itemID == "" {
+		return apperr.Required("item_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.exec(ctx, `DELETE FROM menu_items WHERE id = ? AND menu_id = ?`, itemID, menuID)
+	return expectRows(result, err, "menu item")
+}
+
+// DeleteMenuItemsByMenu removes every item of a menu. It is used when a menu
+// is purged.
+func (r *MenuItemRepository) DeleteMenuItemsByMenu(ctx context.Context, menuID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.exec(ctx, `DELETE FROM menu_items WHERE menu_id = ?`, menuID)
+	return err
+}
+
+// ListMenuItemsByMenu returns the items of a menu sorted by section and position.
+func (r *MenuItemRepository) ListMenuItemsByMenu(ctx context.Context, menuID string) ([]models.MenuItem, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.query(ctx, `SELECT `+menuItemColumns+` FROM menu_items WHERE menu_id = ?
+		ORDER BY `+r.db.binary("section_id")+`, position, `+r.db.binary("id"), menuID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []models.MenuItem
+	for rows.Next() {
+		item, err := scanMenuItem(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *item)
+	}
+	return items, rows.Err()
+}
+
+// ReorderMenuItems moves items into their placed section and position in one
+// transaction. As with the unordered bulk write in MongoDB, the items that
+// exist are moved even when others are not found.
+func (r *MenuItemRepository) ReorderMenuItems(ctx context.Context, menuID string, placements []models.ItemPlacement) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if len(placements) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	updatedAt := millis(now())
+	var matched int64
+	err := r.db.inTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, r.db.rebind(
+			`UPDATE menu_items SET section_id = ?, position = ?, updated_at = ? WHERE id = ? AND menu_id = ?`))
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for _, p := range placements {
+			result, err := stmt.ExecContext(ctx, p.SectionID, p.Position, updatedAt, p.ItemID, menuID)
+			if err != nil {
+				return err
+			}
+			n, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			matched += n
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if matched != int64(len(placements)) {
+		return apperr.NotFound("menu item")
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+)
+
+func newItem(id, sectionID string, position int) *models.MenuItem {
+	created := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &models.MenuItem{
+		ItemID:      id,
+		MenuID:      "m1",
+		BusinessID:  "biz-1",
+		SectionID:   sectionID,
+		Position:    position,
+		Title:       "Item " + id,
+		Price:       models.Money{Amount: 450, Currency: "EUR"},
+		Ingredients: []string{},
+		IsActive:    true,
+		CreatedAt:   created,
+		UpdatedAt:   created,
+	}
+}
+
+func TestMenuItemRepositoryRoundTrips(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMenuItemRepository(testSQLite(t))
+
+	item := newItem("i1", "s1", 0)
+	item.Allergens = []string{"gluten", "milk"}
+	item.ModifierGroups = []models.ModifierGroup{{
+		GroupID: "g1", Name: "Size", MaxSelect: 1,
+		Options: []models.ModifierOption{{OptionID: "large", Name: "Large", PriceDelta: models.Money{Amount: 100, Currency: "EUR"}}},
+	}}
+	item.Image = &models.ItemImage{
+		URL: "https://cdn.example.com/i1.jpg", Key: "items/i1.jpg", ContentType: "image/jpeg", Width: 800, Height: 600,
+		Thumbnails: []models.ImageVariant{{URL: "https://cdn.example.com/i1-200.jpg", Key: "items/i1-200.jpg", Width: 200}},
+		UploadedAt: time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC),
+	}
+	if err := repo.CreateMenuItem(ctx, item); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := repo.CreateMenuItem(ctx, item); !errors.Is(err, apperr.ErrConflict) {
+		t.Errorf("expected conflict for duplicate ID, got %v", err)
+	}
+
+	got, err := repo.GetMenuItemByID(ctx, "m1", "i1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// storage keys are hidden from JSON but must survive storage
+	if !reflect.DeepEqual(got, item) {
+		t.Errorf("item changed in storage:\n got %+v\nwant %+v", got, item)
+	}
+	if got.Ingredients == nil || got.DietaryTags != nil {
+		t.Errorf("expected empty and nil lists to be kept apart, got %#v and %#v", got.Ingredients, got.DietaryTags)
+	}
+
+	if _, err := repo.GetMenuItemByID(ctx, "m2", "i1"); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("expected not found under another menu, got %v", err)
+	}
+}
+
+func TestMenuItemRepositoryUpdateWritesWhatMongoWould(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMenuItemRepository(testSQLite(t))
+
+	item := newItem("i1", "s1", 0)
+	item.Description = "Kept"
+	item.Allergens = []string{"milk"}
+	item.Image = &models.ItemImage{URL: "https://cdn.example.com/i1.jpg", Key: "items/i1.jpg"}
+	if err := repo.CreateMenuItem(ctx, item); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// empty strings and nil lists are left alone; price, is_active and
+	// image are always written
+	err := repo.UpdateMenuItem(ctx, "m1", "i1", &models.MenuItem{
+		Title:       "Renamed",
+		DietaryTags: []string{"vegan"},
+		Price:       models.Money{Amount: 500, Currency: "EUR"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got, err := repo.GetMenuItemByID(ctx, "m1", "i1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	switch {
+	case got.Title != "Renamed" || got.Description != "Kept":
+		t.Errorf("unexpected title and description: %q, %q", got.Title, got.Description)
+	case !reflect.DeepEqual(got.Allergens, []string{"milk"}) || !reflect.DeepEqual(got.DietaryTags, []string{"vegan"}):
+		t.Errorf("unexpected lists: %v, %v", got.Allergens, got.DietaryTags)
+	case got.Price.Amount != 500 || got.IsActive || got.Image != nil:
+		t.Errorf("expected price, is_active and image to be written, got %+v", got)
+	case !got.UpdatedAt.After(item.UpdatedAt):
+		t.Errorf("expected updated_at to move, got %v", got.UpdatedAt)
+	}
+
+	if err := repo.UpdateMenuItem(ctx, "m2", "i1", &models.MenuItem{}); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("expected not found under another menu, got %v", err)
+	}
+}
+
+func TestMenuItemRepositoryListsAndReorders(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMenuItemRepository(testSQLite(t))
+
+	for _, item := range []*models.MenuItem{newItem("i1", "s2", 0), newItem("i2", "s1", 1), newItem("i3", "s1", 0)} {
+		if err := repo.CreateMenuItem(ctx, item); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	other := newItem("x", "s1", 0)
+	other.MenuID = "m2"
+	if err := repo.CreateMenuItem(ctx, other); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	order := func() string {
+		t.Helper()
+		items, err := repo.ListMenuItemsByMenu(ctx, "m1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var ids []string
+		for _, item := range items {
+			ids = append(ids, item.ItemID)
+		}
+		return fmt.Sprint(ids)
+	}
+	if got := order(); got != "[i3 i2 i1]" {
+		t.Errorf("expected [i3 i2 i1], got %s", got)
+	}
+
+	// the items that exist are moved even though one is missing
+	err := repo.ReorderMenuItems(ctx, "m1", []models.ItemPlacement{
+		{ItemID: "i1", SectionID: "s1", Position: 0},
+		{ItemID: "i3", SectionID: "s1", Position: 1},
+		{ItemID: "x", SectionID: "s1", Position: 2},
+	})
+	if !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("expected not found for an item of another menu, got %v", err)
+	}
+	if got := order(); got != "[i1 i2 i3]" {
+		t.Errorf("expected [i1 i2 i3], got %s", got)
+	}
+
+	if err := repo.DeleteMenuItemsByMenu(ctx, "m1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := order(); got != "[]" {
+		t.Errorf("expected no items left, got %s", got)
+	}
+	if _, err := repo.GetMenuItemByID(ctx, "m2", "x"); err != nil {
+		t.Errorf("expected the other menu's item to remain, got %v", err)
+	}
+}
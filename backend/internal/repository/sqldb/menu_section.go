@@ -0,0 +1,207 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+type MenuSectionRepository struct {
+	db *DB
+}
+
+var _ mongo.MenuSectionRepositoryI = (*MenuSectionRepository)(nil)
+
+func NewMenuSectionRepository(db *DB) *MenuSectionRepository {
+	return &MenuSectionRepository{db: db}
+}
+
+const menuSectionColumns = `id, menu_id, business_id, name, position, created_at, updated_at`
+
+func scanMenuSection(row rowScanner) (*models.MenuSection, error) {
+	var section models.MenuSection
+	var createdAt, updatedAt int64
+	err := row.Scan(&section.SectionID, &section.MenuID, &section.BusinessID, &section.Name, &section.Position,
+		&createdAt, &updatedAt)
+	if err != nil {
+		return nil, err
+	}
+	section.CreatedAt = fromMillis(createdAt)
+	section.UpdatedAt = fromMillis(updatedAt)
+	return &section, nil
+}
+
+func (r *MenuSectionRepository) CreateMenuSection(ctx context.Context, section *models.MenuSection) error {
+	if section == nil {
+		return errors.New("menu section cannot be nil")
+	}
+	if section.SectionID == "" {
+		return apperr.Required("section_id")
+	}
+	if section.MenuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if section.Name == "" {
+		return apperr.Invalid("name", "menu section name is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.exec(ctx, `INSERT INTO menu_sections (`+menuSectionColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		section.SectionID, section.MenuID, section.BusinessID, section.Name, section.Position,
+		millis(section.CreatedAt), millis(section.UpdatedAt))
+	if _, ok := duplicateKey(err); ok {
+		return apperr.Conflict("menu section with this ID already exists")
+	}
+	return err
+}
+
+func (r *MenuSectionRepository) GetMenuSectionByID(ctx context.Context, menuID, sectionID string) (*models.MenuSection, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+	if sectionID == "" {
+		return nil, apperr.Required("section_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	section, err := scanMenuSection(r.db.queryRow(ctx,
+		`SELECT `+menuSectionColumns+` FROM menu_sections WHERE id = ? AND menu_id = ?`, sectionID, menuID))
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("menu section")
+	}
+	return section, err
+}
+
+// UpdateMenuSection renames a section; an empty name leaves it unchanged.
+func (r *MenuSectionRepository) UpdateMenuSection(ctx context.Context, menuID, sectionID string, updates *models.MenuSection) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if sectionID == "" {
+		return apperr.Required("section_id")
+	}
+	if updates == nil {
+		return errors.New("updates cannot be nil")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.exec(ctx, `UPDATE menu_sections
+		SET name = CASE WHEN ? = '' THEN name ELSE ? END, updated_at = ?
+		WHERE id = ? AND menu_id = ?`,
+		updates.Name, updates.Name, millis(now()), sectionID, menuID)
+	return expectRows(result, err, "menu section")
+}
+
+func (r *MenuSectionRepository) DeleteMenuSection(ctx context.Context, menuID, sectionID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if sectionID == "" {
+		return apperr.Required("section_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.exec(ctx, `DELETE FROM menu_sections WHERE id = ? AND menu_id = ?`, sectionID, menuID)
+	return expectRows(result, err, "menu section")
+}
+
+// DeleteMenuSectionsByMenu removes every section of a menu. It is used when a
+// menu is purged.
+func (r *MenuSectionRepository) DeleteMenuSectionsByMenu(ctx context.Context, menuID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.exec(ctx, `DELETE FROM menu_sections WHERE menu_id = ?`, menuID)
+	return err
+}
+
+// ListMenuSectionsByMenu returns the sections of a menu sorted by position.
+func (r *MenuSectionRepository) ListMenuSectionsByMenu(ctx context.Context, menuID string) ([]models.MenuSection, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.query(ctx, `SELECT `+menuSectionColumns+` FROM menu_sections WHERE menu_id = ?
+		ORDER BY position, `+r.db.binary("id"), menuID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sections []models.MenuSection
+	for rows.Next() {
+		section, err := scanMenuSection(rows)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, *section)
+	}
+	return sections, rows.Err()
+}
+
+// ReorderMenuSections assigns each section its index in sectionIDs as
+// position, in one transaction. As with the unordered bulk write in MongoDB,
+// the sections that exist are moved even when others are not found.
+func (r *MenuSectionRepository) ReorderMenuSections(ctx context.Context, menuID string, sectionIDs []string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if len(sectionIDs) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	updatedAt := millis(now())
+	var matched int64
+	err := r.db.inTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.PrepareContext(ctx, r.db.rebind(
+			`UPDATE menu_sections SET position = ?, updated_at = ? WHERE id = ? AND menu_id = ?`))
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for i, sectionID := range sectionIDs {
+			result, err := stmt.ExecContext(ctx, i, updatedAt, sectionID, menuID)
+			if err != nil {
+				return err
+			}
+			n, err := result.RowsAffected()
+			if err != nil {
+				return err
+			}
+			matched += n
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if matched != int64(len(sectionIDs)) {
+		return apperr.NotFound("menu section")
+	}
+
+	return nil
+}
@@ -0,0 +1,149 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// MenuVersionRepository is the SQL mongo.MenuVersionRepositoryI. Version
+// numbers are unique per menu, like the menu_number_unique index.
+type MenuVersionRepository struct {
+	db *DB
+}
+
+var _ mongo.MenuVersionRepositoryI = (*MenuVersionRepository)(nil)
+
+func NewMenuVersionRepository(db *DB) *MenuVersionRepository {
+	return &MenuVersionRepository{db: db}
+}
+
+// menuVersionColumns leaves out sections and items, which only
+// GetMenuVersion reads.
+const menuVersionColumns = `id, menu_id, business_id, number, name, description,
+	section_count, item_count, published_at, published_by, restored_from`
+
+func scanMenuVersion(row rowScanner, extra ...any) (*models.MenuVersion, error) {
+	var version models.MenuVersion
+	var publishedAt int64
+	dest := append([]any{&version.VersionID, &version.MenuID, &version.BusinessID, &version.Number, &version.Name,
+		&version.Description, &version.SectionCount, &version.ItemCount, &publishedAt, &version.PublishedBy,
+		&version.RestoredFrom}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	version.PublishedAt = fromMillis(publishedAt)
+	return &version, nil
+}
+
+func (r *MenuVersionRepository) CreateMenuVersion(ctx context.Context, version *models.MenuVersion) error {
+	if version == nil {
+		return errors.New("menu version cannot be nil")
+	}
+	if version.VersionID == "" {
+		return apperr.Required("version_id")
+	}
+	if version.MenuID == "" {
+		return apperr.Required("menu_id")
+	}
+	if version.Number < 1 {
+		return apperr.Invalid("number", "menu version number must be positive")
+	}
+
+	sections, err := encodeDoc(version.Sections)
+	if err != nil {
+		return err
+	}
+	items, err := encodeDoc(version.Items)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	_, err = r.db.exec(ctx, `INSERT INTO menu_versions (`+menuVersionColumns+`, sections, items)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		version.VersionID, version.MenuID, version.BusinessID, version.Number, version.Name, version.Description,
+		version.SectionCount, version.ItemCount, millis(version.PublishedAt), version.PublishedBy, version.RestoredFrom,
+		sections, items)
+	if _, ok := duplicateKey(err); ok {
+		return mongo.ErrMenuVersionExists
+	}
+	return err
+}
+
+func (r *MenuVersionRepository) GetMenuVersion(ctx context.Context, menuID string, number int) (*models.MenuVersion, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var sections, items string
+	version, err := scanMenuVersion(r.db.queryRow(ctx,
+		`SELECT `+menuVersionColumns+`, sections, items FROM menu_versions WHERE menu_id = ? AND number = ?`,
+		menuID, number), &sections, &items)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("menu version")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := decodeDoc(sections, &version.Sections); err != nil {
+		return nil, err
+	}
+	if err := decodeDoc(items, &version.Items); err != nil {
+		return nil, err
+	}
+
+	return version, nil
+}
+
+// ListMenuVersions returns the versions of a menu, newest first, without
+// their sections and items.
+func (r *MenuVersionRepository) ListMenuVersions(ctx context.Context, menuID string) ([]models.MenuVersion, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	rows, err := r.db.query(ctx, `SELECT `+menuVersionColumns+` FROM menu_versions WHERE menu_id = ?
+		ORDER BY number DESC`, menuID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var versions []models.MenuVersion
+	for rows.Next() {
+		version, err := scanMenuVersion(rows)
+		if err != nil {
+			return nil, err
+		}
+		versions = append(versions, *version)
+	}
+	return versions, rows.Err()
+}
+
+// DeleteMenuVersionsByMenu removes every version of a menu. It is used when a
+// menu is purged.
+func (r *MenuVersionRepository) DeleteMenuVersionsByMenu(ctx context.Context, menuID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.exec(ctx, `DELETE FROM menu_versions WHERE menu_id = ?`, menuID)
+	return err
+}
@@ -0,0 +1,118 @@
+-- The tables mirror the MongoDB collections of the same names. Times are
+-- Unix milliseconds; nested values are relaxed Extended JSON.
+
+CREATE TABLE businesses (
+	id TEXT PRIMARY KEY,
+	name TEXT NOT NULL,
+	address TEXT NOT NULL,
+	timezone TEXT NOT NULL,
+	default_currency TEXT NOT NULL,
+	locale TEXT NOT NULL,
+	logo_url TEXT NOT NULL,
+	created_at BIGINT NOT NULL,
+	updated_at BIGINT NOT NULL
+);
+
+CREATE TABLE users (
+	id TEXT PRIMARY KEY,
+	email TEXT NOT NULL,
+	password_hash TEXT NOT NULL,
+	business_id TEXT NOT NULL,
+	role TEXT NOT NULL,
+	created_at BIGINT NOT NULL,
+	updated_at BIGINT NOT NULL
+);
+
+CREATE UNIQUE INDEX users_email_unique ON users (email);
+
+-- slug is NULL rather than empty so that menus without one do not collide;
+-- it stays reserved while a menu is soft-deleted
+CREATE TABLE menus (
+	id TEXT PRIMARY KEY,
+	business_id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	slug TEXT,
+	description TEXT NOT NULL,
+	is_active BOOLEAN NOT NULL,
+	schedule TEXT,
+	published_version INTEGER NOT NULL,
+	published_at BIGINT,
+	revision BIGINT NOT NULL,
+	created_at BIGINT NOT NULL,
+	updated_at BIGINT NOT NULL,
+	deleted_at BIGINT
+);
+
+CREATE UNIQUE INDEX menus_slug_unique ON menus (slug);
+CREATE INDEX menus_business_name ON menus (business_id, deleted_at, name, id);
+CREATE INDEX menus_business_created_at ON menus (business_id, deleted_at, created_at, id);
+CREATE INDEX menus_business_updated_at ON menus (business_id, deleted_at, updated_at, id);
+
+CREATE TABLE menu_sections (
+	id TEXT PRIMARY KEY,
+	menu_id TEXT NOT NULL,
+	business_id TEXT NOT NULL,
+	name TEXT NOT NULL,
+	position INTEGER NOT NULL,
+	created_at BIGINT NOT NULL,
+	updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX menu_sections_menu ON menu_sections (menu_id, position);
+
+CREATE TABLE menu_items (
+	id TEXT PRIMARY KEY,
+	menu_id TEXT NOT NULL,
+	business_id TEXT NOT NULL,
+	section_id TEXT NOT NULL,
+	position INTEGER NOT NULL,
+	title TEXT NOT NULL,
+	description TEXT NOT NULL,
+	price_amount BIGINT NOT NULL,
+	price_currency TEXT NOT NULL,
+	image_url TEXT NOT NULL,
+	ingredients TEXT NOT NULL,
+	is_active BOOLEAN NOT NULL,
+	allergens TEXT NOT NULL,
+	dietary_tags TEXT NOT NULL,
+	modifier_groups TEXT NOT NULL,
+	image TEXT,
+	created_at BIGINT NOT NULL,
+	updated_at BIGINT NOT NULL
+);
+
+CREATE INDEX menu_items_menu ON menu_items (menu_id, section_id, position);
+
+CREATE TABLE menu_versions (
+	id TEXT PRIMARY KEY,
+	menu_id TEXT NOT NULL,
+	business_id TEXT NOT NULL,
+	number INTEGER NOT NULL,
+	name TEXT NOT NULL,
+	description TEXT NOT NULL,
+	sections TEXT NOT NULL,
+	items TEXT NOT NULL,
+	section_count INTEGER NOT NULL,
+	item_count INTEGER NOT NULL,
+	published_at BIGINT NOT NULL,
+	published_by TEXT NOT NULL,
+	restored_from INTEGER NOT NULL
+);
+
+CREATE UNIQUE INDEX menu_versions_menu_number_unique ON menu_versions (menu_id, number);
+
+CREATE TABLE audit_events (
+	id TEXT PRIMARY KEY,
+	business_id TEXT NOT NULL,
+	actor_id TEXT NOT NULL,
+	request_id TEXT NOT NULL,
+	action TEXT NOT NULL,
+	entity TEXT NOT NULL,
+	menu_id TEXT NOT NULL,
+	changes TEXT NOT NULL,
+	occurred_at BIGINT NOT NULL
+);
+
+CREATE INDEX audit_events_business ON audit_events (business_id, occurred_at, id);
+CREATE INDEX audit_events_menu ON audit_events (business_id, menu_id, occurred_at, id);
+CREATE INDEX audit_events_entity ON audit_events (business_id, entity, occurred_at, id);
@@ -0,0 +1,241 @@
+// Package sqldb implements the repository interfaces of package mongo on a
+// SQL database through database/sql. It backs STORAGE=sqlite, for
+// single-box installs, and STORAGE=postgres.
+//
+// Both databases share one schema, created by the embedded migrations that
+// Migrate applies. Times are stored as Unix milliseconds, the precision
+// MongoDB keeps, and nested values such as schedules, modifier groups and
+// images as relaxed Extended JSON using the same bson tags as MongoDB, so
+// every backend reads back the same documents.
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	_ "github.com/jackc/pgx/v5/stdlib" // registers the "pgx" driver
+	"go.mongodb.org/mongo-driver/bson"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// Dialect is the SQL database behind a DB.
+type Dialect string
+
+const (
+	SQLite   Dialect = "sqlite"
+	Postgres Dialect = "postgres"
+)
+
+// DB is a database handle that knows its dialect. Queries are written with
+// ? placeholders and rewritten for PostgreSQL.
+type DB struct {
+	db      *sql.DB
+	dialect Dialect
+}
+
+// Open connects to the database named by dsn: a file path or file: URI for
+// SQLite, a postgres:// URL or key=value string for PostgreSQL.
+func Open(ctx context.Context, dialect Dialect, dsn string) (*DB, error) {
+	var driver string
+	switch dialect {
+	case SQLite:
+		driver = "sqlite"
+	case Postgres:
+		driver = "pgx"
+	default:
+		return nil, fmt.Errorf("sqldb: unknown dialect %q", dialect)
+	}
+	if dsn == "" {
+		return nil, errors.New("sqldb: empty DSN")
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if dialect == SQLite {
+		// SQLite allows one writer at a time; a single connection queues
+		// writes in the pool instead of failing them with SQLITE_BUSY, and
+		// keeps a :memory: database alive
+		db.SetMaxOpenConns(1)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		return nil, errors.Join(err, db.Close())
+	}
+
+	return &DB{db: db, dialect: dialect}, nil
+}
+
+func (db *DB) Close() error {
+	return db.db.Close()
+}
+
+// Dialect returns the database db is connected to.
+func (db *DB) Dialect() Dialect {
+	return db.dialect
+}
+
+// rebind rewrites the ? placeholders of query into the dialect's form.
+func (db *DB) rebind(query string) string {
+	if db.dialect != Postgres {
+		return query
+	}
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// binary returns the text expression expr compared byte by byte, as Go and
+// MongoDB compare strings.
+func (db *DB) binary(expr string) string {
+	if db.dialect == Postgres {
+		return expr + ` COLLATE "C"`
+	}
+	return expr
+}
+
+func (db *DB) exec(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return db.db.ExecContext(ctx, db.rebind(query), args...)
+}
+
+func (db *DB) query(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return db.db.QueryContext(ctx, db.rebind(query), args...)
+}
+
+func (db *DB) queryRow(ctx context.Context, query string, args ...any) *sql.Row {
+	return db.db.QueryRowContext(ctx, db.rebind(query), args...)
+}
+
+// inTx runs fn in a transaction, committing it if fn returns nil and rolling
+// it back otherwise.
+func (db *DB) inTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := db.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// duplicateKey reports whether err is a unique constraint violation and
+// names what was violated: the constraint in PostgreSQL, the columns in
+// SQLite.
+func duplicateKey(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return pgErr.ConstraintName, true
+	}
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return sqliteErr.Error(), true
+		}
+	}
+	return "", false
+}
+
+// rowScanner is a *sql.Row or *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// now is the current time as it is stored.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Millisecond)
+}
+
+func millis(t time.Time) int64 {
+	return t.UnixMilli()
+}
+
+func fromMillis(ms int64) time.Time {
+	return time.UnixMilli(ms).UTC()
+}
+
+func nullMillis(t *time.Time) sql.NullInt64 {
+	if t == nil {
+		return sql.NullInt64{}
+	}
+	return sql.NullInt64{Int64: t.UnixMilli(), Valid: true}
+}
+
+func timePtr(ms sql.NullInt64) *time.Time {
+	if !ms.Valid {
+		return nil
+	}
+	t := fromMillis(ms.Int64)
+	return &t
+}
+
+// nullString stores an empty string as NULL, for optional unique columns.
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
+
+// document wraps a nested value, which need not be a document itself, for
+// Extended JSON.
+type document[T any] struct {
+	V T `bson:"v"`
+}
+
+// encodeDoc returns v as relaxed Extended JSON.
+func encodeDoc[T any](v T) (string, error) {
+	data, err := bson.MarshalExtJSON(document[T]{V: v}, false, false)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// decodeDoc reads a value written by encodeDoc into dst.
+func decodeDoc[T any](data string, dst *T) error {
+	var doc document[T]
+	if err := bson.UnmarshalExtJSON([]byte(data), false, &doc); err != nil {
+		return err
+	}
+	*dst = doc.V
+	return nil
+}
+
+// encodeNullDoc is encodeDoc with a nil v stored as NULL.
+func encodeNullDoc[T any](v *T) (sql.NullString, error) {
+	if v == nil {
+		return sql.NullString{}, nil
+	}
+	data, err := encodeDoc(v)
+	return sql.NullString{String: data, Valid: true}, err
+}
+
+// decodeNullDoc is decodeDoc with NULL read as nil.
+func decodeNullDoc[T any](data sql.NullString) (*T, error) {
+	if !data.Valid {
+		return nil, nil
+	}
+	var v *T
+	if err := decodeDoc(data.String, &v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
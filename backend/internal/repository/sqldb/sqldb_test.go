@@ -0,0 +1,58 @@
+package sqldb
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRebind(t *testing.T) {
+	query := "SELECT id FROM menus WHERE business_id = ? AND name > ? LIMIT ?"
+
+	if got := (&DB{dialect: SQLite}).rebind(query); got != query {
+		t.Errorf("expected SQLite queries to be unchanged, got %q", got)
+	}
+	want := "SELECT id FROM menus WHERE business_id = $1 AND name > $2 LIMIT $3"
+	if got := (&DB{dialect: Postgres}).rebind(query); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := "-- a comment;\nCREATE TABLE a (\n\tid TEXT\n);\n\nCREATE INDEX a_id ON a (id);\n"
+
+	got := splitStatements(script)
+	if len(got) != 2 || got[0] != "CREATE TABLE a (\n\tid TEXT\n);" || got[1] != "CREATE INDEX a_id ON a (id);" {
+		t.Errorf("unexpected statements: %q", got)
+	}
+}
+
+func TestMigrateIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	db := testSQLite(t)
+
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var recorded int
+	if err := db.queryRow(ctx, "SELECT COUNT(*) FROM schema_migrations").Scan(&recorded); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recorded != len(migrations) {
+		t.Errorf("expected %d recorded migrations, got %d", len(migrations), recorded)
+	}
+
+	applied, err := Migrate(ctx, db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if applied != 0 {
+		t.Errorf("expected a migrated database to need no migrations, applied %d", applied)
+	}
+}
+
+func TestOpenRejectsUnknownDialect(t *testing.T) {
+	if _, err := Open(context.Background(), Dialect("mysql"), "dsn"); err == nil {
+		t.Error("expected an error for an unknown dialect")
+	}
+}
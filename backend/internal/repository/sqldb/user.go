@@ -0,0 +1,92 @@
+package sqldb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// UserRepository is the SQL mongo.UserRepositoryI. Emails are unique, like
+// the email index in MongoDB.
+type UserRepository struct {
+	db *DB
+}
+
+var _ mongo.UserRepositoryI = (*UserRepository)(nil)
+
+func NewUserRepository(db *DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) CreateUser(ctx context.Context, user *models.User) error {
+	if user == nil {
+		return errors.New("user cannot be nil")
+	}
+	if user.UserID == "" {
+		return apperr.Required("user_id")
+	}
+	if user.Email == "" {
+		return apperr.Required("email")
+	}
+	if user.PasswordHash == "" {
+		return apperr.Required("password_hash")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	_, err := r.db.exec(ctx, `INSERT INTO users (id, email, password_hash, business_id, role, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		user.UserID, user.Email, user.PasswordHash, user.BusinessID, user.Role,
+		millis(user.CreatedAt), millis(user.UpdatedAt))
+	if _, ok := duplicateKey(err); ok {
+		return apperr.Conflict("user with this email already exists")
+	}
+	return err
+}
+
+func (r *UserRepository) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	if email == "" {
+		return nil, apperr.Required("email")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	var user models.User
+	var createdAt, updatedAt int64
+	err := r.db.queryRow(ctx, `SELECT id, email, password_hash, business_id, role, created_at, updated_at
+		FROM users WHERE email = ?`, email).
+		Scan(&user.UserID, &user.Email, &user.PasswordHash, &user.BusinessID, &user.Role, &createdAt, &updatedAt)
+	if err == sql.ErrNoRows {
+		return nil, apperr.NotFound("user")
+	}
+	if err != nil {
+		return nil, err
+	}
+	user.CreatedAt = fromMillis(createdAt)
+	user.UpdatedAt = fromMillis(updatedAt)
+
+	return &user, nil
+}
+
+// SetUserRole changes the role of the user with the given email.
+func (r *UserRepository) SetUserRole(ctx context.Context, email, role string, updatedAt time.Time) error {
+	if email == "" {
+		return apperr.Required("email")
+	}
+	if role == "" {
+		return apperr.Required("role")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	result, err := r.db.exec(ctx, `UPDATE users SET role = ?, updated_at = ? WHERE email = ?`, role, millis(updatedAt), email)
+	return expectRows(result, err, "user")
+}
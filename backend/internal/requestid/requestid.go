@@ -0,0 +1,18 @@
+// Package requestid carries the ID of the HTTP request being served through
+// its context so logs and audit events can be correlated.
+package requestid
+
+import "context"
+
+type contextKey struct{}
+
+// With returns a copy of ctx carrying id.
+func With(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// From returns the request ID stored by With, or "" if there is none.
+func From(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/validate"
+)
+
+// dietConflicts lists, for each dietary tag, the allergens an item carrying
+// the tag cannot contain.
+var dietConflicts = map[string][]string{
+	models.DietVegan:      {models.AllergenMilk, models.AllergenEggs, models.AllergenFish, models.AllergenCrustaceans, models.AllergenMolluscs},
+	models.DietVegetarian: {models.AllergenFish, models.AllergenCrustaceans, models.AllergenMolluscs},
+	models.DietGlutenFree: {models.AllergenGluten},
+}
+
+// normalizeAllergens lowercases, validates, de-duplicates and sorts allergen
+// codes, recording unknown codes against field, e.g. "allergens[2]". It
+// returns a non-nil slice of the valid codes.
+func normalizeAllergens(v *validate.Validator, field string, allergens []string) []string {
+	return normalizeTags(v, field, allergens, "allergen", models.IsValidAllergen, models.Allergens)
+}
+
+// normalizeDietaryTags is normalizeAllergens for dietary tags.
+func normalizeDietaryTags(v *validate.Validator, field string, tags []string) []string {
+	return normalizeTags(v, field, tags, "dietary tag", models.IsValidDietaryTag, models.DietaryTags)
+}
+
+func normalizeTags(v *validate.Validator, field string, values []string, kind string, valid func(string) bool, all []string) []string {
+	seen := map[string]bool{}
+	normalized := []string{}
+	for i, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !valid(value) {
+			v.Addf(fmt.Sprintf("%s[%d]", field, i), "%s %q must be one of %s", kind, value, strings.Join(all, ", "))
+			continue
+		}
+		if seen[value] {
+			continue
+		}
+		seen[value] = true
+		normalized = append(normalized, value)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// normalizeDietaryInfo normalizes the allergens and dietary tags of an item
+// and checks that they do not contradict each other.
+func normalizeDietaryInfo(v *validate.Validator, allergens, dietaryTags []string) ([]string, []string) {
+	allergens = normalizeAllergens(v, "allergens", allergens)
+	dietaryTags = normalizeDietaryTags(v, "dietary_tags", dietaryTags)
+	checkDietConflicts(v, allergens, dietaryTags)
+	return allergens, dietaryTags
+}
+
+// checkDietConflicts rejects dietary tags that contradict the declared
+// allergens, such as a vegan item containing milk.
+func checkDietConflicts(v *validate.Validator, allergens, dietaryTags []string) {
+	for _, tag := range dietaryTags {
+		for _, conflict := range dietConflicts[tag] {
+			for _, allergen := range allergens {
+				if allergen == conflict {
+					v.Addf("dietary_tags", "menu item tagged %s must not contain %s", tag, allergen)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/validate"
+)
+
+func TestNormalizeDietaryInfo(t *testing.T) {
+	var v validate.Validator
+	allergens, tags := normalizeDietaryInfo(&v, []string{"Sesame", " gluten", "sesame"}, []string{"halal"})
+	if err := v.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(allergens, []string{"gluten", "sesame"}) || !reflect.DeepEqual(tags, []string{"halal"}) {
+		t.Errorf("unexpected result %v %v", allergens, tags)
+	}
+
+	invalid := []struct {
+		allergens, tags []string
+		fields          string
+	}{
+		{[]string{"nuts"}, nil, "allergens[0]"},
+		{nil, []string{"keto"}, "dietary_tags[0]"},
+		{[]string{"milk"}, []string{"vegan"}, "dietary_tags"},
+		{[]string{"fish"}, []string{"vegetarian"}, "dietary_tags"},
+		{[]string{"gluten"}, []string{"gluten-free"}, "dietary_tags"},
+		// every problem is reported at once
+		{[]string{"eggs", "nuts", "milk", "chocolate"}, []string{"keto", "vegan"}, "allergens[1] allergens[3] dietary_tags[0] dietary_tags dietary_tags"},
+	}
+	for _, tc := range invalid {
+		var v validate.Validator
+		normalizeDietaryInfo(&v, tc.allergens, tc.tags)
+		if got := invalidFields(v.Err()); got != tc.fields {
+			t.Errorf("%v %v: expected invalid fields %q, got %q", tc.allergens, tc.tags, tc.fields, got)
+		}
+	}
+}
+
+func TestCreateMenuItemRejectsUnknownAllergen(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+	svc := NewMenuItemService(menuRepo, memory.NewMenuSectionRepository(), memory.NewMenuItemRepository(), memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+
+	req := &models.CreateMenuItemRequest{
+		Title:     "Cake",
+		Price:     models.Money{Amount: 450, Currency: "EUR"},
+		Allergens: []string{"eggs", "chocolate"},
+	}
+	if _, err := svc.CreateMenuItem(context.Background(), "m1", req, "b1"); invalidFields(err) != "allergens[1]" {
+		t.Errorf("expected allergens[1] to be invalid, got %v", err)
+	}
+
+	req.Allergens = []string{"eggs", "milk"}
+	item, err := svc.CreateMenuItem(context.Background(), "m1", req, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(item.Allergens, []string{"eggs", "milk"}) || item.DietaryTags == nil {
+		t.Errorf("unexpected item: %+v", item)
+	}
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/auth"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/custard-technology/abakcus/backend/internal/requestid"
+)
+
+// Page size bounds for ListAuditEvents.
+const (
+	DefaultAuditPageSize = 50
+	MaxAuditPageSize     = 100
+)
+
+// auditIgnoredFields change on every write and would only add noise.
+var auditIgnoredFields = map[string]bool{"updated_at": true, "revision": true}
+
+// AuditService records who changed what in a business's menus and lists
+// the recorded events.
+type AuditService struct {
+	repo mongo.AuditRepositoryI
+	now  func() time.Time
+}
+
+func NewAuditService(repo mongo.AuditRepositoryI) *AuditService {
+	return &AuditService{repo: repo, now: time.Now}
+}
+
+// Record stores an event for a change to entity ("type:id") of menuID. The
+// actor and request ID are taken from ctx. before is nil for creations and
+// after for deletions; only the top-level fields that differ between them
+// are kept. An update that changed nothing is not recorded.
+//
+// Recording happens after the change is saved and is best effort: a failure
+// is logged but does not fail the request, whose change already took effect.
+func (s *AuditService) Record(ctx context.Context, businessID, action, entity, menuID string, before, after any) {
+	changes, err := diffFields(before, after)
+	if err != nil {
+		log.Printf("audit: diffing %s %s failed: %v", action, entity, err)
+		return
+	}
+	if action == models.AuditUpdate && len(changes) == 0 {
+		return
+	}
+
+	event := &models.AuditEvent{
+		EventID:    uuid.New().String(),
+		BusinessID: businessID,
+		RequestID:  requestid.From(ctx),
+		Action:     action,
+		Entity:     entity,
+		MenuID:     menuID,
+		Changes:    changes,
+		OccurredAt: s.now(),
+	}
+	if id, ok := auth.FromContext(ctx); ok {
+		event.ActorID = id.UserID
+	}
+
+	// the request may already be cancelled by the time we get here
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.repo.CreateAuditEvent(ctx, event); err != nil {
+		log.Printf("audit: recording %s %s failed: %v", action, entity, err)
+	}
+}
+
+// ListAuditEvents returns one page of a business's audit log, newest first.
+// The next page is requested by passing NextCursor back with the same entity.
+func (s *AuditService) ListAuditEvents(ctx context.Context, businessID string, opts models.AuditListOptions) (*models.AuditPage, error) {
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+	if opts.Entity != "" {
+		kind, id, ok := strings.Cut(opts.Entity, ":")
+		if !ok || id == "" || (kind != models.AuditEntityMenu && kind != models.AuditEntitySection && kind != models.AuditEntityItem) {
+			return nil, apperr.Invalid("entity", "entity must be menu:{id}, section:{id} or item:{id}")
+		}
+	}
+
+	if opts.Limit == 0 {
+		opts.Limit = DefaultAuditPageSize
+	}
+	if opts.Limit < 1 || opts.Limit > MaxAuditPageSize {
+		return nil, apperr.Invalidf("limit", "limit must be between 1 and %d", MaxAuditPageSize)
+	}
+	if opts.Cursor != "" {
+		after, err := models.DecodeAuditCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		opts.After = &after
+	}
+
+	// fetch one extra event to learn whether another page exists
+	pageSize := opts.Limit
+	opts.Limit++
+	events, err := s.repo.ListAuditEvents(ctx, businessID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.AuditPage{Events: events}
+	if len(events) > pageSize {
+		page.Events = events[:pageSize]
+		last := page.Events[pageSize-1]
+		page.NextCursor = models.AuditCursor{OccurredAt: last.OccurredAt, ID: last.EventID}.Encode()
+	}
+	if page.Events == nil {
+		page.Events = []models.AuditEvent{}
+	}
+
+	return page, nil
+}
+
+// diffFields compares the JSON forms of before and after field by field and
+// returns the fields that differ, sorted by name. Either side may be nil.
+func diffFields(before, after any) ([]models.FieldChange, error) {
+	b, err := jsonFields(before)
+	if err != nil {
+		return nil, err
+	}
+	a, err := jsonFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	names := map[string]bool{}
+	for name := range b {
+		names[name] = true
+	}
+	for name := range a {
+		names[name] = true
+	}
+
+	changes := []models.FieldChange{}
+	for name := range names {
+		if auditIgnoredFields[name] || reflect.DeepEqual(b[name], a[name]) {
+			continue
+		}
+		changes = append(changes, models.FieldChange{Field: name, Before: b[name], After: a[name]})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+
+	return changes, nil
+}
+
+func jsonFields(v any) (map[string]any, error) {
+	if v == nil || (reflect.ValueOf(v).Kind() == reflect.Pointer && reflect.ValueOf(v).IsNil()) {
+		return map[string]any{}, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]any{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// entityRef returns the "type:id" reference of an audited entity.
+func entityRef(kind, id string) string {
+	return kind + ":" + id
+}
@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/auth"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/requestid"
+)
+
+func TestMenuMutationsAreAudited(t *testing.T) {
+	auditRepo := memory.NewAuditRepository()
+	svc := NewMenuService(memory.NewMenuRepository(), newTestBusinessRepository(t, "biz-1"), newTestAuditService(auditRepo))
+
+	ctx := auth.WithIdentity(context.Background(), auth.Identity{UserID: "u1", BusinessID: "biz-1", Role: auth.RoleOwner})
+	ctx = requestid.With(ctx, "req-1")
+
+	menu, err := svc.CreateMenu(ctx, &models.CreateMenuRequest{Name: "Lunch"}, "biz-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.PatchMenu(ctx, menu.MenuID, "biz-1", []int64{AnyRevision}, []byte(`{"name":"Dinner"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// nothing changes, so nothing is recorded
+	if _, err := svc.PatchMenu(ctx, menu.MenuID, "biz-1", []int64{AnyRevision}, []byte(`{"name":"Dinner"}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.DeleteMenu(ctx, menu.MenuID, "biz-1", []int64{AnyRevision}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	events := auditEvents(t, auditRepo, "biz-1")
+	if len(events) != 3 {
+		t.Fatalf("expected 3 events, got %d", len(events))
+	}
+	slices.Reverse(events)
+	for i, action := range []string{models.AuditCreate, models.AuditUpdate, models.AuditDelete} {
+		e := events[i]
+		if e.Action != action || e.Entity != "menu:"+menu.MenuID || e.MenuID != menu.MenuID {
+			t.Errorf("event %d: expected %s of menu:%s, got %s of %s", i, action, menu.MenuID, e.Action, e.Entity)
+		}
+		if e.ActorID != "u1" || e.BusinessID != "biz-1" || e.RequestID != "req-1" {
+			t.Errorf("event %d: expected actor u1, business biz-1, request req-1, got %+v", i, e)
+		}
+	}
+
+	update := events[1].Changes
+	if len(update) != 1 || update[0].Field != "name" || update[0].Before != "Lunch" || update[0].After != "Dinner" {
+		t.Errorf("expected only the name to change from Lunch to Dinner, got %+v", update)
+	}
+	for _, change := range events[2].Changes {
+		if change.After != nil {
+			t.Errorf("expected deletion to clear %s, got %v", change.Field, change.After)
+		}
+	}
+}
+
+func TestSectionAndItemMutationsAreAuditedUnderTheirMenu(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Dinner", BusinessID: "b1"})
+	sectionRepo := memory.NewMenuSectionRepository()
+	itemRepo := memory.NewMenuItemRepository()
+	auditRepo := memory.NewAuditRepository()
+	audit := newTestAuditService(auditRepo)
+	sectionSvc := NewMenuSectionService(menuRepo, sectionRepo, itemRepo, audit)
+	itemSvc := NewMenuItemService(menuRepo, sectionRepo, itemRepo, memory.NewBusinessRepository(), audit)
+	ctx := context.Background()
+
+	section, err := sectionSvc.CreateMenuSection(ctx, "m1", &models.CreateMenuSectionRequest{Name: "Starters"}, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	item, err := itemSvc.CreateMenuItem(ctx, "m1", &models.CreateMenuItemRequest{Title: "Soup", Price: eur(500), SectionID: section.SectionID}, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := itemSvc.DeleteMenuItem(ctx, "m1", item.ItemID, "b1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	page, err := audit.ListAuditEvents(ctx, "b1", models.AuditListOptions{Entity: "menu:m1"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Events) != 3 {
+		t.Fatalf("expected 3 events for the menu, got %d", len(page.Events))
+	}
+
+	page, err = audit.ListAuditEvents(ctx, "b1", models.AuditListOptions{Entity: "item:" + item.ItemID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Events) != 2 {
+		t.Fatalf("expected 2 events for the item, got %d", len(page.Events))
+	}
+	if page.Events[0].Action != models.AuditDelete {
+		t.Errorf("expected the deletion first, got %s", page.Events[0].Action)
+	}
+}
+
+func TestListAuditEventsPaginates(t *testing.T) {
+	auditRepo := memory.NewAuditRepository()
+	svc := NewAuditService(auditRepo)
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 5; i++ {
+		svc.now = func() time.Time { return start.Add(time.Duration(i) * time.Minute) }
+		svc.Record(context.Background(), "b1", models.AuditUpdate, "menu:m1", "m1", map[string]any{"n": i}, map[string]any{"n": i + 1})
+	}
+	svc.Record(context.Background(), "b2", models.AuditCreate, "menu:m2", "m2", nil, map[string]any{"n": 1})
+
+	var seen []models.AuditEvent
+	cursor := ""
+	for {
+		page, err := svc.ListAuditEvents(context.Background(), "b1", models.AuditListOptions{Limit: 2, Cursor: cursor})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		seen = append(seen, page.Events...)
+		if page.NextCursor == "" {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	if len(seen) != 5 {
+		t.Fatalf("expected 5 events of b1, got %d", len(seen))
+	}
+	for i := 1; i < len(seen); i++ {
+		if !seen[i].OccurredAt.Before(seen[i-1].OccurredAt) {
+			t.Errorf("expected newest first, got %v after %v", seen[i].OccurredAt, seen[i-1].OccurredAt)
+		}
+	}
+}
+
+func TestListAuditEventsRejectsInvalidEntity(t *testing.T) {
+	svc := NewAuditService(memory.NewAuditRepository())
+
+	for _, entity := range []string{"menu", "menu:", "order:1"} {
+		_, err := svc.ListAuditEvents(context.Background(), "b1", models.AuditListOptions{Entity: entity})
+		if err == nil || !strings.Contains(err.Error(), "must") {
+			t.Errorf("%q: expected a validation error, got %v", entity, err)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/auth"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCredentials is returned by Login for an unknown email or a wrong
+// password; the two cases are deliberately indistinguishable.
+var ErrInvalidCredentials = apperr.New(apperr.ErrUnauthorized, "invalid email or password")
+
+type AuthService struct {
+	repo         mongo.UserRepositoryI
+	businessRepo mongo.BusinessRepositoryI
+	tokens       *auth.TokenManager
+}
+
+func NewAuthService(repo mongo.UserRepositoryI, businessRepo mongo.BusinessRepositoryI, tokens *auth.TokenManager) *AuthService {
+	return &AuthService{repo: repo, businessRepo: businessRepo, tokens: tokens}
+}
+
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", apperr.Required("email")
+	}
+	if _, err := mail.ParseAddress(email); err != nil {
+		return "", apperr.Invalid("email", "email must be a valid address")
+	}
+	return email, nil
+}
+
+// Register creates a new business and its owner account and signs the owner
+// in. Business settings missing from the request default to
+// DefaultBusinessName, DefaultBusinessTimezone, DefaultBusinessCurrency and
+// DefaultBusinessLocale.
+func (s *AuthService) Register(ctx context.Context, req *models.RegisterRequest) (*models.TokenResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+	email, err := normalizeEmail(req.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	business := &models.Business{
+		BusinessID:      uuid.New().String(),
+		Name:            firstNonEmpty(strings.TrimSpace(req.BusinessName), DefaultBusinessName),
+		Timezone:        firstNonEmpty(req.Timezone, DefaultBusinessTimezone),
+		DefaultCurrency: firstNonEmpty(req.DefaultCurrency, DefaultBusinessCurrency),
+		Locale:          firstNonEmpty(req.Locale, DefaultBusinessLocale),
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+	}
+	if err := validateBusiness(business); err != nil {
+		return nil, err
+	}
+
+	user := &models.User{
+		UserID:       uuid.New().String(),
+		Email:        email,
+		PasswordHash: hash,
+		BusinessID:   business.BusinessID,
+		Role:         auth.RoleOwner,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	if err := s.businessRepo.CreateBusiness(ctx, business); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateUser(ctx, user); err != nil {
+		// nothing references the new business yet, so drop it again
+		if delErr := s.businessRepo.DeleteBusiness(ctx, business.BusinessID); delErr != nil {
+			log.Printf("error removing business %s after failed registration: %v", business.BusinessID, delErr)
+		}
+		return nil, err
+	}
+
+	return s.issue(user)
+}
+
+func firstNonEmpty(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+	return fallback
+}
+
+func (s *AuthService) Login(ctx context.Context, req *models.LoginRequest) (*models.TokenResponse, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+	email, err := normalizeEmail(req.Email)
+	if err != nil {
+		return nil, ErrInvalidCredentials
+	}
+
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return nil, ErrInvalidCredentials
+		}
+		return nil, err
+	}
+	if user == nil || !auth.CheckPassword(user.PasswordHash, req.Password) {
+		return nil, ErrInvalidCredentials
+	}
+
+	return s.issue(user)
+}
+
+// SetUserRole makes the user with the given email an owner or an admin and
+// returns the updated user. Roles travel in tokens, so the change applies
+// from the user's next login. An admin cannot revoke their own role, which
+// would otherwise lock the last admin out.
+func (s *AuthService) SetUserRole(ctx context.Context, email, role string) (*models.User, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	if role != auth.RoleOwner && role != auth.RoleAdmin {
+		return nil, apperr.Invalid("role", "role must be owner or admin")
+	}
+
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	if id, ok := auth.FromContext(ctx); ok && id.UserID == user.UserID && role != auth.RoleAdmin {
+		return nil, apperr.Conflict("admins cannot revoke their own role")
+	}
+
+	now := time.Now()
+	if err := s.repo.SetUserRole(ctx, email, role, now); err != nil {
+		return nil, err
+	}
+	user.Role = role
+	user.UpdatedAt = now
+
+	return user, nil
+}
+
+func (s *AuthService) issue(user *models.User) (*models.TokenResponse, error) {
+	token, claims, err := s.tokens.Issue(user.UserID, user.BusinessID, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TokenResponse{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresAt:   time.Unix(claims.ExpiresAt, 0).UTC(),
+		User:        user,
+	}, nil
+}
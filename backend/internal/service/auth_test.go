@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/auth"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+)
+
+func newTestAuthService(t *testing.T) *AuthService {
+	t.Helper()
+	tokens, err := auth.NewTokenManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return NewAuthService(memory.NewUserRepository(), memory.NewBusinessRepository(), tokens)
+}
+
+func TestRegisterIssuesVerifiableToken(t *testing.T) {
+	svc := newTestAuthService(t)
+
+	resp, err := svc.Register(context.Background(), &models.RegisterRequest{Email: " Owner@Example.com ", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if resp.User.Email != "owner@example.com" {
+		t.Errorf("expected normalized email, got %s", resp.User.Email)
+	}
+	if resp.User.BusinessID == "" || resp.User.Role != auth.RoleOwner {
+		t.Errorf("unexpected user: %+v", resp.User)
+	}
+
+	claims, err := svc.tokens.Verify(resp.AccessToken)
+	if err != nil {
+		t.Fatalf("token should verify: %v", err)
+	}
+	if claims.BusinessID != resp.User.BusinessID {
+		t.Errorf("expected %s, got %s", resp.User.BusinessID, claims.BusinessID)
+	}
+}
+
+func TestLoginInvalidCredentials(t *testing.T) {
+	svc := newTestAuthService(t)
+
+	if _, err := svc.Register(context.Background(), &models.RegisterRequest{Email: "owner@example.com", Password: "correct horse"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cases := []*models.LoginRequest{
+		{Email: "owner@example.com", Password: "battery staple"},
+		{Email: "nobody@example.com", Password: "correct horse"},
+	}
+	for _, req := range cases {
+		if _, err := svc.Login(context.Background(), req); err != ErrInvalidCredentials {
+			t.Errorf("%s: expected ErrInvalidCredentials, got %v", req.Email, err)
+		}
+	}
+
+	if _, err := svc.Login(context.Background(), &models.LoginRequest{Email: "OWNER@example.com", Password: "correct horse"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestRegisterRejectsWeakPassword(t *testing.T) {
+	svc := newTestAuthService(t)
+
+	if _, err := svc.Register(context.Background(), &models.RegisterRequest{Email: "owner@example.com", Password: "short"}); err == nil {
+		t.Fatal("expected error for short password")
+	}
+}
+
+// recordingBusinessRepository remembers the IDs of the businesses created
+// through it.
+type recordingBusinessRepository struct {
+	*memory.BusinessRepository
+	created []string
+}
+
+func (r *recordingBusinessRepository) CreateBusiness(ctx context.Context, business *models.Business) error {
+	r.created = append(r.created, business.BusinessID)
+	return r.BusinessRepository.CreateBusiness(ctx, business)
+}
+
+func TestRegisterCreatesBusiness(t *testing.T) {
+	tokens, err := auth.NewTokenManager([]byte("0123456789abcdef0123456789abcdef"), time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	businessRepo := &recordingBusinessRepository{BusinessRepository: memory.NewBusinessRepository()}
+	svc := NewAuthService(memory.NewUserRepository(), businessRepo, tokens)
+
+	resp, err := svc.Register(context.Background(), &models.RegisterRequest{
+		Email:        "owner@example.com",
+		Password:     "correct horse",
+		BusinessName: "Tasca do Zé",
+		Timezone:     "Europe/Lisbon",
+		Locale:       "pt-pt",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	business, _ := businessRepo.GetBusinessByID(context.Background(), resp.User.BusinessID)
+	if business == nil {
+		t.Fatal("expected a business for the new owner")
+	}
+	if business.Name != "Tasca do Zé" || business.Timezone != "Europe/Lisbon" || business.Locale != "pt-PT" || business.DefaultCurrency != DefaultBusinessCurrency {
+		t.Errorf("unexpected business: %+v", business)
+	}
+
+	// a failed registration must not leave a business behind
+	if _, err := svc.Register(context.Background(), &models.RegisterRequest{Email: "owner@example.com", Password: "correct horse"}); err == nil {
+		t.Fatal("expected duplicate email error")
+	}
+	if len(businessRepo.created) != 2 {
+		t.Fatalf("expected a second business to be attempted, got %v", businessRepo.created)
+	}
+	if _, err := businessRepo.GetBusinessByID(context.Background(), businessRepo.created[1]); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("expected the second business to be removed, got %v", err)
+	}
+
+	if _, err := svc.Register(context.Background(), &models.RegisterRequest{Email: "other@example.com", Password: "correct horse", Timezone: "Mars/Olympus"}); err == nil {
+		t.Error("expected error for invalid timezone")
+	}
+}
+
+func TestSetUserRole(t *testing.T) {
+	svc := newTestAuthService(t)
+	ctx := context.Background()
+
+	registered, err := svc.Register(ctx, &models.RegisterRequest{Email: "owner@example.com", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	user, err := svc.SetUserRole(ctx, " Owner@Example.com ", auth.RoleAdmin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.Role != auth.RoleAdmin || user.UserID != registered.User.UserID {
+		t.Errorf("expected the owner to become an admin, got %+v", user)
+	}
+
+	// the role is carried by the tokens issued from now on
+	resp, err := svc.Login(ctx, &models.LoginRequest{Email: "owner@example.com", Password: "correct horse"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claims, err := svc.tokens.Verify(resp.AccessToken)
+	if err != nil {
+		t.Fatalf("token should verify: %v", err)
+	}
+	if claims.Role != auth.RoleAdmin {
+		t.Errorf("expected an admin token, got role %q", claims.Role)
+	}
+
+	if _, err := svc.SetUserRole(ctx, "owner@example.com", "superuser"); !errors.Is(err, apperr.ErrValidation) {
+		t.Errorf("expected a validation error for an unknown role, got %v", err)
+	}
+	if _, err := svc.SetUserRole(ctx, "nobody@example.com", auth.RoleAdmin); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("expected not found for an unknown email, got %v", err)
+	}
+
+	self := auth.WithIdentity(ctx, auth.Identity{UserID: registered.User.UserID, Role: auth.RoleAdmin})
+	if _, err := svc.SetUserRole(self, "owner@example.com", auth.RoleOwner); !errors.Is(err, apperr.ErrConflict) {
+		t.Errorf("expected a conflict when an admin revokes their own role, got %v", err)
+	}
+}
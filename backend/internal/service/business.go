@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/custard-technology/abakcus/backend/internal/validate"
+	"golang.org/x/text/language"
+)
+
+// Settings given to a business when registration or the startup backfill
+// does not provide them.
+const (
+	DefaultBusinessName     = "My business"
+	DefaultBusinessTimezone = "UTC"
+	DefaultBusinessCurrency = "EUR"
+	DefaultBusinessLocale   = "en"
+)
+
+type BusinessService struct {
+	repo mongo.BusinessRepositoryI
+}
+
+func NewBusinessService(repo mongo.BusinessRepositoryI) *BusinessService {
+	return &BusinessService{repo: repo}
+}
+
+func (s *BusinessService) GetBusiness(ctx context.Context, businessID string) (*models.Business, error) {
+	return getBusiness(ctx, s.repo, businessID)
+}
+
+func (s *BusinessService) UpdateBusiness(ctx context.Context, businessID string, req *models.UpdateBusinessRequest) (*models.Business, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	existing, err := getBusiness(ctx, s.repo, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		existing.Name = strings.TrimSpace(req.Name)
+	}
+	if req.Address != nil {
+		existing.Address = *req.Address
+	}
+	if req.Timezone != "" {
+		existing.Timezone = req.Timezone
+	}
+	if req.DefaultCurrency != "" {
+		existing.DefaultCurrency = req.DefaultCurrency
+	}
+	if req.Locale != "" {
+		existing.Locale = req.Locale
+	}
+	if req.LogoURL != "" {
+		existing.LogoURL = req.LogoURL
+	}
+	if err := validateBusiness(existing); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateBusiness(ctx, businessID, existing); err != nil {
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// getBusiness loads a business and reports a missing one as not found. It is
+// shared by the services that must check a business exists.
+func getBusiness(ctx context.Context, repo mongo.BusinessRepositoryI, businessID string) (*models.Business, error) {
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	business, err := repo.GetBusinessByID(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if business == nil {
+		return nil, apperr.NotFound("business")
+	}
+
+	return business, nil
+}
+
+// businessLocation returns the time zone schedules of the business are
+// evaluated in. Businesses without a timezone use UTC.
+func businessLocation(business *models.Business) (*time.Location, error) {
+	if business.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(business.Timezone)
+}
+
+// validateBusiness checks the profile and settings of a business, trimming
+// the text fields and canonicalizing the locale and country codes.
+func validateBusiness(b *models.Business) error {
+	var v validate.Validator
+	v.Name("name", &b.Name, validate.MaxNameLength)
+
+	if b.Timezone == "" {
+		v.Add("timezone", "timezone is required")
+	} else if _, err := time.LoadLocation(b.Timezone); err != nil || b.Timezone == "Local" {
+		v.Addf("timezone", "timezone %q must be an IANA time zone such as Europe/Lisbon", b.Timezone)
+	}
+
+	if b.DefaultCurrency == "" {
+		v.Add("default_currency", "default_currency is required")
+	} else if !models.IsValidCurrency(b.DefaultCurrency) {
+		v.Addf("default_currency", "default_currency %q must be an ISO 4217 code", b.DefaultCurrency)
+	}
+
+	if b.Locale == "" {
+		v.Add("locale", "locale is required")
+	} else if tag, err := language.Parse(b.Locale); err != nil {
+		v.Addf("locale", "locale %q must be a BCP 47 language tag such as pt-PT", b.Locale)
+	} else {
+		b.Locale = tag.String()
+	}
+
+	v.Text("address.line1", &b.Address.Line1, validate.MaxNameLength)
+	v.Text("address.line2", &b.Address.Line2, validate.MaxNameLength)
+	v.Text("address.city", &b.Address.City, validate.MaxNameLength)
+	v.Text("address.postal_code", &b.Address.PostalCode, validate.MaxNameLength)
+	v.Text("address.region", &b.Address.Region, validate.MaxNameLength)
+	if b.Address.Country != "" {
+		region, err := language.ParseRegion(b.Address.Country)
+		if err != nil || len(b.Address.Country) != 2 || !region.IsCountry() {
+			v.Addf("address.country", "address country %q must be an ISO 3166-1 alpha-2 code", b.Address.Country)
+		} else {
+			b.Address.Country = region.String()
+		}
+	}
+
+	v.URL("logo_url", &b.LogoURL)
+
+	return v.Err()
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+)
+
+func newTestBusiness() *models.Business {
+	return &models.Business{
+		BusinessID:      "b1",
+		Name:            "Tasca",
+		Timezone:        "UTC",
+		DefaultCurrency: "EUR",
+		Locale:          "en",
+	}
+}
+
+func TestUpdateBusiness(t *testing.T) {
+	repo := memory.NewBusinessRepository()
+	seedBusinesses(t, repo, newTestBusiness())
+	svc := NewBusinessService(repo)
+
+	business, err := svc.UpdateBusiness(context.Background(), "b1", &models.UpdateBusinessRequest{
+		Address:         &models.Address{Line1: "Rua Augusta 1", City: "Lisboa", Country: "pt"},
+		Timezone:        "Europe/Lisbon",
+		DefaultCurrency: "EUR",
+		LogoURL:         "https://cdn.example.com/logo.png",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if business.Name != "Tasca" {
+		t.Errorf("name should be unchanged, got %s", business.Name)
+	}
+	if business.Address.Country != "PT" || business.Timezone != "Europe/Lisbon" {
+		t.Errorf("unexpected business: %+v", business)
+	}
+}
+
+func TestUpdateBusinessValidation(t *testing.T) {
+	cases := []models.UpdateBusinessRequest{
+		{Timezone: "Nowhere/City"},
+		{DefaultCurrency: "EURO"},
+		{Locale: "not a locale"},
+		{Address: &models.Address{Country: "Portugal"}},
+		{LogoURL: "ftp://example.com/logo.png"},
+	}
+	for _, req := range cases {
+		repo := memory.NewBusinessRepository()
+		seedBusinesses(t, repo, newTestBusiness())
+		svc := NewBusinessService(repo)
+
+		if _, err := svc.UpdateBusiness(context.Background(), "b1", &req); err == nil {
+			t.Errorf("expected error for %+v", req)
+		}
+	}
+}
+
+func TestGetBusinessNotFound(t *testing.T) {
+	svc := NewBusinessService(memory.NewBusinessRepository())
+
+	if _, err := svc.GetBusiness(context.Background(), "missing"); err == nil || err.Error() != "business not found" {
+		t.Errorf("expected business not found, got %v", err)
+	}
+}
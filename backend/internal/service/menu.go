@@ -1,32 +1,58 @@
 package service
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
+	"unicode"
 
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/mergepatch"
 	"github.com/custard-technology/abakcus/backend/internal/models"
 	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/custard-technology/abakcus/backend/internal/validate"
 	"github.com/google/uuid"
+	"golang.org/x/text/unicode/norm"
 )
 
+// AnyRevision, among the revisions passed to UpdateMenu, PatchMenu or
+// DeleteMenu, matches whatever revision of the menu is current, as for
+// "If-Match: *".
+const AnyRevision int64 = -1
+
+// ErrMenuModified is returned by UpdateMenu and DeleteMenu when the menu was
+// changed after the caller read the revision it passed.
+var ErrMenuModified = apperr.New(apperr.ErrPreconditionFailed, "menu has been modified since it was read; fetch it again and retry")
+
 type MenuService struct {
-	repo mongo.MenuRepositoryI
+	repo         mongo.MenuRepositoryI
+	businessRepo mongo.BusinessRepositoryI
+	audit        *AuditService
 }
 
-func NewMenuService(repo mongo.MenuRepositoryI) *MenuService {
-	return &MenuService{repo: repo}
+func NewMenuService(repo mongo.MenuRepositoryI, businessRepo mongo.BusinessRepositoryI, audit *AuditService) *MenuService {
+	return &MenuService{repo: repo, businessRepo: businessRepo, audit: audit}
 }
 
 func (s *MenuService) CreateMenu(ctx context.Context, req *models.CreateMenuRequest, businessID string) (*models.Menu, error) {
 	if req == nil {
 		return nil, errors.New("request cannot be nil")
 	}
-	if req.Name == "" {
-		return nil, errors.New("menu name is required")
+	var v validate.Validator
+	validateMenuFields(&v, &req.Name, &req.Slug, &req.Description, req.Schedule)
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
 	if businessID == "" {
-		return nil, errors.New("business_id is required")
+		return nil, apperr.Required("business_id")
+	}
+	if _, err := getBusiness(ctx, s.businessRepo, businessID); err != nil {
+		return nil, err
 	}
 
 	menu := &models.Menu{
@@ -37,82 +63,375 @@ func (s *MenuService) CreateMenu(ctx context.Context, req *models.CreateMenuRequ
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 		IsActive:    true,
+		Schedule:    req.Schedule,
+		Revision:    1,
+	}
+
+	if req.Slug != "" {
+		menu.Slug = req.Slug
+		if err := s.repo.CreateMenu(ctx, menu); err != nil {
+			return nil, err
+		}
+		s.audit.Record(ctx, businessID, models.AuditCreate, entityRef(models.AuditEntityMenu, menu.MenuID), menu.MenuID, nil, menu)
+		return menu, nil
 	}
 
-	err := s.repo.CreateMenu(ctx, menu)
+	// derive the slug from the name, trying numbered variants while it is taken
+	var err error
+	for _, slug := range slugCandidates(slugify(req.Name), menu.MenuID) {
+		menu.Slug = slug
+		err = s.repo.CreateMenu(ctx, menu)
+		if !errors.Is(err, mongo.ErrMenuSlugTaken) {
+			break
+		}
+	}
 	if err != nil {
 		return nil, err
 	}
+	s.audit.Record(ctx, businessID, models.AuditCreate, entityRef(models.AuditEntityMenu, menu.MenuID), menu.MenuID, nil, menu)
 
 	return menu, nil
 }
 
-func (s *MenuService) GetMenu(ctx context.Context, menuID string) (*models.Menu, error) {
+// GetMenu returns the menu if it belongs to businessID. Menus of other
+// businesses are reported as not found.
+func (s *MenuService) GetMenu(ctx context.Context, menuID, businessID string) (*models.Menu, error) {
 	if menuID == "" {
-		return nil, errors.New("menu_id is required")
+		return nil, apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
 	}
 
-	menu, err := s.repo.GetMenuByID(ctx, menuID)
+	menu, err := s.repo.GetMenuByID(ctx, menuID, businessID)
 	if err != nil {
 		return nil, err
 	}
+	if menu == nil {
+		return nil, apperr.NotFound("menu")
+	}
 
 	return menu, nil
 }
 
-func (s *MenuService) UpdateMenu(ctx context.Context, menuID string, req *models.UpdateMenuRequest) (*models.Menu, error) {
-	if menuID == "" {
-		return nil, errors.New("menu_id is required")
-	}
+// UpdateMenu replaces the editable fields of the menu with req, as PUT does,
+// if the menu is still at one of revisions, which the caller read before
+// deciding on the change; otherwise ErrMenuModified is returned and nothing
+// is written.
+func (s *MenuService) UpdateMenu(ctx context.Context, menuID, businessID string, revisions []int64, req *models.UpdateMenuRequest) (*models.Menu, error) {
 	if req == nil {
 		return nil, errors.New("request cannot be nil")
 	}
 
-	existing, err := s.repo.GetMenuByID(ctx, menuID)
+	existing, err := s.getMenuAtRevision(ctx, menuID, businessID, revisions)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.replaceMenu(ctx, existing, req)
+}
+
+// PatchMenu applies a JSON Merge Patch (RFC 7396) to the editable fields of
+// the menu, under the same revision check as UpdateMenu. Members set to null
+// clear optional fields; clearing a required one is refused like an
+// UpdateMenu without it.
+func (s *MenuService) PatchMenu(ctx context.Context, menuID, businessID string, revisions []int64, patch []byte) (*models.Menu, error) {
+	if !mergepatch.IsObject(patch) {
+		return nil, apperr.Invalid("", "patch must be a JSON object")
+	}
+
+	existing, err := s.getMenuAtRevision(ctx, menuID, businessID, revisions)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := json.Marshal(models.NewUpdateMenuRequest(existing))
+	if err != nil {
+		return nil, err
+	}
+	merged, err := mergepatch.Apply(doc, patch)
 	if err != nil {
 		return nil, err
 	}
 
-	if req.Name != "" {
-		existing.Name = req.Name
+	var req models.UpdateMenuRequest
+	dec := json.NewDecoder(bytes.NewReader(merged))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		return nil, apperr.Invalidf("", "patch must only change name, slug, description, is_active and schedule: %v", err)
+	}
+
+	return s.replaceMenu(ctx, existing, &req)
+}
+
+// getMenuAtRevision returns the menu if it is at one of revisions or they
+// include AnyRevision, and ErrMenuModified otherwise.
+func (s *MenuService) getMenuAtRevision(ctx context.Context, menuID, businessID string, revisions []int64) (*models.Menu, error) {
+	menu, err := s.GetMenu(ctx, menuID, businessID)
+	if err != nil {
+		return nil, err
 	}
-	if req.Description != "" {
-		existing.Description = req.Description
+	for _, revision := range revisions {
+		if revision == AnyRevision || revision == menu.Revision {
+			return menu, nil
+		}
 	}
-	if req.IsActive != nil {
-		existing.IsActive = *req.IsActive
+	return nil, ErrMenuModified
+}
+
+// replaceMenu validates req, copies it onto existing and saves the result
+// on condition that the stored menu is still at existing.Revision.
+func (s *MenuService) replaceMenu(ctx context.Context, existing *models.Menu, req *models.UpdateMenuRequest) (*models.Menu, error) {
+	var v validate.Validator
+	validateMenuFields(&v, &req.Name, &req.Slug, &req.Description, req.Schedule)
+	v.Check(req.Slug != "", "slug", "slug is required")
+	v.Check(req.IsActive != nil, "is_active", "is_active is required")
+	if err := v.Err(); err != nil {
+		return nil, err
 	}
+
+	before := *existing
+	existing.Name = req.Name
+	existing.Slug = req.Slug
+	existing.Description = req.Description
+	existing.IsActive = *req.IsActive
+	existing.Schedule = req.Schedule
 	existing.UpdatedAt = time.Now()
 
-	err = s.repo.UpdateMenu(ctx, menuID, existing)
+	// the repository checks the revision again in the same write, which
+	// catches changes made since the menu was read
+	err := s.repo.UpdateMenu(ctx, existing.MenuID, existing.BusinessID, existing)
+	if errors.Is(err, mongo.ErrMenuRevisionConflict) {
+		return nil, ErrMenuModified
+	}
 	if err != nil {
 		return nil, err
 	}
+	existing.Revision++
+	s.audit.Record(ctx, existing.BusinessID, models.AuditUpdate, entityRef(models.AuditEntityMenu, existing.MenuID), existing.MenuID, &before, existing)
 
 	return existing, nil
 }
 
-func (s *MenuService) DeleteMenu(ctx context.Context, menuID string) error {
-	if menuID == "" {
-		return errors.New("menu_id is required")
+// DeleteMenu soft-deletes the menu if it is still at one of revisions, like
+// UpdateMenu.
+func (s *MenuService) DeleteMenu(ctx context.Context, menuID, businessID string, revisions []int64) error {
+	existing, err := s.getMenuAtRevision(ctx, menuID, businessID, revisions)
+	if err != nil {
+		return err
+	}
+
+	err = s.repo.DeleteMenu(ctx, menuID, businessID, existing.Revision)
+	if errors.Is(err, mongo.ErrMenuRevisionConflict) {
+		return ErrMenuModified
+	}
+	if err != nil {
+		return err
+	}
+	s.audit.Record(ctx, businessID, models.AuditDelete, entityRef(models.AuditEntityMenu, menuID), menuID, existing, nil)
+
+	return nil
+}
+
+// Page size bounds for ListMenusByBusiness.
+const (
+	DefaultMenuPageSize = 50
+	MaxMenuPageSize     = 100
+)
+
+// ListMenusByBusiness returns one page of the business's menus. The next
+// page is requested by passing the returned NextCursor back with the same
+// filters and sort.
+func (s *MenuService) ListMenusByBusiness(ctx context.Context, businessID string, opts models.MenuListOptions) (*models.MenuPage, error) {
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	if opts.Limit == 0 {
+		opts.Limit = DefaultMenuPageSize
+	}
+	if opts.Limit < 1 || opts.Limit > MaxMenuPageSize {
+		return nil, apperr.Invalidf("limit", "limit must be between 1 and %d", MaxMenuPageSize)
+	}
+
+	switch opts.SortBy {
+	case "":
+		opts.SortBy = models.MenuSortCreatedAt
+	case models.MenuSortName, models.MenuSortCreatedAt, models.MenuSortUpdatedAt:
+	default:
+		return nil, apperr.Invalid("sort", "sort must be one of name, created_at, updated_at")
+	}
+
+	if opts.Cursor != "" {
+		after, err := models.DecodeMenuCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		if after.SortBy != opts.SortBy || after.Desc != opts.SortDesc {
+			return nil, apperr.Invalid("cursor", "cursor must be used with the sort it was issued for")
+		}
+		opts.After = &after
+	}
+
+	// fetch one extra menu to learn whether another page exists
+	pageSize := opts.Limit
+	opts.Limit++
+	menus, err := s.repo.ListMenusByBusiness(ctx, businessID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &models.MenuPage{Menus: menus}
+	if len(menus) > pageSize {
+		page.Menus = menus[:pageSize]
+		page.NextCursor = models.NewMenuCursor(page.Menus[pageSize-1], opts.SortBy, opts.SortDesc).Encode()
+	}
+	if page.Menus == nil {
+		page.Menus = []models.Menu{}
+	}
+
+	return page, nil
+}
+
+// ActiveMenusAt returns the business's menus that diners can be served at t:
+// switched on, published and within their schedule, evaluated in the
+// business's timezone. Menus are sorted by name.
+func (s *MenuService) ActiveMenusAt(ctx context.Context, businessID string, t time.Time) ([]models.Menu, error) {
+	return activeMenusAt(ctx, s.repo, s.businessRepo, businessID, t)
+}
+
+// activeMenusAt implements MenuService.ActiveMenusAt for the services that
+// serve menus without a MenuService.
+func activeMenusAt(ctx context.Context, menuRepo mongo.MenuRepositoryI, businessRepo mongo.BusinessRepositoryI, businessID string, t time.Time) ([]models.Menu, error) {
+	business, err := getBusiness(ctx, businessRepo, businessID)
+	if err != nil {
+		return nil, err
+	}
+	loc, err := businessLocation(business)
+	if err != nil {
+		return nil, err
+	}
+
+	active := true
+	menus, err := menuRepo.ListMenusByBusiness(ctx, businessID, models.MenuListOptions{
+		IsActive: &active,
+		SortBy:   models.MenuSortName,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return s.repo.DeleteMenu(ctx, menuID)
+	available := []models.Menu{}
+	for _, menu := range menus {
+		if !menu.IsActive || menu.PublishedVersion == 0 {
+			continue
+		}
+		if menu.Schedule != nil && !menu.Schedule.AvailableAt(t, loc) {
+			continue
+		}
+		available = append(available, menu)
+	}
+
+	return available, nil
 }
 
-func (s *MenuService) ListMenusByBusiness(ctx context.Context, businessID string) ([]models.Menu, error) {
+// RestoreMenu undoes a soft delete and returns the restored menu. A menu
+// that is not deleted is returned unchanged and nothing is audited.
+func (s *MenuService) RestoreMenu(ctx context.Context, menuID, businessID string) (*models.Menu, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
 	if businessID == "" {
-		return nil, errors.New("business_id is required")
+		return nil, apperr.Required("business_id")
+	}
+
+	// reads never return deleted menus, so a hit is already live
+	if menu, err := s.GetMenu(ctx, menuID, businessID); err == nil {
+		return menu, nil
+	} else if !errors.Is(err, apperr.ErrNotFound) {
+		return nil, err
 	}
 
-	menus, err := s.repo.ListMenusByBusiness(ctx, businessID)
+	if err := s.repo.RestoreMenu(ctx, menuID, businessID); err != nil {
+		return nil, err
+	}
+
+	menu, err := s.GetMenu(ctx, menuID, businessID)
 	if err != nil {
 		return nil, err
 	}
+	s.audit.Record(ctx, businessID, models.AuditRestore, entityRef(models.AuditEntityMenu, menuID), menuID, nil, menu)
+
+	return menu, nil
+}
+
+// maxSlugLength bounds slugs so public URLs stay readable.
+const maxSlugLength = 64
 
-	if menus == nil {
-		menus = []models.Menu{}
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// validateMenuFields checks the fields that creating and replacing a menu
+// have in common, trimming the text ones. An empty slug passes; it is
+// derived from the name on creation.
+func validateMenuFields(v *validate.Validator, name, slug, description *string, schedule *models.MenuSchedule) {
+	v.Name("name", name, validate.MaxNameLength)
+	*slug = strings.TrimSpace(*slug)
+	if *slug != "" {
+		v.Merge("slug", validateSlug(*slug))
+	}
+	v.Text("description", description, validate.MaxTextLength)
+	if schedule != nil {
+		v.Merge("schedule", schedule.Validate())
 	}
+}
 
-	return menus, nil
+func validateSlug(slug string) error {
+	if len(slug) > maxSlugLength || !slugPattern.MatchString(slug) {
+		return apperr.Invalidf("slug", "slug must be at most %d lowercase letters, digits and single hyphens", maxSlugLength)
+	}
+	return nil
+}
+
+// slugify turns a menu name into a URL slug: accents are stripped, letters and
+// digits are lowercased and every other run of characters becomes one hyphen,
+// e.g. "Café & Brunch" becomes "cafe-brunch". Names without any usable
+// character yield "menu".
+func slugify(name string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			continue
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			pendingHyphen = true
+		}
+	}
+
+	// leave room for the numeric suffix added by slugCandidates
+	slug := b.String()
+	if len(slug) > maxSlugLength-10 {
+		slug = strings.TrimRight(slug[:maxSlugLength-10], "-")
+	}
+	if slug == "" {
+		return "menu"
+	}
+	return slug
+}
+
+// slugCandidates lists the slugs tried in turn for a new menu: the base, then
+// base-2 to base-9, and finally the base suffixed with the start of the menu
+// ID, which is practically unique.
+func slugCandidates(base, menuID string) []string {
+	candidates := []string{base}
+	for i := 2; i < 10; i++ {
+		candidates = append(candidates, fmt.Sprintf("%s-%d", base, i))
+	}
+	return append(candidates, base+"-"+strings.SplitN(menuID, "-", 2)[0])
 }
@@ -0,0 +1,300 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/custard-technology/abakcus/backend/internal/validate"
+	"github.com/google/uuid"
+)
+
+type MenuItemService struct {
+	menuRepo     mongo.MenuRepositoryI
+	sectionRepo  mongo.MenuSectionRepositoryI
+	repo         mongo.MenuItemRepositoryI
+	businessRepo mongo.BusinessRepositoryI
+	audit        *AuditService
+}
+
+func NewMenuItemService(menuRepo mongo.MenuRepositoryI, sectionRepo mongo.MenuSectionRepositoryI, repo mongo.MenuItemRepositoryI, businessRepo mongo.BusinessRepositoryI, audit *AuditService) *MenuItemService {
+	return &MenuItemService{menuRepo: menuRepo, sectionRepo: sectionRepo, repo: repo, businessRepo: businessRepo, audit: audit}
+}
+
+// getOwnedMenu loads the parent menu scoped to businessID. A menu owned by
+// another business is reported as not found so callers cannot probe for menu
+// IDs across tenants.
+func getOwnedMenu(ctx context.Context, menuRepo mongo.MenuRepositoryI, menuID, businessID string) (*models.Menu, error) {
+	if menuID == "" {
+		return nil, apperr.Required("menu_id")
+	}
+	if businessID == "" {
+		return nil, apperr.Required("business_id")
+	}
+
+	menu, err := menuRepo.GetMenuByID(ctx, menuID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if menu == nil {
+		return nil, apperr.NotFound("menu")
+	}
+
+	return menu, nil
+}
+
+// Limits of menu item fields. maxMenuItemPrice is in minor units of the
+// item's currency and only guards against typos such as extra zeros.
+const (
+	maxMenuItemPrice    = 100_000_000
+	maxIngredients      = 50
+	maxIngredientLength = 100
+)
+
+// validateMenuItemFields checks the fields of an item that creating and
+// updating it have in common, trimming the text ones.
+func validateMenuItemFields(v *validate.Validator, title, description *string, price models.Money, ingredients []string) {
+	v.Name("title", title, validate.MaxNameLength)
+	v.Text("description", description, validate.MaxTextLength)
+	if err := price.Validate(); err != nil {
+		v.Merge("price", err)
+	} else {
+		v.Check(price.Amount <= maxMenuItemPrice, "price", fmt.Sprintf("price must be at most %d minor units", maxMenuItemPrice))
+	}
+	if len(ingredients) > maxIngredients {
+		v.Addf("ingredients", "ingredients must have at most %d entries", maxIngredients)
+		return
+	}
+	for i := range ingredients {
+		v.Name(fmt.Sprintf("ingredients[%d]", i), &ingredients[i], maxIngredientLength)
+	}
+}
+
+// CreateMenuItem adds an item to the end of its section. A price without a
+// currency is in the business's default currency, and so are modifier deltas
+// without one.
+func (s *MenuItemService) CreateMenuItem(ctx context.Context, menuID string, req *models.CreateMenuItemRequest, businessID string) (*models.MenuItem, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+	if req.Price.Currency == "" && businessID != "" {
+		business, err := s.businessRepo.GetBusinessByID(ctx, businessID)
+		if err != nil {
+			return nil, err
+		}
+		req.Price.Currency = business.DefaultCurrency
+	}
+	var v validate.Validator
+	validateMenuItemFields(&v, &req.Title, &req.Description, req.Price, req.Ingredients)
+	v.URL("image_url", &req.ImageURL)
+	allergens, dietaryTags := normalizeDietaryInfo(&v, req.Allergens, req.DietaryTags)
+	modifierGroups := normalizeModifierGroups(&v, req.ModifierGroups, req.Price.Currency)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	menu, err := getOwnedMenu(ctx, s.menuRepo, menuID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.SectionID != "" {
+		section, err := s.sectionRepo.GetMenuSectionByID(ctx, menu.MenuID, req.SectionID)
+		if err != nil {
+			return nil, err
+		}
+		if section == nil {
+			return nil, apperr.NotFound("menu section")
+		}
+	}
+
+	// new items go to the end of their section
+	existing, err := s.repo.ListMenuItemsByMenu(ctx, menu.MenuID)
+	if err != nil {
+		return nil, err
+	}
+	position := 0
+	for _, other := range existing {
+		if other.SectionID == req.SectionID && other.Position >= position {
+			position = other.Position + 1
+		}
+	}
+
+	ingredients := req.Ingredients
+	if ingredients == nil {
+		ingredients = []string{}
+	}
+
+	item := &models.MenuItem{
+		ItemID:      uuid.New().String(),
+		MenuID:      menu.MenuID,
+		BusinessID:  menu.BusinessID,
+		SectionID:   req.SectionID,
+		Position:    position,
+		Title:       req.Title,
+		Description: req.Description,
+		Price:       req.Price,
+		ImageURL:    req.ImageURL,
+		Ingredients: ingredients,
+		IsActive:    true,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+
+		Allergens:      allergens,
+		DietaryTags:    dietaryTags,
+		ModifierGroups: modifierGroups,
+	}
+
+	err = s.repo.CreateMenuItem(ctx, item)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, businessID, models.AuditCreate, entityRef(models.AuditEntityItem, item.ItemID), menuID, nil, item)
+
+	return item, nil
+}
+
+func (s *MenuItemService) GetMenuItem(ctx context.Context, menuID, itemID, businessID string) (*models.MenuItem, error) {
+	return getOwnedMenuItem(ctx, s.menuRepo, s.repo, menuID, itemID, businessID)
+}
+
+// getOwnedMenuItem fetches an item of a menu owned by businessID.
+func getOwnedMenuItem(ctx context.Context, menuRepo mongo.MenuRepositoryI, repo mongo.MenuItemRepositoryI, menuID, itemID, businessID string) (*models.MenuItem, error) {
+	if itemID == "" {
+		return nil, apperr.Required("item_id")
+	}
+
+	if _, err := getOwnedMenu(ctx, menuRepo, menuID, businessID); err != nil {
+		return nil, err
+	}
+
+	item, err := repo.GetMenuItemByID(ctx, menuID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if item == nil {
+		return nil, apperr.NotFound("menu item")
+	}
+
+	return item, nil
+}
+
+func (s *MenuItemService) UpdateMenuItem(ctx context.Context, menuID, itemID string, req *models.UpdateMenuItemRequest, businessID string) (*models.MenuItem, error) {
+	if itemID == "" {
+		return nil, apperr.Required("item_id")
+	}
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	// the other fields are checked once merged into the item
+	var v validate.Validator
+	v.URL("image_url", &req.ImageURL)
+
+	existing, err := s.GetMenuItem(ctx, menuID, itemID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	before := *existing
+
+	if req.Title != "" {
+		existing.Title = req.Title
+	}
+	if req.Description != "" {
+		existing.Description = req.Description
+	}
+	if req.Price != nil {
+		// a new amount without a currency stays in the item's currency
+		currency := existing.Price.Currency
+		existing.Price = *req.Price
+		if existing.Price.Currency == "" {
+			existing.Price.Currency = currency
+		}
+	}
+	if req.ImageURL != "" && req.ImageURL != existing.ImageURL {
+		// an external image replaces the uploaded one
+		existing.ImageURL = req.ImageURL
+		existing.Image = nil
+	}
+	if req.Ingredients != nil {
+		existing.Ingredients = req.Ingredients
+	}
+	if req.IsActive != nil {
+		existing.IsActive = *req.IsActive
+	}
+	if req.Allergens != nil {
+		existing.Allergens = req.Allergens
+	}
+	if req.DietaryTags != nil {
+		existing.DietaryTags = req.DietaryTags
+	}
+	if req.ModifierGroups != nil {
+		existing.ModifierGroups = req.ModifierGroups
+	}
+	validateMenuItemFields(&v, &existing.Title, &existing.Description, existing.Price, existing.Ingredients)
+	existing.Allergens, existing.DietaryTags = normalizeDietaryInfo(&v, existing.Allergens, existing.DietaryTags)
+	// revalidated on every update so a currency change cannot strand deltas
+	existing.ModifierGroups = normalizeModifierGroups(&v, existing.ModifierGroups, existing.Price.Currency)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+	existing.UpdatedAt = time.Now()
+
+	err = s.repo.UpdateMenuItem(ctx, menuID, itemID, existing)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, businessID, models.AuditUpdate, entityRef(models.AuditEntityItem, itemID), menuID, &before, existing)
+
+	return existing, nil
+}
+
+func (s *MenuItemService) DeleteMenuItem(ctx context.Context, menuID, itemID, businessID string) error {
+	if itemID == "" {
+		return apperr.Required("item_id")
+	}
+
+	existing, err := s.GetMenuItem(ctx, menuID, itemID, businessID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteMenuItem(ctx, menuID, itemID); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, businessID, models.AuditDelete, entityRef(models.AuditEntityItem, itemID), menuID, existing, nil)
+
+	return nil
+}
+
+func (s *MenuItemService) ListMenuItems(ctx context.Context, menuID, businessID string) ([]models.MenuItem, error) {
+	if _, err := getOwnedMenu(ctx, s.menuRepo, menuID, businessID); err != nil {
+		return nil, err
+	}
+
+	items, err := s.repo.ListMenuItemsByMenu(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+
+	if items == nil {
+		items = []models.MenuItem{}
+	}
+
+	return items, nil
+}
+
+// PriceMenuItem prices an item of a menu owned by businessID with the given
+// modifier selections.
+func (s *MenuItemService) PriceMenuItem(ctx context.Context, menuID, itemID string, req *models.PriceRequest, businessID string) (*models.PriceQuote, error) {
+	item, err := s.GetMenuItem(ctx, menuID, itemID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	return priceMenuItem(item, req)
+}
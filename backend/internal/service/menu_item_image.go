@@ -0,0 +1,111 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/custard-technology/abakcus/backend/internal/imaging"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/custard-technology/abakcus/backend/internal/storage"
+)
+
+// MenuItemImageService stores uploaded item photos and their thumbnails.
+type MenuItemImageService struct {
+	menuRepo mongo.MenuRepositoryI
+	repo     mongo.MenuItemRepositoryI
+	store    storage.Storage
+	audit    *AuditService
+	now      func() time.Time
+}
+
+func NewMenuItemImageService(menuRepo mongo.MenuRepositoryI, repo mongo.MenuItemRepositoryI, store storage.Storage, audit *AuditService) *MenuItemImageService {
+	return &MenuItemImageService{menuRepo: menuRepo, repo: repo, store: store, audit: audit, now: time.Now}
+}
+
+// UploadMenuItemImage replaces the image of an item of a menu owned by
+// businessID with data. The content type is sniffed from data, and the
+// original is stored next to thumbnails in imaging.ThumbnailWidths.
+//
+// Every upload is stored under fresh keys, so clients and CDNs may cache
+// image URLs forever. The objects of the previous image are kept because
+// published menu versions may still show them.
+func (s *MenuItemImageService) UploadMenuItemImage(ctx context.Context, menuID, itemID string, data []byte, businessID string) (*models.MenuItem, error) {
+	item, err := getOwnedMenuItem(ctx, s.menuRepo, s.repo, menuID, itemID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := imaging.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	thumbs, err := img.Thumbnails()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := fmt.Sprintf("items/%s/%s/", item.ItemID, uuid.New().String())
+	image := &models.ItemImage{
+		Key:         prefix + "original." + img.Extension(),
+		ContentType: img.ContentType,
+		Width:       img.Width,
+		Height:      img.Height,
+		Size:        int64(len(data)),
+		Thumbnails:  []models.ImageVariant{},
+		UploadedAt:  s.now(),
+	}
+	image.URL = s.store.URL(image.Key)
+
+	var stored []string
+	if err := s.store.Put(ctx, image.Key, bytes.NewReader(data), image.Size, image.ContentType); err != nil {
+		return nil, err
+	}
+	stored = append(stored, image.Key)
+
+	for _, thumb := range thumbs {
+		key := fmt.Sprintf("%sw%d.%s", prefix, thumb.Width, thumb.Extension())
+		if err := s.store.Put(ctx, key, bytes.NewReader(thumb.Data), int64(len(thumb.Data)), thumb.ContentType); err != nil {
+			s.deleteObjects(stored)
+			return nil, err
+		}
+		stored = append(stored, key)
+		image.Thumbnails = append(image.Thumbnails, models.ImageVariant{
+			URL:         s.store.URL(key),
+			Key:         key,
+			ContentType: thumb.ContentType,
+			Width:       thumb.Width,
+			Height:      thumb.Height,
+		})
+	}
+
+	before := *item
+	item.Image = image
+	item.ImageURL = image.URL
+	item.UpdatedAt = s.now()
+	if err := s.repo.UpdateMenuItem(ctx, menuID, itemID, item); err != nil {
+		s.deleteObjects(stored)
+		return nil, err
+	}
+	s.audit.Record(ctx, businessID, models.AuditUpdate, entityRef(models.AuditEntityItem, itemID), menuID, &before, item)
+
+	return item, nil
+}
+
+// deleteObjects removes the objects of a failed upload on a best-effort
+// basis. Failures are only logged and leave orphaned objects behind.
+func (s *MenuItemImageService) deleteObjects(keys []string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			log.Printf("error deleting stored image %s: %v", key, err)
+		}
+	}
+}
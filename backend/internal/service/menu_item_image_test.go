@@ -0,0 +1,128 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/imaging"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/storage"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func newTestImageService(t *testing.T) (*MenuItemImageService, *memory.MenuItemRepository, *storage.MemoryStorage) {
+	t.Helper()
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Dinner", BusinessID: "b1"})
+	itemRepo := memory.NewMenuItemRepository()
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", MenuID: "m1", BusinessID: "b1", Title: "Pizza", Price: eur(900), ImageURL: "https://example.com/old.jpg"})
+	store := storage.NewMemoryStorage("https://media.example.com")
+	return NewMenuItemImageService(menuRepo, itemRepo, store, NewAuditService(memory.NewAuditRepository())), itemRepo, store
+}
+
+func TestUploadMenuItemImage(t *testing.T) {
+	svc, itemRepo, store := newTestImageService(t)
+
+	item, err := svc.UploadMenuItemImage(context.Background(), "m1", "i1", testPNG(t, 700, 350), "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	image := item.Image
+	if image == nil || image.ContentType != "image/png" || image.Width != 700 || image.Height != 350 {
+		t.Fatalf("unexpected image: %+v", image)
+	}
+	if item.ImageURL != image.URL || !strings.HasPrefix(image.URL, "https://media.example.com/items/i1/") {
+		t.Errorf("expected image_url to point at the upload, got %q", item.ImageURL)
+	}
+	if len(image.Thumbnails) != 3 || image.Thumbnails[0].Width != 160 || image.Thumbnails[2].Width != 640 {
+		t.Errorf("unexpected thumbnails: %+v", image.Thumbnails)
+	}
+	if keys := store.Keys(); len(keys) != 4 {
+		t.Errorf("expected original and 3 thumbnails stored, got %v", keys)
+	}
+	if obj, _ := store.Object(image.Key); obj.ContentType != "image/png" {
+		t.Errorf("unexpected stored content type %q", obj.ContentType)
+	}
+	if storedItem(t, itemRepo, "m1", "i1").Image == nil {
+		t.Error("item should have been updated")
+	}
+}
+
+func TestUploadMenuItemImageKeepsPrevious(t *testing.T) {
+	svc, _, store := newTestImageService(t)
+
+	first, err := svc.UploadMenuItemImage(context.Background(), "m1", "i1", testPNG(t, 400, 400), "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	firstKeys := first.Image.Keys()
+
+	second, err := svc.UploadMenuItemImage(context.Background(), "m1", "i1", testPNG(t, 200, 100), "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// published versions may still reference the previous image
+	for _, key := range firstKeys {
+		if _, ok := store.Object(key); !ok {
+			t.Errorf("previous object %s should have been kept", key)
+		}
+	}
+	for _, key := range second.Image.Keys() {
+		if _, ok := store.Object(key); !ok {
+			t.Errorf("new object %s missing", key)
+		}
+	}
+}
+
+func TestUploadMenuItemImageErrors(t *testing.T) {
+	svc, _, store := newTestImageService(t)
+
+	if _, err := svc.UploadMenuItemImage(context.Background(), "m1", "i1", []byte("not an image"), "b1"); !errors.Is(err, imaging.ErrUnsupportedFormat) {
+		t.Errorf("expected ErrUnsupportedFormat, got %v", err)
+	}
+	if _, err := svc.UploadMenuItemImage(context.Background(), "m1", "i1", testPNG(t, 10, 10), "b2"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found for another business, got %v", err)
+	}
+	if _, err := svc.UploadMenuItemImage(context.Background(), "m1", "missing", testPNG(t, 10, 10), "b1"); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected not found for a missing item, got %v", err)
+	}
+	if keys := store.Keys(); len(keys) != 0 {
+		t.Errorf("nothing should have been stored, got %v", keys)
+	}
+}
+
+func TestUpdateMenuItemExternalImageClearsUpload(t *testing.T) {
+	svc, itemRepo, _ := newTestImageService(t)
+	if _, err := svc.UploadMenuItemImage(context.Background(), "m1", "i1", testPNG(t, 10, 10), "b1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	itemSvc := NewMenuItemService(svc.menuRepo, memory.NewMenuSectionRepository(), itemRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+	item, err := itemSvc.UpdateMenuItem(context.Background(), "m1", "i1", &models.UpdateMenuItemRequest{ImageURL: "https://example.com/new.jpg"}, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Image != nil {
+		t.Error("an external image_url should replace the uploaded image")
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+)
+
+func TestCreateMenuItem(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	itemRepo := memory.NewMenuItemRepository()
+	svc := NewMenuItemService(menuRepo, memory.NewMenuSectionRepository(), itemRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+
+	req := &models.CreateMenuItemRequest{
+		Title:       "Margherita",
+		Price:       models.Money{Amount: 950, Currency: "EUR"},
+		Ingredients: []string{"tomato", "mozzarella"},
+	}
+
+	item, err := svc.CreateMenuItem(context.Background(), "m1", req, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if item.MenuID != "m1" {
+		t.Errorf("expected m1, got %s", item.MenuID)
+	}
+	if item.BusinessID != "b1" {
+		t.Errorf("expected b1, got %s", item.BusinessID)
+	}
+	if stored := storedItem(t, itemRepo, "m1", item.ItemID); stored.Title != "Margherita" {
+		t.Errorf("expected the item to be stored, got %+v", stored)
+	}
+}
+
+func TestCreateMenuItemValidation(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	// a business without a default currency leaves the price currency missing
+	svc := NewMenuItemService(menuRepo, memory.NewMenuSectionRepository(), memory.NewMenuItemRepository(), newTestBusinessRepository(t, "b1"), NewAuditService(memory.NewAuditRepository()))
+
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+
+	cases := []*models.CreateMenuItemRequest{
+		{Title: "", Price: models.Money{Amount: 100, Currency: "EUR"}},
+		{Title: "Soup", Price: models.Money{Amount: -100, Currency: "EUR"}},
+		{Title: "Soup", Price: models.Money{Amount: 100}},
+		{Title: "Soup", Price: models.Money{Amount: 100, Currency: "XYZ"}},
+		{Title: "Soup", Price: models.Money{Amount: 100, Currency: "EUR"}, Ingredients: []string{"leek", " "}},
+		{Title: "Soup", Price: models.Money{Amount: maxMenuItemPrice + 1, Currency: "EUR"}},
+		{Title: "Soup", Price: models.Money{Amount: 100, Currency: "EUR"}, ImageURL: "soup.png"},
+		{Title: "Soup\x07", Price: models.Money{Amount: 100, Currency: "EUR"}},
+	}
+	for _, req := range cases {
+		if _, err := svc.CreateMenuItem(context.Background(), "m1", req, "b1"); err == nil {
+			t.Errorf("expected validation error for %+v", req)
+		}
+	}
+}
+
+func TestCreateMenuItemReportsEveryInvalidField(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	svc := NewMenuItemService(menuRepo, memory.NewMenuSectionRepository(), memory.NewMenuItemRepository(), memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+
+	req := &models.CreateMenuItemRequest{
+		Title:       " ",
+		Price:       models.Money{Amount: 100, Currency: "XYZ"},
+		Ingredients: []string{"leek", ""},
+		Allergens:   []string{"eggs", "nuts"},
+		ModifierGroups: []models.ModifierGroup{{
+			Name:      "Extras",
+			MaxSelect: 1,
+			Options: []models.ModifierOption{
+				{Name: "Bacon", PriceDelta: models.Money{Amount: 200}},
+				{Name: strings.Repeat("x", maxModifierNameLength+1), PriceDelta: models.Money{Amount: math.MaxInt64}},
+			},
+		}},
+	}
+	_, err := svc.CreateMenuItem(context.Background(), "m1", req, "b1")
+	if !errors.Is(err, apperr.ErrInvalidFields) {
+		t.Fatalf("expected invalid fields, got %v", err)
+	}
+	want := "title price.currency ingredients[1] allergens[1] modifier_groups[0].options[1].name modifier_groups[0].options[1].price_delta.amount"
+	if got := invalidFields(err); got != want {
+		t.Errorf("expected invalid fields %s, got %s", want, got)
+	}
+}
+
+func TestMenuItemCurrencyDefaults(t *testing.T) {
+	ctx := context.Background()
+	menuRepo := memory.NewMenuRepository()
+	businessRepo := memory.NewBusinessRepository()
+	svc := NewMenuItemService(menuRepo, memory.NewMenuSectionRepository(), memory.NewMenuItemRepository(), businessRepo, NewAuditService(memory.NewAuditRepository()))
+	seedBusinesses(t, businessRepo, &models.Business{BusinessID: "b1", Name: "Test", DefaultCurrency: "GBP"})
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+
+	item, err := svc.CreateMenuItem(ctx, "m1", &models.CreateMenuItemRequest{
+		Title: "Fish and chips",
+		Price: models.Money{Amount: 1250},
+		ModifierGroups: []models.ModifierGroup{{
+			Name:      "Sides",
+			MaxSelect: 1,
+			Options:   []models.ModifierOption{{Name: "Mushy peas", PriceDelta: models.Money{Amount: 150}}},
+		}},
+	}, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Price.Currency != "GBP" {
+		t.Errorf("expected the business's currency, got %s", item.Price)
+	}
+	if delta := item.ModifierGroups[0].Options[0].PriceDelta; delta.Currency != "GBP" {
+		t.Errorf("expected the delta in the business's currency, got %s", delta)
+	}
+
+	// an explicit currency wins, and a new amount keeps the item's currency
+	item, err = svc.CreateMenuItem(ctx, "m1", &models.CreateMenuItemRequest{Title: "Tea", Price: models.Money{Amount: 300, Currency: "EUR"}}, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updated, err := svc.UpdateMenuItem(ctx, "m1", item.ItemID, &models.UpdateMenuItemRequest{Price: &models.Money{Amount: 350}}, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Price != (models.Money{Amount: 350, Currency: "EUR"}) {
+		t.Errorf("expected 3.50 EUR, got %s", updated.Price)
+	}
+}
+
+// invalidFields lists the fields named by an apperr.ErrInvalidFields error,
+// separated by spaces.
+func invalidFields(err error) string {
+	var fields []string
+	for _, f := range apperr.FieldsOf(err) {
+		fields = append(fields, f.Field)
+	}
+	return strings.Join(fields, " ")
+}
+
+func TestMenuItemScopedToBusiness(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	itemRepo := memory.NewMenuItemRepository()
+	svc := NewMenuItemService(menuRepo, memory.NewMenuSectionRepository(), itemRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", MenuID: "m1", BusinessID: "b1", Title: "Tea"})
+
+	if _, err := svc.GetMenuItem(context.Background(), "m1", "i1", "b2"); err == nil {
+		t.Fatal("expected error for another business")
+	}
+	if err := svc.DeleteMenuItem(context.Background(), "m1", "i1", "b2"); err == nil {
+		t.Fatal("expected error for another business")
+	}
+	if _, err := itemRepo.GetMenuItemByID(context.Background(), "m1", "i1"); err != nil {
+		t.Errorf("item should not have been deleted, got %v", err)
+	}
+}
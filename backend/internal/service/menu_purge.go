@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/custard-technology/abakcus/backend/internal/storage"
+)
+
+// MenuPurgeService permanently removes soft-deleted menus together with
+// their sections, items, versions and item images, either on demand or once
+// they are older than the retention period.
+type MenuPurgeService struct {
+	menuRepo    mongo.MenuRepositoryI
+	sectionRepo mongo.MenuSectionRepositoryI
+	itemRepo    mongo.MenuItemRepositoryI
+	versionRepo mongo.MenuVersionRepositoryI
+	store       storage.Storage
+	audit       *AuditService
+	retention   time.Duration
+	now         func() time.Time
+}
+
+func NewMenuPurgeService(menuRepo mongo.MenuRepositoryI, sectionRepo mongo.MenuSectionRepositoryI, itemRepo mongo.MenuItemRepositoryI, versionRepo mongo.MenuVersionRepositoryI, store storage.Storage, audit *AuditService, retention time.Duration) *MenuPurgeService {
+	return &MenuPurgeService{
+		menuRepo:    menuRepo,
+		sectionRepo: sectionRepo,
+		itemRepo:    itemRepo,
+		versionRepo: versionRepo,
+		store:       store,
+		audit:       audit,
+		retention:   retention,
+		now:         time.Now,
+	}
+}
+
+// PurgeMenu hard-deletes a soft-deleted menu. Images, items, sections and
+// versions go first and the menu document last, so a failure half-way leaves
+// a soft-deleted menu that the next run finds and purges again. Live menus
+// are reported as not found.
+func (s *MenuPurgeService) PurgeMenu(ctx context.Context, menuID string) error {
+	if menuID == "" {
+		return apperr.Required("menu_id")
+	}
+
+	menu, err := s.menuRepo.GetDeletedMenu(ctx, menuID)
+	if err != nil {
+		return err
+	}
+	keys, err := s.imageKeys(ctx, menuID)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := s.store.Delete(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	if err := s.itemRepo.DeleteMenuItemsByMenu(ctx, menuID); err != nil {
+		return err
+	}
+	if err := s.sectionRepo.DeleteMenuSectionsByMenu(ctx, menuID); err != nil {
+		return err
+	}
+	if err := s.versionRepo.DeleteMenuVersionsByMenu(ctx, menuID); err != nil {
+		return err
+	}
+	// a restore between the lookup and here leaves the menu live without its
+	// content; the repository refuses to purge it, so it is at least kept
+	if err := s.menuRepo.PurgeMenu(ctx, menuID); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, menu.BusinessID, models.AuditPurge, entityRef(models.AuditEntityMenu, menuID), menuID, menu, nil)
+
+	return nil
+}
+
+// imageKeys returns the storage keys of every image the menu's items use in
+// the draft or in any published version. Replacing an image keeps the old
+// objects because published versions may still show them, so they are only
+// freed here.
+func (s *MenuPurgeService) imageKeys(ctx context.Context, menuID string) ([]string, error) {
+	items, err := s.itemRepo.ListMenuItemsByMenu(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.versionRepo.ListMenuVersions(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+	for _, summary := range versions {
+		// listings leave out the content of each version
+		version, err := s.versionRepo.GetMenuVersion(ctx, menuID, summary.Number)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, version.Items...)
+	}
+
+	seen := map[string]bool{}
+	var keys []string
+	for _, item := range items {
+		if item.Image == nil {
+			continue
+		}
+		for _, key := range item.Image.Keys() {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys, nil
+}
+
+// PurgeExpired purges every menu deleted longer ago than the retention
+// period and returns how many were removed.
+func (s *MenuPurgeService) PurgeExpired(ctx context.Context) (int, error) {
+	menus, err := s.menuRepo.ListDeletedMenus(ctx, s.now().Add(-s.retention))
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	for _, menu := range menus {
+		if err := s.PurgeMenu(ctx, menu.MenuID); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+
+	return purged, nil
+}
+
+// Run calls PurgeExpired every interval until ctx is cancelled. Errors are
+// logged and the next tick tries again.
+func (s *MenuPurgeService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			purged, err := s.PurgeExpired(ctx)
+			if err != nil {
+				log.Printf("menu purge failed after %d menus: %v", purged, err)
+				continue
+			}
+			if purged > 0 {
+				log.Printf("purged %d deleted menus", purged)
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/storage"
+)
+
+func TestPurgeExpired(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	sectionRepo := memory.NewMenuSectionRepository()
+	itemRepo := memory.NewMenuItemRepository()
+	svc := NewMenuPurgeService(menuRepo, sectionRepo, itemRepo, memory.NewMenuVersionRepository(), storage.NewMemoryStorage("https://media.example.com"), newTestAuditService(memory.NewAuditRepository()), 24*time.Hour)
+
+	old := time.Now().Add(-48 * time.Hour)
+	recent := time.Now().Add(-time.Hour)
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "old", Name: "Test", BusinessID: "b1", DeletedAt: &old})
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "recent", Name: "Test", BusinessID: "b1", DeletedAt: &recent})
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "live", Name: "Test", BusinessID: "b1"})
+	seedSections(t, sectionRepo, &models.MenuSection{SectionID: "s1", Name: "Starters", MenuID: "old"})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", Title: "Tea", BusinessID: "b1", MenuID: "old"})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i2", Title: "Tea", BusinessID: "b1", MenuID: "live"})
+
+	purged, err := svc.PurgeExpired(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("expected 1 purged menu, got %d", purged)
+	}
+
+	ctx := context.Background()
+	deleted, err := menuRepo.ListDeletedMenus(ctx, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].MenuID != "recent" {
+		t.Errorf("expected only the recently deleted menu to be kept, got %+v", deleted)
+	}
+	if _, err := sectionRepo.GetMenuSectionByID(ctx, "old", "s1"); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("sections of the purged menu should have been removed, got %v", err)
+	}
+	if _, err := itemRepo.GetMenuItemByID(ctx, "old", "i1"); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("items of the purged menu should have been removed, got %v", err)
+	}
+	if _, err := itemRepo.GetMenuItemByID(ctx, "live", "i2"); err != nil {
+		t.Errorf("items of live menus should be kept, got %v", err)
+	}
+}
+
+func TestPurgeMenuRefusesLiveMenu(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	itemRepo := memory.NewMenuItemRepository()
+	svc := NewMenuPurgeService(menuRepo, memory.NewMenuSectionRepository(), itemRepo, memory.NewMenuVersionRepository(), storage.NewMemoryStorage("https://media.example.com"), newTestAuditService(memory.NewAuditRepository()), time.Hour)
+
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", Title: "Tea", BusinessID: "b1", MenuID: "m1"})
+
+	if err := svc.PurgeMenu(context.Background(), "m1"); err == nil {
+		t.Fatal("expected error purging a live menu")
+	}
+	if _, err := itemRepo.GetMenuItemByID(context.Background(), "m1", "i1"); err != nil {
+		t.Errorf("items of a live menu must not be removed, got %v", err)
+	}
+}
+
+// failingVersionRepository fails to delete versions while fail is set.
+type failingVersionRepository struct {
+	*memory.MenuVersionRepository
+	fail bool
+}
+
+func (r *failingVersionRepository) DeleteMenuVersionsByMenu(ctx context.Context, menuID string) error {
+	if r.fail {
+		return errors.New("versions unavailable")
+	}
+	return r.MenuVersionRepository.DeleteMenuVersionsByMenu(ctx, menuID)
+}
+
+func TestPurgeMenuRemovesImagesAndIsAudited(t *testing.T) {
+	ctx := context.Background()
+	menuRepo := memory.NewMenuRepository()
+	itemRepo := memory.NewMenuItemRepository()
+	versionRepo := &failingVersionRepository{MenuVersionRepository: memory.NewMenuVersionRepository(), fail: true}
+	store := storage.NewMemoryStorage("https://media.example.com")
+	auditRepo := memory.NewAuditRepository()
+	svc := NewMenuPurgeService(menuRepo, memory.NewMenuSectionRepository(), itemRepo, versionRepo, store, newTestAuditService(auditRepo), time.Hour)
+
+	image := func(key string) *models.ItemImage {
+		return &models.ItemImage{Key: key + ".jpg", Thumbnails: []models.ImageVariant{{Key: key + "-w320.webp"}}}
+	}
+	for _, key := range []string{"draft.jpg", "draft-w320.webp", "published.jpg", "published-w320.webp", "other.jpg"} {
+		if err := store.Put(ctx, key, strings.NewReader("img"), 3, "image/jpeg"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	deleted := time.Now()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1", DeletedAt: &deleted})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", Title: "Tea", BusinessID: "b1", MenuID: "m1", Image: image("draft")})
+	// the published version still shows the image the draft replaced
+	version := &models.MenuVersion{VersionID: "v1", MenuID: "m1", BusinessID: "b1", Number: 1, Name: "Test",
+		Items: []models.MenuItem{{ItemID: "i1", Title: "Tea", BusinessID: "b1", MenuID: "m1", Image: image("published")}}}
+	if err := versionRepo.CreateMenuVersion(ctx, version); err != nil {
+		t.Fatal(err)
+	}
+
+	// a failure half-way keeps the menu, so the next run can finish the job
+	if err := svc.PurgeMenu(ctx, "m1"); err == nil {
+		t.Fatal("expected the version failure to be returned")
+	}
+	if _, err := menuRepo.GetDeletedMenu(ctx, "m1"); err != nil {
+		t.Fatalf("expected the menu to survive a failed purge, got %v", err)
+	}
+	if events := auditEvents(t, auditRepo, "b1"); len(events) != 0 {
+		t.Errorf("expected a failed purge not to be audited, got %+v", events)
+	}
+
+	versionRepo.fail = false
+	if err := svc.PurgeMenu(ctx, "m1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := menuRepo.GetDeletedMenu(ctx, "m1"); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("expected the menu to be purged, got %v", err)
+	}
+	if keys := store.Keys(); len(keys) != 1 || keys[0] != "other.jpg" {
+		t.Errorf("expected only the unrelated object to be kept, got %v", keys)
+	}
+
+	events := auditEvents(t, auditRepo, "b1")
+	if len(events) != 1 || events[0].Action != models.AuditPurge || events[0].Entity != "menu:m1" || events[0].MenuID != "m1" {
+		t.Fatalf("expected one purge event for menu m1, got %+v", events)
+	}
+}
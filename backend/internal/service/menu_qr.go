@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/qr"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// MenuQRService renders QR codes that send diners to a menu's public page.
+type MenuQRService struct {
+	menuRepo mongo.MenuRepositoryI
+	baseURL  string
+}
+
+// NewMenuQRService returns a service encoding URLs of the form
+// baseURL + "/" + slug. baseURL must not end with a slash.
+func NewMenuQRService(menuRepo mongo.MenuRepositoryI, baseURL string) *MenuQRService {
+	return &MenuQRService{menuRepo: menuRepo, baseURL: baseURL}
+}
+
+// PublicMenuURL returns the diner-facing URL of the menu with the given slug.
+func (s *MenuQRService) PublicMenuURL(slug string) string {
+	return s.baseURL + "/" + url.PathEscape(slug)
+}
+
+// RenderMenuQR renders the QR code for a menu owned by businessID. The code
+// encodes the menu's public URL, so it is the same whether or not the menu is
+// currently active.
+func (s *MenuQRService) RenderMenuQR(ctx context.Context, menuID, businessID string, opts qr.Options) ([]byte, error) {
+	menu, err := getOwnedMenu(ctx, s.menuRepo, menuID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	if menu.Slug == "" {
+		return nil, apperr.Invalid("slug", "menu slug is required")
+	}
+
+	return qr.Render(s.PublicMenuURL(menu.Slug), opts)
+}
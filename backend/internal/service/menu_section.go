@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/custard-technology/abakcus/backend/internal/validate"
+	"github.com/google/uuid"
+)
+
+type MenuSectionService struct {
+	menuRepo mongo.MenuRepositoryI
+	repo     mongo.MenuSectionRepositoryI
+	itemRepo mongo.MenuItemRepositoryI
+	audit    *AuditService
+}
+
+func NewMenuSectionService(menuRepo mongo.MenuRepositoryI, repo mongo.MenuSectionRepositoryI, itemRepo mongo.MenuItemRepositoryI, audit *AuditService) *MenuSectionService {
+	return &MenuSectionService{menuRepo: menuRepo, repo: repo, itemRepo: itemRepo, audit: audit}
+}
+
+func (s *MenuSectionService) CreateMenuSection(ctx context.Context, menuID string, req *models.CreateMenuSectionRequest, businessID string) (*models.MenuSection, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+	var v validate.Validator
+	v.Name("name", &req.Name, validate.MaxNameLength)
+	if err := v.Err(); err != nil {
+		return nil, err
+	}
+
+	menu, err := getOwnedMenu(ctx, s.menuRepo, menuID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	// new sections go to the end of the menu
+	existing, err := s.repo.ListMenuSectionsByMenu(ctx, menu.MenuID)
+	if err != nil {
+		return nil, err
+	}
+	position := 0
+	for _, other := range existing {
+		if other.Position >= position {
+			position = other.Position + 1
+		}
+	}
+
+	section := &models.MenuSection{
+		SectionID:  uuid.New().String(),
+		MenuID:     menu.MenuID,
+		BusinessID: menu.BusinessID,
+		Name:       req.Name,
+		Position:   position,
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	err = s.repo.CreateMenuSection(ctx, section)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, businessID, models.AuditCreate, entityRef(models.AuditEntitySection, section.SectionID), menuID, nil, section)
+
+	return section, nil
+}
+
+func (s *MenuSectionService) GetMenuSection(ctx context.Context, menuID, sectionID, businessID string) (*models.MenuSection, error) {
+	if sectionID == "" {
+		return nil, apperr.Required("section_id")
+	}
+
+	if _, err := getOwnedMenu(ctx, s.menuRepo, menuID, businessID); err != nil {
+		return nil, err
+	}
+
+	section, err := s.repo.GetMenuSectionByID(ctx, menuID, sectionID)
+	if err != nil {
+		return nil, err
+	}
+	if section == nil {
+		return nil, apperr.NotFound("menu section")
+	}
+
+	return section, nil
+}
+
+func (s *MenuSectionService) UpdateMenuSection(ctx context.Context, menuID, sectionID string, req *models.UpdateMenuSectionRequest, businessID string) (*models.MenuSection, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+	// an empty name leaves the section's name unchanged
+	if req.Name != "" {
+		var v validate.Validator
+		v.Name("name", &req.Name, validate.MaxNameLength)
+		if err := v.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	existing, err := s.GetMenuSection(ctx, menuID, sectionID, businessID)
+	if err != nil {
+		return nil, err
+	}
+	before := *existing
+
+	if req.Name != "" {
+		existing.Name = req.Name
+	}
+	existing.UpdatedAt = time.Now()
+
+	err = s.repo.UpdateMenuSection(ctx, menuID, sectionID, existing)
+	if err != nil {
+		return nil, err
+	}
+	s.audit.Record(ctx, businessID, models.AuditUpdate, entityRef(models.AuditEntitySection, sectionID), menuID, &before, existing)
+
+	return existing, nil
+}
+
+// DeleteMenuSection removes an empty section. Sections that still contain
+// items are refused so items are never orphaned silently; move them with
+// ReorderMenu first.
+func (s *MenuSectionService) DeleteMenuSection(ctx context.Context, menuID, sectionID, businessID string) error {
+	existing, err := s.GetMenuSection(ctx, menuID, sectionID, businessID)
+	if err != nil {
+		return err
+	}
+
+	items, err := s.itemRepo.ListMenuItemsByMenu(ctx, menuID)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if item.SectionID == sectionID {
+			return apperr.Conflict("menu section still has items")
+		}
+	}
+
+	if err := s.repo.DeleteMenuSection(ctx, menuID, sectionID); err != nil {
+		return err
+	}
+	s.audit.Record(ctx, businessID, models.AuditDelete, entityRef(models.AuditEntitySection, sectionID), menuID, existing, nil)
+
+	return nil
+}
+
+func (s *MenuSectionService) ListMenuSections(ctx context.Context, menuID, businessID string) ([]models.MenuSection, error) {
+	if _, err := getOwnedMenu(ctx, s.menuRepo, menuID, businessID); err != nil {
+		return nil, err
+	}
+
+	sections, err := s.repo.ListMenuSectionsByMenu(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sections == nil {
+		sections = []models.MenuSection{}
+	}
+
+	return sections, nil
+}
+
+// ReorderMenu applies a complete menu layout. The request must list every
+// section of the menu and every item exactly once; partial layouts are
+// rejected so positions never collide.
+func (s *MenuSectionService) ReorderMenu(ctx context.Context, menuID string, req *models.MenuOrderRequest, businessID string) (*models.MenuTree, error) {
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	if _, err := getOwnedMenu(ctx, s.menuRepo, menuID, businessID); err != nil {
+		return nil, err
+	}
+
+	sections, err := s.repo.ListMenuSectionsByMenu(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.itemRepo.ListMenuItemsByMenu(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+
+	knownSections := make(map[string]bool, len(sections))
+	for _, section := range sections {
+		knownSections[section.SectionID] = true
+	}
+	knownItems := make(map[string]bool, len(items))
+	for _, item := range items {
+		knownItems[item.ItemID] = true
+	}
+
+	sectionIDs := make([]string, 0, len(req.Sections))
+	var placements []models.ItemPlacement
+	seenSections := make(map[string]bool, len(req.Sections))
+	seenItems := make(map[string]bool, len(items))
+
+	placeItems := func(sectionID string, itemIDs []string) error {
+		for i, itemID := range itemIDs {
+			if !knownItems[itemID] {
+				return apperr.NotFound("menu item")
+			}
+			if seenItems[itemID] {
+				return apperr.Invalid("", "order must not list an item twice")
+			}
+			seenItems[itemID] = true
+			placements = append(placements, models.ItemPlacement{ItemID: itemID, SectionID: sectionID, Position: i})
+		}
+		return nil
+	}
+
+	for _, order := range req.Sections {
+		if !knownSections[order.SectionID] {
+			return nil, apperr.NotFound("menu section")
+		}
+		if seenSections[order.SectionID] {
+			return nil, apperr.Invalid("", "order must not list a section twice")
+		}
+		seenSections[order.SectionID] = true
+		sectionIDs = append(sectionIDs, order.SectionID)
+
+		if err := placeItems(order.SectionID, order.ItemIDs); err != nil {
+			return nil, err
+		}
+	}
+	if err := placeItems("", req.UnsectionedItemIDs); err != nil {
+		return nil, err
+	}
+
+	if len(seenSections) != len(sections) {
+		return nil, apperr.Invalid("", "order must list every section of the menu")
+	}
+	if len(seenItems) != len(items) {
+		return nil, apperr.Invalid("", "order must list every item of the menu")
+	}
+
+	if err := s.repo.ReorderMenuSections(ctx, menuID, sectionIDs); err != nil {
+		return nil, err
+	}
+	if err := s.itemRepo.ReorderMenuItems(ctx, menuID, placements); err != nil {
+		return nil, err
+	}
+
+	return s.GetMenuTree(ctx, menuID, businessID)
+}
+
+// GetMenuTree returns the menu with its sections and items nested in
+// display order. Items without a section are returned in MenuTree.Items.
+func (s *MenuSectionService) GetMenuTree(ctx context.Context, menuID, businessID string) (*models.MenuTree, error) {
+	menu, err := getOwnedMenu(ctx, s.menuRepo, menuID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := s.repo.ListMenuSectionsByMenu(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.itemRepo.ListMenuItemsByMenu(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+
+	return buildMenuTree(menu, sections, items), nil
+}
+
+func buildMenuTree(menu *models.Menu, sections []models.MenuSection, items []models.MenuItem) *models.MenuTree {
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].Position < sections[j].Position })
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Position < items[j].Position })
+
+	tree := &models.MenuTree{
+		Menu:     *menu,
+		Sections: make([]models.MenuSectionTree, 0, len(sections)),
+		Items:    []models.MenuItem{},
+	}
+
+	index := make(map[string]int, len(sections))
+	for i, section := range sections {
+		index[section.SectionID] = i
+		tree.Sections = append(tree.Sections, models.MenuSectionTree{MenuSection: section, Items: []models.MenuItem{}})
+	}
+
+	for _, item := range items {
+		if i, ok := index[item.SectionID]; ok {
+			tree.Sections[i].Items = append(tree.Sections[i].Items, item)
+			continue
+		}
+		tree.Items = append(tree.Items, item)
+	}
+
+	return tree
+}
@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+)
+
+func newTestMenuSectionService(t *testing.T) (*MenuSectionService, *memory.MenuSectionRepository, *memory.MenuItemRepository) {
+	t.Helper()
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+	sectionRepo := memory.NewMenuSectionRepository()
+	itemRepo := memory.NewMenuItemRepository()
+	return NewMenuSectionService(menuRepo, sectionRepo, itemRepo, NewAuditService(memory.NewAuditRepository())), sectionRepo, itemRepo
+}
+
+func TestCreateMenuSectionAppends(t *testing.T) {
+	svc, _, _ := newTestMenuSectionService(t)
+
+	first, err := svc.CreateMenuSection(context.Background(), "m1", &models.CreateMenuSectionRequest{Name: "Starters"}, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := svc.CreateMenuSection(context.Background(), "m1", &models.CreateMenuSectionRequest{Name: "Mains"}, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first.Position != 0 || second.Position != 1 {
+		t.Errorf("expected positions 0 and 1, got %d and %d", first.Position, second.Position)
+	}
+}
+
+func TestReorderMenu(t *testing.T) {
+	svc, sectionRepo, itemRepo := newTestMenuSectionService(t)
+
+	seedSections(t, sectionRepo, &models.MenuSection{SectionID: "s1", Name: "Starters", MenuID: "m1", Position: 0})
+	seedSections(t, sectionRepo, &models.MenuSection{SectionID: "s2", Name: "Mains", MenuID: "m1", Position: 1})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", Title: "Tea", BusinessID: "b1", MenuID: "m1", SectionID: "s1"})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i2", Title: "Tea", BusinessID: "b1", MenuID: "m1", SectionID: "s1", Position: 1})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i3", Title: "Tea", BusinessID: "b1", MenuID: "m1"})
+
+	req := &models.MenuOrderRequest{
+		Sections: []models.SectionOrder{
+			{SectionID: "s2", ItemIDs: []string{"i2", "i3"}},
+			{SectionID: "s1", ItemIDs: []string{"i1"}},
+		},
+	}
+
+	tree, err := svc.ReorderMenu(context.Background(), "m1", req, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(tree.Sections) != 2 || tree.Sections[0].SectionID != "s2" {
+		t.Fatalf("expected s2 first, got %+v", tree.Sections)
+	}
+	if got := tree.Sections[0].Items; len(got) != 2 || got[0].ItemID != "i2" || got[1].ItemID != "i3" {
+		t.Errorf("unexpected items in s2: %+v", got)
+	}
+	if len(tree.Items) != 0 {
+		t.Errorf("expected no unsectioned items, got %d", len(tree.Items))
+	}
+}
+
+func TestReorderMenuRejectsPartialLayout(t *testing.T) {
+	svc, sectionRepo, itemRepo := newTestMenuSectionService(t)
+
+	seedSections(t, sectionRepo, &models.MenuSection{SectionID: "s1", Name: "Starters", MenuID: "m1"})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", Title: "Tea", BusinessID: "b1", MenuID: "m1", SectionID: "s1"})
+
+	req := &models.MenuOrderRequest{Sections: []models.SectionOrder{{SectionID: "s1"}}}
+	if _, err := svc.ReorderMenu(context.Background(), "m1", req, "b1"); err == nil {
+		t.Fatal("expected error when an item is missing from the order")
+	}
+}
+
+func TestDeleteMenuSectionWithItems(t *testing.T) {
+	svc, sectionRepo, itemRepo := newTestMenuSectionService(t)
+
+	seedSections(t, sectionRepo, &models.MenuSection{SectionID: "s1", Name: "Starters", MenuID: "m1"})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", Title: "Tea", BusinessID: "b1", MenuID: "m1", SectionID: "s1"})
+
+	if err := svc.DeleteMenuSection(context.Background(), "m1", "s1", "b1"); err == nil {
+		t.Fatal("expected error when section still has items")
+	}
+}
@@ -2,14 +2,31 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
 	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
 )
 
+// newTestBusinessRepository returns a business repository holding the given
+// businesses.
+func newTestBusinessRepository(t *testing.T, businessIDs ...string) *memory.BusinessRepository {
+	t.Helper()
+	repo := memory.NewBusinessRepository()
+	for _, id := range businessIDs {
+		seedBusinesses(t, repo, &models.Business{BusinessID: id, Name: "Test"})
+	}
+	return repo
+}
+
 func TestCreateMenu(t *testing.T) {
-	mockRepo := NewMockMenuRepository()
-	svc := NewMenuService(mockRepo)
+	menuRepo := memory.NewMenuRepository()
+	svc := NewMenuService(menuRepo, newTestBusinessRepository(t, "biz-123"), NewAuditService(memory.NewAuditRepository()))
 
 	req := &models.CreateMenuRequest{
 		Name:        "Lunch",
@@ -30,13 +47,13 @@ func TestCreateMenu(t *testing.T) {
 }
 
 func TestGetMenu(t *testing.T) {
-	mockRepo := NewMockMenuRepository()
-	svc := NewMenuService(mockRepo)
+	menuRepo := memory.NewMenuRepository()
+	svc := NewMenuService(menuRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
 
 	menu := &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"}
-	mockRepo.SetMenu("m1", menu)
+	seedMenus(t, menuRepo, menu)
 
-	retrieved, err := svc.GetMenu(context.Background(), "m1")
+	retrieved, err := svc.GetMenu(context.Background(), "m1", "b1")
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
@@ -47,17 +64,460 @@ func TestGetMenu(t *testing.T) {
 }
 
 func TestDeleteMenu(t *testing.T) {
-	mockRepo := NewMockMenuRepository()
-	svc := NewMenuService(mockRepo)
+	menuRepo := memory.NewMenuRepository()
+	svc := NewMenuService(menuRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+
+	err := svc.DeleteMenu(context.Background(), "m1", "b1", []int64{AnyRevision})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	deleted, err := menuRepo.ListDeletedMenus(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0].MenuID != "m1" {
+		t.Errorf("menu should have been soft-deleted, got %+v", deleted)
+	}
+	if _, err := svc.GetMenu(context.Background(), "m1", "b1"); err == nil {
+		t.Error("deleted menu should not be readable")
+	}
+	page, _ := svc.ListMenusByBusiness(context.Background(), "b1", models.MenuListOptions{})
+	if len(page.Menus) != 0 {
+		t.Errorf("deleted menu should not be listed, got %d menus", len(page.Menus))
+	}
+}
+
+func TestRestoreMenu(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	auditRepo := memory.NewAuditRepository()
+	svc := NewMenuService(menuRepo, memory.NewBusinessRepository(), NewAuditService(auditRepo))
+
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+	if err := svc.DeleteMenu(context.Background(), "m1", "b1", []int64{AnyRevision}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	menu, err := svc.RestoreMenu(context.Background(), "m1", "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if menu.DeletedAt != nil {
+		t.Error("restored menu should not carry a tombstone")
+	}
+
+	// restoring a live menu returns it unchanged and audits nothing
+	recorded := len(auditEvents(t, auditRepo, "b1"))
+	again, err := svc.RestoreMenu(context.Background(), "m1", "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if again.Revision != menu.Revision {
+		t.Errorf("expected revision %d to be kept, got %d", menu.Revision, again.Revision)
+	}
+	if events := auditEvents(t, auditRepo, "b1"); recorded != 2 || len(events) != recorded {
+		t.Errorf("expected only the delete and restore to be audited, got %+v", events)
+	}
+
+	if _, err := svc.RestoreMenu(context.Background(), "m1", "b2"); err == nil {
+		t.Error("expected error restoring another business's menu")
+	}
+}
+
+func TestMenuOwnershipEnforced(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	svc := NewMenuService(menuRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+
+	if _, err := svc.GetMenu(context.Background(), "m1", "b2"); err == nil {
+		t.Error("expected error reading another business's menu")
+	}
+
+	name := &models.UpdateMenuRequest{Name: "Hijacked"}
+	if _, err := svc.UpdateMenu(context.Background(), "m1", "b2", []int64{AnyRevision}, name); err == nil {
+		t.Error("expected error updating another business's menu")
+	}
+	if storedMenu(t, menuRepo, "m1", "b1").Name != "Test" {
+		t.Error("menu should not have been updated")
+	}
+
+	if err := svc.DeleteMenu(context.Background(), "m1", "b2", []int64{AnyRevision}); err == nil {
+		t.Error("expected error deleting another business's menu")
+	}
+	if _, err := menuRepo.GetMenuByID(context.Background(), "m1", "b1"); err != nil {
+		t.Errorf("menu should not have been deleted, got %v", err)
+	}
+}
+
+// replaceMenuRequest returns a full replacement of an active menu without
+// description or schedule.
+func replaceMenuRequest(name, slug string) *models.UpdateMenuRequest {
+	active := true
+	return &models.UpdateMenuRequest{Name: name, Slug: slug, IsActive: &active}
+}
+
+func TestUpdateMenuReplacesAllFields(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	svc := NewMenuService(menuRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+	seedMenus(t, menuRepo, &models.Menu{
+		MenuID: "m1", Name: "Lunch", Slug: "lunch", Description: "Weekdays", BusinessID: "b1", IsActive: true,
+		Schedule: &models.MenuSchedule{Windows: []models.WeeklyWindow{{Days: []string{"mon"}, Start: "11:00", End: "15:00"}}},
+	})
+
+	menu, err := svc.UpdateMenu(context.Background(), "m1", "b1", []int64{AnyRevision}, replaceMenuRequest("Brunch", "brunch"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stored := storedMenu(t, menuRepo, "m1", "b1")
+	if menu.Description != "" || stored.Description != "" || stored.Schedule != nil {
+		t.Errorf("expected omitted description and schedule to be cleared, got %q and %+v", stored.Description, stored.Schedule)
+	}
+	if stored.Name != "Brunch" || stored.Slug != "brunch" {
+		t.Errorf("expected Brunch at brunch, got %s at %s", stored.Name, stored.Slug)
+	}
+
+	missing := []struct {
+		req  *models.UpdateMenuRequest
+		want string
+	}{
+		{&models.UpdateMenuRequest{Slug: "brunch", IsActive: new(bool)}, "name is required"},
+		{&models.UpdateMenuRequest{Name: "Brunch", IsActive: new(bool)}, "slug is required"},
+		{&models.UpdateMenuRequest{Name: "Brunch", Slug: "brunch"}, "is_active is required"},
+	}
+	for _, m := range missing {
+		if _, err := svc.UpdateMenu(context.Background(), "m1", "b1", []int64{AnyRevision}, m.req); err == nil || !strings.Contains(err.Error(), m.want) {
+			t.Errorf("expected %q, got %v", m.want, err)
+		}
+	}
+}
+
+func TestPatchMenu(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	svc := NewMenuService(menuRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+	seedMenus(t, menuRepo, &models.Menu{
+		MenuID: "m1", Name: "Lunch", Slug: "lunch", Description: "Weekdays", BusinessID: "b1", IsActive: true,
+		Schedule: &models.MenuSchedule{Windows: []models.WeeklyWindow{{Days: []string{"mon"}, Start: "11:00", End: "15:00"}}},
+	})
+
+	menu, err := svc.PatchMenu(context.Background(), "m1", "b1", []int64{AnyRevision}, []byte(`{"description":null,"is_active":false}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if menu.Description != "" || menu.IsActive || menu.Name != "Lunch" || menu.Schedule == nil {
+		t.Errorf("expected only description and is_active to change, got %+v", menu)
+	}
+
+	// nested objects are merged and arrays replaced
+	patch := `{"schedule":{"overrides":[{"from":"2026-12-25","to":"2026-12-25","closed":true}]}}`
+	menu, err = svc.PatchMenu(context.Background(), "m1", "b1", []int64{AnyRevision}, []byte(patch))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(menu.Schedule.Windows) != 1 || len(menu.Schedule.Overrides) != 1 {
+		t.Errorf("expected the windows to stay and an override to be added, got %+v", menu.Schedule)
+	}
+
+	menu, err = svc.PatchMenu(context.Background(), "m1", "b1", []int64{AnyRevision}, []byte(`{"schedule":null}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stored := storedMenu(t, menuRepo, "m1", "b1"); stored.Schedule != nil {
+		t.Errorf("expected the schedule to be cleared, got %+v", stored.Schedule)
+	}
+
+	invalid := map[string]string{
+		`{"name":null}`:      "name is required",
+		`{"slug":null}`:      "slug is required",
+		`{"menu_id":"m2"}`:   "must only change",
+		`{"is_active":"no"}`: "must only change",
+		`["name"]`:           "must be a JSON object",
+	}
+	for patch, want := range invalid {
+		if _, err := svc.PatchMenu(context.Background(), "m1", "b1", []int64{AnyRevision}, []byte(patch)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("%s: expected %q, got %v", patch, want, err)
+		}
+	}
+	if stored := storedMenu(t, menuRepo, "m1", "b1"); stored.Name != "Lunch" || stored.MenuID != "m1" {
+		t.Error("rejected patches should not have been applied")
+	}
+}
 
-	mockRepo.SetMenu("m1", &models.Menu{MenuID: "m1"})
+func TestUpdateMenuRevision(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	svc := NewMenuService(menuRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Lunch", Slug: "lunch", BusinessID: "b1", Revision: 1})
 
-	err := svc.DeleteMenu(context.Background(), "m1")
+	menu, err := svc.UpdateMenu(context.Background(), "m1", "b1", []int64{1}, replaceMenuRequest("Brunch", "lunch"))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	if stored := storedMenu(t, menuRepo, "m1", "b1"); menu.Revision != 2 || stored.Revision != 2 {
+		t.Errorf("expected revision 2, got %d returned and %d stored", menu.Revision, stored.Revision)
+	}
+
+	if _, err := svc.UpdateMenu(context.Background(), "m1", "b1", []int64{1}, replaceMenuRequest("Dinner", "lunch")); !errors.Is(err, ErrMenuModified) {
+		t.Errorf("expected ErrMenuModified, got %v", err)
+	}
+	if err := svc.DeleteMenu(context.Background(), "m1", "b1", []int64{1}); !errors.Is(err, ErrMenuModified) {
+		t.Errorf("expected ErrMenuModified, got %v", err)
+	}
+	if err := svc.DeleteMenu(context.Background(), "m1", "b1", nil); !errors.Is(err, ErrMenuModified) {
+		t.Errorf("no revisions: expected ErrMenuModified, got %v", err)
+	}
+	if stored, err := menuRepo.GetMenuByID(context.Background(), "m1", "b1"); err != nil || stored.Name != "Brunch" {
+		t.Error("stale writes should not have been applied")
+	}
+
+	// any one of several revisions may match
+	menu, err = svc.UpdateMenu(context.Background(), "m1", "b1", []int64{1, 2}, replaceMenuRequest("Dinner", "lunch"))
+	if err != nil || menu.Revision != 3 {
+		t.Errorf("expected revision 3, got %v, %v", menu, err)
+	}
+}
+
+// staleMenuRepository serves a menu as it was before a concurrent write, so
+// the revision only mismatches in the write itself.
+type staleMenuRepository struct {
+	*memory.MenuRepository
+	stale models.Menu
+}
+
+func (r *staleMenuRepository) GetMenuByID(ctx context.Context, menuID, businessID string) (*models.Menu, error) {
+	menu := r.stale
+	return &menu, nil
+}
+
+func TestUpdateMenuConcurrentWrite(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Brunch", Slug: "lunch", BusinessID: "b1", Revision: 2})
+	repo := &staleMenuRepository{MenuRepository: menuRepo, stale: models.Menu{MenuID: "m1", Name: "Lunch", Slug: "lunch", BusinessID: "b1", Revision: 1}}
+	svc := NewMenuService(repo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+
+	if _, err := svc.UpdateMenu(context.Background(), "m1", "b1", []int64{1}, replaceMenuRequest("Dinner", "lunch")); !errors.Is(err, ErrMenuModified) {
+		t.Errorf("expected ErrMenuModified, got %v", err)
+	}
+	if stored := storedMenu(t, menuRepo, "m1", "b1"); stored.Name != "Brunch" {
+		t.Errorf("expected the concurrent write to survive, got %q", stored.Name)
+	}
+}
+
+func TestListMenusPagination(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	svc := NewMenuService(menuRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"m1", "m2", "m3", "m4", "m5"} {
+		seedMenus(t, menuRepo, &models.Menu{
+			MenuID:     id,
+			Name:       "Menu",
+			BusinessID: "b1",
+			IsActive:   i%2 == 0,
+			CreatedAt:  base.Add(time.Duration(i/2) * time.Hour), // pairs share a timestamp
+		})
+	}
+
+	var seen []string
+	opts := models.MenuListOptions{Limit: 2}
+	for {
+		page, err := svc.ListMenusByBusiness(context.Background(), "b1", opts)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, m := range page.Menus {
+			seen = append(seen, m.MenuID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		opts.Cursor = page.NextCursor
+	}
+
+	want := "m1,m2,m3,m4,m5"
+	if got := strings.Join(seen, ","); got != want {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+}
+
+func TestListMenusFiltersAndSort(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	svc := NewMenuService(menuRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Brunch", BusinessID: "b1", IsActive: true})
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m2", Name: "Dinner", BusinessID: "b1", IsActive: true})
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m3", Name: "Archive", BusinessID: "b1"})
+
+	active := true
+	page, err := svc.ListMenusByBusiness(context.Background(), "b1", models.MenuListOptions{
+		IsActive: &active,
+		SortBy:   models.MenuSortName,
+		SortDesc: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.Menus) != 2 || page.Menus[0].Name != "Dinner" || page.Menus[1].Name != "Brunch" {
+		t.Errorf("unexpected page: %+v", page.Menus)
+	}
+}
+
+func TestListMenusRejectsInvalidOptions(t *testing.T) {
+	svc := NewMenuService(memory.NewMenuRepository(), memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+
+	nameCursor := models.NewMenuCursor(models.Menu{MenuID: "m1", Name: "A"}, models.MenuSortName, false).Encode()
+	cases := []models.MenuListOptions{
+		{Limit: -1},
+		{Limit: MaxMenuPageSize + 1},
+		{SortBy: "price"},
+		{Cursor: "not-a-cursor"},
+		{Cursor: nameCursor, SortBy: models.MenuSortCreatedAt},
+	}
+	for _, opts := range cases {
+		if _, err := svc.ListMenusByBusiness(context.Background(), "b1", opts); err == nil {
+			t.Errorf("expected error for %+v", opts)
+		}
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	cases := map[string]string{
+		"Brunch":                "brunch",
+		"Café & Brunch":         "cafe-brunch",
+		"  Summer   Menu 2026 ": "summer-menu-2026",
+		"!!!":                   "menu",
+		"寿司":                    "menu",
+	}
+	for name, want := range cases {
+		if got := slugify(name); got != want {
+			t.Errorf("slugify(%q) = %q, want %q", name, got, want)
+		}
+	}
+
+	if got := slugify(strings.Repeat("a", 200)); len(got) > maxSlugLength {
+		t.Errorf("slug too long: %d characters", len(got))
+	}
+}
+
+func TestCreateMenuUnknownBusiness(t *testing.T) {
+	svc := NewMenuService(memory.NewMenuRepository(), newTestBusinessRepository(t, "biz-123"), NewAuditService(memory.NewAuditRepository()))
+
+	_, err := svc.CreateMenu(context.Background(), &models.CreateMenuRequest{Name: "Lunch"}, "biz-404")
+	if err == nil || err.Error() != "business not found" {
+		t.Errorf("expected business not found, got %v", err)
+	}
+}
+
+func TestCreateMenuSlugs(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	svc := NewMenuService(menuRepo, newTestBusinessRepository(t, "b1", "b2"), NewAuditService(memory.NewAuditRepository()))
+	ctx := context.Background()
+
+	first, err := svc.CreateMenu(ctx, &models.CreateMenuRequest{Name: "Lunch Menu"}, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := svc.CreateMenu(ctx, &models.CreateMenuRequest{Name: "Lunch menu!"}, "b2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Slug != "lunch-menu" || second.Slug != "lunch-menu-2" {
+		t.Errorf("unexpected slugs %q and %q", first.Slug, second.Slug)
+	}
+
+	if _, err := svc.CreateMenu(ctx, &models.CreateMenuRequest{Name: "Other", Slug: "lunch-menu"}, "b1"); !errors.Is(err, mongo.ErrMenuSlugTaken) {
+		t.Errorf("expected slug conflict, got %v", err)
+	}
+	if _, err := svc.CreateMenu(ctx, &models.CreateMenuRequest{Name: "Other", Slug: "Not A Slug"}, "b1"); err == nil {
+		t.Error("expected error for invalid slug")
+	}
+}
+
+func TestUpdateMenuSlug(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	svc := NewMenuService(menuRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "A", Slug: "a", BusinessID: "b1"})
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m2", Name: "B", Slug: "b", BusinessID: "b1"})
+
+	menu, err := svc.PatchMenu(context.Background(), "m1", "b1", []int64{AnyRevision}, []byte(`{"name":"Renamed"}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if menu.Slug != "a" {
+		t.Errorf("renaming must keep the slug, got %q", menu.Slug)
+	}
+
+	if _, err := svc.UpdateMenu(context.Background(), "m1", "b1", []int64{AnyRevision}, replaceMenuRequest("Renamed", "b")); !errors.Is(err, mongo.ErrMenuSlugTaken) {
+		t.Errorf("expected slug conflict, got %v", err)
+	}
+}
+
+func TestActiveMenusAt(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	businessRepo := memory.NewBusinessRepository()
+	seedBusinesses(t, businessRepo, &models.Business{BusinessID: "b1", Name: "Test", Timezone: "America/New_York"})
+	svc := NewMenuService(menuRepo, businessRepo, NewAuditService(memory.NewAuditRepository()))
+
+	weekly := func(days, start, end string) *models.MenuSchedule {
+		return &models.MenuSchedule{Windows: []models.WeeklyWindow{{Days: strings.Split(days, ","), Start: start, End: end}}}
+	}
+	holiday := weekly("mon", "11:00", "15:00")
+	holiday.Overrides = []models.DateOverride{{From: "2026-10-19", To: "2026-10-19", Closed: true}}
+	special := weekly("sun", "10:00", "12:00")
+	special.Overrides = []models.DateOverride{{From: "2026-10-24", To: "2026-10-24", Windows: []models.TimeRange{{Start: "10:00", End: "12:00"}}}}
+	seedMenus(t, menuRepo,
+		&models.Menu{MenuID: "m1", Name: "Lunch", BusinessID: "b1", IsActive: true, PublishedVersion: 1, Schedule: weekly("mon,tue", "11:00", "15:00")},
+		&models.Menu{MenuID: "m2", Name: "Late", BusinessID: "b1", IsActive: true, PublishedVersion: 1, Schedule: weekly("fri", "22:00", "02:00")},
+		&models.Menu{MenuID: "m3", Name: "Drinks", BusinessID: "b1", IsActive: true, PublishedVersion: 2},
+		&models.Menu{MenuID: "m4", Name: "Holiday Lunch", BusinessID: "b1", IsActive: true, PublishedVersion: 1, Schedule: holiday},
+		&models.Menu{MenuID: "m5", Name: "Special", BusinessID: "b1", IsActive: true, PublishedVersion: 1, Schedule: special},
+		// never published and switched off menus are never served
+		&models.Menu{MenuID: "m6", Name: "Draft", BusinessID: "b1", IsActive: true},
+		&models.Menu{MenuID: "m7", Name: "Off", BusinessID: "b1", PublishedVersion: 1},
+		&models.Menu{MenuID: "m8", Name: "Elsewhere", BusinessID: "b2", IsActive: true, PublishedVersion: 1},
+	)
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	tests := []struct {
+		name  string
+		local string
+		want  string
+	}{
+		{"weekly window", "2026-10-20 13:00", "Drinks,Lunch"},
+		{"closed override replaces the weekly window", "2026-10-19 13:00", "Drinks,Lunch"},
+		{"outside every window", "2026-10-19 16:00", "Drinks"},
+		{"overnight window before midnight", "2026-10-23 23:00", "Drinks,Late"},
+		{"overnight window after midnight", "2026-10-24 01:30", "Drinks,Late"},
+		{"overnight window end is exclusive", "2026-10-24 02:00", "Drinks"},
+		{"override hours on a day without windows", "2026-10-24 11:00", "Drinks,Special"},
+		{"weekly window of the overridden menu", "2026-10-25 11:00", "Drinks,Special"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			at, err := time.ParseInLocation("2006-01-02 15:04", tt.local, newYork)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// the service reads t in the business's timezone, whatever its location
+			menus, err := svc.ActiveMenusAt(context.Background(), "b1", at.UTC())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			names := make([]string, len(menus))
+			for i, menu := range menus {
+				names[i] = menu.Name
+			}
+			if got := strings.Join(names, ","); got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
 
-	if _, ok := mockRepo.menus["m1"]; ok {
-		t.Error("menu should have been deleted")
+	if _, err := svc.ActiveMenusAt(context.Background(), "missing", time.Now()); !errors.Is(err, apperr.ErrNotFound) {
+		t.Errorf("expected an unknown business to be not found, got %v", err)
 	}
 }
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// MenuVersionService publishes menu drafts as immutable versions and rolls
+// the published menu back to earlier ones.
+//
+// The menu document with its sections and items is the draft that every
+// edit goes to. Diners only ever see the version the menu points at through
+// PublishedVersion.
+type MenuVersionService struct {
+	menuRepo    mongo.MenuRepositoryI
+	sectionRepo mongo.MenuSectionRepositoryI
+	itemRepo    mongo.MenuItemRepositoryI
+	versionRepo mongo.MenuVersionRepositoryI
+	audit       *AuditService
+	now         func() time.Time
+}
+
+func NewMenuVersionService(menuRepo mongo.MenuRepositoryI, sectionRepo mongo.MenuSectionRepositoryI, itemRepo mongo.MenuItemRepositoryI, versionRepo mongo.MenuVersionRepositoryI, audit *AuditService) *MenuVersionService {
+	return &MenuVersionService{
+		menuRepo:    menuRepo,
+		sectionRepo: sectionRepo,
+		itemRepo:    itemRepo,
+		versionRepo: versionRepo,
+		audit:       audit,
+		now:         time.Now,
+	}
+}
+
+// PublishMenu snapshots the current draft of a menu owned by businessID as a
+// new version and makes it the one diners see. userID is recorded as the
+// publisher.
+func (s *MenuVersionService) PublishMenu(ctx context.Context, menuID, businessID, userID string) (*models.MenuVersion, error) {
+	menu, err := getOwnedMenu(ctx, s.menuRepo, menuID, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	sections, err := s.sectionRepo.ListMenuSectionsByMenu(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.itemRepo.ListMenuItemsByMenu(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.publish(ctx, &models.MenuVersion{
+		MenuID:      menu.MenuID,
+		BusinessID:  menu.BusinessID,
+		Name:        menu.Name,
+		Description: menu.Description,
+		Sections:    sections,
+		Items:       items,
+		PublishedBy: userID,
+	})
+}
+
+// RollbackMenu publishes the content of an earlier version again. The copy
+// gets the next version number with RestoredFrom set, so history is never
+// rewritten and a rollback can itself be rolled back. The draft is left as
+// it is.
+func (s *MenuVersionService) RollbackMenu(ctx context.Context, menuID string, number int, businessID, userID string) (*models.MenuVersion, error) {
+	target, err := s.GetMenuVersion(ctx, menuID, number, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.publish(ctx, &models.MenuVersion{
+		MenuID:       target.MenuID,
+		BusinessID:   target.BusinessID,
+		Name:         target.Name,
+		Description:  target.Description,
+		Sections:     target.Sections,
+		Items:        target.Items,
+		PublishedBy:  userID,
+		RestoredFrom: target.Number,
+	})
+}
+
+// publish stores version under the next free number and points the menu at
+// it. A concurrent publish that takes the same number makes this one fail
+// rather than overwrite it.
+func (s *MenuVersionService) publish(ctx context.Context, version *models.MenuVersion) (*models.MenuVersion, error) {
+	versions, err := s.versionRepo.ListMenuVersions(ctx, version.MenuID)
+	if err != nil {
+		return nil, err
+	}
+
+	version.VersionID = uuid.New().String()
+	version.Number = 1
+	if len(versions) > 0 {
+		version.Number = versions[0].Number + 1
+	}
+	if version.Sections == nil {
+		version.Sections = []models.MenuSection{}
+	}
+	if version.Items == nil {
+		version.Items = []models.MenuItem{}
+	}
+	version.SectionCount = len(version.Sections)
+	version.ItemCount = len(version.Items)
+	version.PublishedAt = s.now()
+
+	if err := s.versionRepo.CreateMenuVersion(ctx, version); err != nil {
+		if errors.Is(err, mongo.ErrMenuVersionExists) {
+			return nil, apperr.Conflict("menu version already exists; another publish finished first, retry")
+		}
+		return nil, err
+	}
+	if err := s.menuRepo.SetMenuPublishedVersion(ctx, version.MenuID, version.BusinessID, version.Number, version.PublishedAt); err != nil {
+		return nil, err
+	}
+
+	// the published version is always the newest one
+	action := models.AuditPublish
+	before := map[string]any{"published_version": version.Number - 1}
+	after := map[string]any{"published_version": version.Number}
+	if version.RestoredFrom > 0 {
+		action = models.AuditRollback
+		after["restored_from"] = version.RestoredFrom
+	}
+	s.audit.Record(ctx, version.BusinessID, action, entityRef(models.AuditEntityMenu, version.MenuID), version.MenuID, before, after)
+
+	return version, nil
+}
+
+// ListMenuVersions returns the versions of a menu owned by businessID,
+// newest first and without their sections and items.
+func (s *MenuVersionService) ListMenuVersions(ctx context.Context, menuID, businessID string) ([]models.MenuVersion, error) {
+	if _, err := getOwnedMenu(ctx, s.menuRepo, menuID, businessID); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.versionRepo.ListMenuVersions(ctx, menuID)
+	if err != nil {
+		return nil, err
+	}
+	if versions == nil {
+		versions = []models.MenuVersion{}
+	}
+
+	return versions, nil
+}
+
+// GetMenuVersion returns a complete version of a menu owned by businessID.
+func (s *MenuVersionService) GetMenuVersion(ctx context.Context, menuID string, number int, businessID string) (*models.MenuVersion, error) {
+	if number < 1 {
+		return nil, apperr.Invalid("number", "version number must be a positive integer")
+	}
+	if _, err := getOwnedMenu(ctx, s.menuRepo, menuID, businessID); err != nil {
+		return nil, err
+	}
+
+	return s.versionRepo.GetMenuVersion(ctx, menuID, number)
+}
+
+// PublishUnversionedMenus publishes the current content of menus created
+// before versioning existed as their version 1, so diners keep seeing them.
+// It returns how many menus were published.
+func (s *MenuVersionService) PublishUnversionedMenus(ctx context.Context, menus []models.Menu) (int, error) {
+	published := 0
+	for _, menu := range menus {
+		sections, err := s.sectionRepo.ListMenuSectionsByMenu(ctx, menu.MenuID)
+		if err != nil {
+			return published, err
+		}
+		items, err := s.itemRepo.ListMenuItemsByMenu(ctx, menu.MenuID)
+		if err != nil {
+			return published, err
+		}
+
+		version := &models.MenuVersion{
+			MenuID:      menu.MenuID,
+			BusinessID:  menu.BusinessID,
+			Name:        menu.Name,
+			Description: menu.Description,
+			Sections:    sections,
+			Items:       items,
+		}
+		if _, err := s.publish(ctx, version); err != nil {
+			return published, err
+		}
+		published++
+	}
+
+	return published, nil
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+)
+
+func newTestVersionService(t *testing.T) (*MenuVersionService, *memory.MenuRepository, *memory.MenuItemRepository) {
+	t.Helper()
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Dinner", Slug: "dinner", BusinessID: "b1", IsActive: true})
+	itemRepo := memory.NewMenuItemRepository()
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", BusinessID: "b1", MenuID: "m1", Title: "Steak", Price: eur(2400), IsActive: true})
+	svc := NewMenuVersionService(menuRepo, memory.NewMenuSectionRepository(), itemRepo, memory.NewMenuVersionRepository(), NewAuditService(memory.NewAuditRepository()))
+	return svc, menuRepo, itemRepo
+}
+
+func TestPublishMenu(t *testing.T) {
+	svc, menuRepo, itemRepo := newTestVersionService(t)
+	published := time.Date(2026, 5, 1, 10, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return published }
+
+	v1, err := svc.PublishMenu(context.Background(), "m1", "b1", "u1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v1.Number != 1 || v1.Name != "Dinner" || v1.ItemCount != 1 || v1.PublishedBy != "u1" {
+		t.Errorf("unexpected version: %+v", v1)
+	}
+	if menu := storedMenu(t, menuRepo, "m1", "b1"); menu.PublishedVersion != 1 || !menu.PublishedAt.Equal(published) {
+		t.Errorf("menu should point at version 1, got %d at %v", menu.PublishedVersion, menu.PublishedAt)
+	}
+
+	// edits after publishing do not change the snapshot
+	editItem(t, itemRepo, "m1", "i1", func(item *models.MenuItem) { item.Title = "Ribeye" })
+	v2, err := svc.PublishMenu(context.Background(), "m1", "b1", "u1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v2.Number != 2 || v2.Items[0].Title != "Ribeye" {
+		t.Errorf("unexpected second version: %+v", v2)
+	}
+	first, err := svc.GetMenuVersion(context.Background(), "m1", 1, "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first.Items[0].Title != "Steak" {
+		t.Errorf("version 1 should be immutable, got %q", first.Items[0].Title)
+	}
+
+	versions, err := svc.ListMenuVersions(context.Background(), "m1", "b1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(versions) != 2 || versions[0].Number != 2 || versions[0].Items != nil {
+		t.Errorf("expected versions newest first without items, got %+v", versions)
+	}
+}
+
+func TestRollbackMenu(t *testing.T) {
+	svc, menuRepo, itemRepo := newTestVersionService(t)
+	if _, err := svc.PublishMenu(context.Background(), "m1", "b1", "u1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	editItem(t, itemRepo, "m1", "i1", func(item *models.MenuItem) { item.Title = "Mistake" })
+	if _, err := svc.PublishMenu(context.Background(), "m1", "b1", "u1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	v3, err := svc.RollbackMenu(context.Background(), "m1", 1, "b1", "u2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if v3.Number != 3 || v3.RestoredFrom != 1 || v3.Items[0].Title != "Steak" || v3.PublishedBy != "u2" {
+		t.Errorf("unexpected rollback version: %+v", v3)
+	}
+	if menu := storedMenu(t, menuRepo, "m1", "b1"); menu.PublishedVersion != 3 {
+		t.Errorf("menu should point at version 3, got %d", menu.PublishedVersion)
+	}
+	if storedItem(t, itemRepo, "m1", "i1").Title != "Mistake" {
+		t.Error("rollback should leave the draft alone")
+	}
+
+	if _, err := svc.RollbackMenu(context.Background(), "m1", 9, "b1", "u2"); err == nil {
+		t.Error("expected error for a missing version")
+	}
+	if _, err := svc.RollbackMenu(context.Background(), "m1", 0, "b1", "u2"); err == nil {
+		t.Error("expected error for version 0")
+	}
+}
+
+func TestMenuVersionsScopedToBusiness(t *testing.T) {
+	svc, _, _ := newTestVersionService(t)
+	if _, err := svc.PublishMenu(context.Background(), "m1", "b1", "u1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := svc.PublishMenu(context.Background(), "m1", "b2", "u9"); err == nil {
+		t.Error("expected error publishing another business's menu")
+	}
+	if _, err := svc.ListMenuVersions(context.Background(), "m1", "b2"); err == nil {
+		t.Error("expected error listing another business's versions")
+	}
+	if _, err := svc.RollbackMenu(context.Background(), "m1", 1, "b2", "u9"); err == nil {
+		t.Error("expected error rolling back another business's menu")
+	}
+}
+
+func TestPublishUnversionedMenus(t *testing.T) {
+	svc, menuRepo, _ := newTestVersionService(t)
+	deleted := time.Now()
+	old := &models.Menu{MenuID: "m2", Name: "Old", BusinessID: "b1", DeletedAt: &deleted}
+	seedMenus(t, menuRepo, old)
+
+	published, err := svc.PublishUnversionedMenus(context.Background(), []models.Menu{*storedMenu(t, menuRepo, "m1", "b1"), *old})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if published != 2 {
+		t.Errorf("expected 2 menus published, got %d", published)
+	}
+	deletedMenus, err := menuRepo.ListDeletedMenus(context.Background(), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(deletedMenus) != 1 || deletedMenus[0].PublishedVersion != 1 {
+		t.Errorf("soft-deleted menus should be versioned too, got %+v", deletedMenus)
+	}
+}
+
+func TestGetPublicMenuServesPublishedVersion(t *testing.T) {
+	svc, menuRepo, itemRepo := newTestVersionService(t)
+	public := NewPublicMenuService(menuRepo, svc.versionRepo, newTestBusinessRepository(t, "b1"))
+
+	if _, err := public.GetPublicMenu(context.Background(), "dinner", models.PublicMenuFilter{}); err == nil || err.Error() != "menu not found" {
+		t.Errorf("expected an unpublished menu to be not found, got %v", err)
+	}
+
+	if _, err := svc.PublishMenu(context.Background(), "m1", "b1", "u1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	editItem(t, itemRepo, "m1", "i1", func(item *models.MenuItem) { item.Title = "Draft only" })
+	editMenu(t, menuRepo, "m1", "b1", func(menu *models.Menu) { menu.Name = "Draft name" })
+
+	menu, err := public.GetPublicMenu(context.Background(), "dinner", models.PublicMenuFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if menu.Name != "Dinner" || len(menu.Items) != 1 || menu.Items[0].Title != "Steak" {
+		t.Errorf("expected the published content, got %+v", menu)
+	}
+
+	// activation applies without publishing
+	editMenu(t, menuRepo, "m1", "b1", func(menu *models.Menu) { menu.IsActive = false })
+	if _, err := public.GetPublicMenu(context.Background(), "dinner", models.PublicMenuFilter{}); err == nil {
+		t.Error("expected a deactivated menu to be hidden")
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/validate"
+	"github.com/google/uuid"
+)
+
+// Limits on modifier groups per item, options per group and the length of
+// their names.
+const (
+	maxModifierGroups     = 20
+	maxModifierOptions    = 50
+	maxModifierNameLength = 100
+	maxPriceQuantity      = 999
+)
+
+// normalizeModifierGroups validates the modifier groups of an item priced in
+// currency, recording problems against paths such as
+// "modifier_groups[0].options[1].name". Missing group and option IDs are
+// generated and option deltas without a currency take the item's. It returns
+// a non-nil slice.
+//
+// Deltas are bounded by maxMenuItemPrice in either direction so that pricing
+// the largest allowed selection and quantity cannot overflow.
+func normalizeModifierGroups(v *validate.Validator, groups []models.ModifierGroup, currency string) []models.ModifierGroup {
+	if len(groups) > maxModifierGroups {
+		v.Addf("modifier_groups", "menu item must have at most %d modifier groups", maxModifierGroups)
+		return []models.ModifierGroup{}
+	}
+
+	normalized := make([]models.ModifierGroup, 0, len(groups))
+	groupIDs := map[string]bool{}
+	for i, group := range groups {
+		field := fmt.Sprintf("modifier_groups[%d]", i)
+		v.Name(field+".name", &group.Name, maxModifierNameLength)
+		if group.GroupID == "" {
+			group.GroupID = uuid.New().String()
+		}
+		if groupIDs[group.GroupID] {
+			v.Add(field+".group_id", "modifier group group_id must be unique")
+		}
+		groupIDs[group.GroupID] = true
+
+		switch {
+		case len(group.Options) == 0:
+			v.Add(field+".options", "modifier group must have at least one option")
+		case len(group.Options) > maxModifierOptions:
+			v.Addf(field+".options", "modifier group must have at most %d options", maxModifierOptions)
+		}
+		v.Check(group.MinSelect >= 0, field+".min_select", "min_select must not be negative")
+		switch {
+		case group.MaxSelect < 1 || group.MaxSelect > len(group.Options):
+			v.Add(field+".max_select", "max_select must be between 1 and the number of options")
+		case group.MinSelect > group.MaxSelect:
+			v.Add(field+".min_select", "min_select must not exceed max_select")
+		}
+
+		options := make([]models.ModifierOption, 0, len(group.Options))
+		optionIDs := map[string]bool{}
+		for j, option := range group.Options {
+			if j >= maxModifierOptions {
+				break
+			}
+			optionField := fmt.Sprintf("%s.options[%d]", field, j)
+			v.Name(optionField+".name", &option.Name, maxModifierNameLength)
+			if option.OptionID == "" {
+				option.OptionID = uuid.New().String()
+			}
+			if optionIDs[option.OptionID] {
+				v.Add(optionField+".option_id", "modifier option option_id must be unique within its group")
+			}
+			optionIDs[option.OptionID] = true
+
+			if option.PriceDelta.Currency == "" {
+				option.PriceDelta.Currency = currency
+			}
+			if option.PriceDelta.Currency != currency {
+				v.Addf(optionField+".price_delta.currency", "price_delta must be in %s like the item price", currency)
+			}
+			if option.PriceDelta.Amount < -maxMenuItemPrice || option.PriceDelta.Amount > maxMenuItemPrice {
+				v.Addf(optionField+".price_delta.amount", "price_delta must be between -%d and %d minor units", maxMenuItemPrice, maxMenuItemPrice)
+			}
+			options = append(options, option)
+		}
+		group.Options = options
+		normalized = append(normalized, group)
+	}
+
+	return normalized
+}
+
+// priceMenuItem prices item with the given modifier selections. Every group's
+// selection rules are enforced, including groups that are not mentioned, which
+// count as nothing selected. The unit price never goes below zero.
+func priceMenuItem(item *models.MenuItem, req *models.PriceRequest) (*models.PriceQuote, error) {
+	if item == nil {
+		return nil, apperr.NotFound("menu item")
+	}
+	if req == nil {
+		return nil, errors.New("request cannot be nil")
+	}
+
+	quantity := req.Quantity
+	if quantity == 0 {
+		quantity = 1
+	}
+	if quantity < 1 || quantity > maxPriceQuantity {
+		return nil, apperr.Invalidf("quantity", "quantity must be between 1 and %d", maxPriceQuantity)
+	}
+
+	selected := make(map[string][]string, len(req.Selections))
+	for _, selection := range req.Selections {
+		if _, ok := selected[selection.GroupID]; ok {
+			return nil, apperr.Invalidf("selections", "selections must list modifier group %q once", selection.GroupID)
+		}
+		selected[selection.GroupID] = selection.OptionIDs
+	}
+
+	currency := item.Price.Currency
+	quote := &models.PriceQuote{
+		ItemID:    item.ItemID,
+		BasePrice: item.Price,
+		Lines:     []models.PriceLine{},
+		Quantity:  quantity,
+	}
+	unit := item.Price.Amount
+
+	for _, group := range item.ModifierGroups {
+		optionIDs := selected[group.GroupID]
+		delete(selected, group.GroupID)
+
+		if len(optionIDs) < group.MinSelect || len(optionIDs) > group.MaxSelect {
+			if group.MinSelect == group.MaxSelect {
+				return nil, apperr.Invalidf("modifier_groups", "modifier group %q must have exactly %d selected", group.Name, group.MinSelect)
+			}
+			return nil, apperr.Invalidf("modifier_groups", "modifier group %q must have between %d and %d selected", group.Name, group.MinSelect, group.MaxSelect)
+		}
+
+		seen := map[string]bool{}
+		for _, optionID := range optionIDs {
+			if seen[optionID] {
+				return nil, apperr.Invalidf("modifier_groups", "modifier group %q options must not be selected twice", group.Name)
+			}
+			seen[optionID] = true
+
+			option := findModifierOption(group, optionID)
+			if option == nil {
+				return nil, apperr.Invalidf("modifier_groups", "modifier group %q selection %q must be one of its options", group.Name, optionID)
+			}
+			unit += option.PriceDelta.Amount
+			quote.Lines = append(quote.Lines, models.PriceLine{
+				GroupID:    group.GroupID,
+				OptionID:   option.OptionID,
+				Name:       option.Name,
+				PriceDelta: option.PriceDelta,
+			})
+		}
+	}
+
+	for groupID := range selected {
+		return nil, apperr.Invalidf("selections", "selections must only name modifier groups of the item, got %q", groupID)
+	}
+
+	if unit < 0 {
+		unit = 0
+	}
+	quote.UnitPrice = models.Money{Amount: unit, Currency: currency}
+	quote.Total = models.Money{Amount: unit * int64(quantity), Currency: currency}
+
+	return quote, nil
+}
+
+func findModifierOption(group models.ModifierGroup, optionID string) *models.ModifierOption {
+	for i := range group.Options {
+		if group.Options[i].OptionID == optionID {
+			return &group.Options[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+	"github.com/custard-technology/abakcus/backend/internal/validate"
+)
+
+func eur(amount int64) models.Money {
+	return models.Money{Amount: amount, Currency: "EUR"}
+}
+
+func newTestModifierItem() *models.MenuItem {
+	return &models.MenuItem{
+		ItemID:     "i1",
+		MenuID:     "m1",
+		BusinessID: "b1",
+		Title:      "Burger",
+		Price:      eur(900),
+		ModifierGroups: []models.ModifierGroup{
+			{
+				GroupID: "size", Name: "Size", MinSelect: 1, MaxSelect: 1,
+				Options: []models.ModifierOption{
+					{OptionID: "s", Name: "S", PriceDelta: eur(0)},
+					{OptionID: "m", Name: "M", PriceDelta: eur(150)},
+					{OptionID: "l", Name: "L", PriceDelta: eur(300)},
+				},
+			},
+			{
+				GroupID: "extras", Name: "Extras", MinSelect: 0, MaxSelect: 2,
+				Options: []models.ModifierOption{
+					{OptionID: "bacon", Name: "Bacon", PriceDelta: eur(120)},
+					{OptionID: "cheese", Name: "Cheese", PriceDelta: eur(80)},
+					{OptionID: "no-bun", Name: "No bun", PriceDelta: eur(-100)},
+				},
+			},
+		},
+	}
+}
+
+func TestPriceMenuItem(t *testing.T) {
+	quote, err := priceMenuItem(newTestModifierItem(), &models.PriceRequest{
+		Selections: []models.ModifierSelection{
+			{GroupID: "size", OptionIDs: []string{"l"}},
+			{GroupID: "extras", OptionIDs: []string{"bacon", "no-bun"}},
+		},
+		Quantity: 2,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if quote.UnitPrice != eur(1220) {
+		t.Errorf("expected unit price 12.20 EUR, got %s", quote.UnitPrice)
+	}
+	if quote.Total != eur(2440) {
+		t.Errorf("expected total 24.40 EUR, got %s", quote.Total)
+	}
+	if len(quote.Lines) != 3 {
+		t.Errorf("expected 3 lines, got %+v", quote.Lines)
+	}
+}
+
+func TestPriceMenuItemRejectsInvalidSelections(t *testing.T) {
+	cases := map[string][]models.ModifierSelection{
+		"required group missing": {{GroupID: "extras", OptionIDs: []string{"bacon"}}},
+		"too many in group":      {{GroupID: "size", OptionIDs: []string{"s", "m"}}},
+		"over max_select":        {{GroupID: "size", OptionIDs: []string{"s"}}, {GroupID: "extras", OptionIDs: []string{"bacon", "cheese", "no-bun"}}},
+		"duplicate option":       {{GroupID: "size", OptionIDs: []string{"s"}}, {GroupID: "extras", OptionIDs: []string{"bacon", "bacon"}}},
+		"unknown option":         {{GroupID: "size", OptionIDs: []string{"xl"}}},
+		"unknown group":          {{GroupID: "size", OptionIDs: []string{"s"}}, {GroupID: "sauce", OptionIDs: []string{"bbq"}}},
+		"group listed twice":     {{GroupID: "size", OptionIDs: []string{"s"}}, {GroupID: "size", OptionIDs: []string{"m"}}},
+	}
+	for name, selections := range cases {
+		if _, err := priceMenuItem(newTestModifierItem(), &models.PriceRequest{Selections: selections}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestNormalizeModifierGroups(t *testing.T) {
+	var v validate.Validator
+	groups := normalizeModifierGroups(&v, []models.ModifierGroup{{
+		Name: " Sauce ", MinSelect: 0, MaxSelect: 1,
+		Options: []models.ModifierOption{{Name: "Aioli", PriceDelta: models.Money{Amount: 50}}},
+	}}, "EUR")
+	if err := v.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if groups[0].GroupID == "" || groups[0].Options[0].OptionID == "" {
+		t.Error("expected generated IDs")
+	}
+	if groups[0].Name != "Sauce" || groups[0].Options[0].PriceDelta.Currency != "EUR" {
+		t.Errorf("unexpected group: %+v", groups[0])
+	}
+
+	long := strings.Repeat("x", maxModifierNameLength+1)
+	invalid := map[string]struct {
+		group  models.ModifierGroup
+		fields string
+	}{
+		"no options":         {models.ModifierGroup{Name: "Size", MaxSelect: 1}, "modifier_groups[0].options modifier_groups[0].max_select"},
+		"max above options":  {models.ModifierGroup{Name: "Size", MaxSelect: 2, Options: []models.ModifierOption{{Name: "S"}}}, "modifier_groups[0].max_select"},
+		"min above max":      {models.ModifierGroup{Name: "Size", MinSelect: 2, MaxSelect: 1, Options: []models.ModifierOption{{Name: "S"}, {Name: "M"}}}, "modifier_groups[0].min_select"},
+		"currency mismatch":  {models.ModifierGroup{Name: "Size", MaxSelect: 1, Options: []models.ModifierOption{{Name: "S", PriceDelta: models.Money{Currency: "USD"}}}}, "modifier_groups[0].options[0].price_delta.currency"},
+		"duplicate optionID": {models.ModifierGroup{Name: "Size", MaxSelect: 1, Options: []models.ModifierOption{{OptionID: "a", Name: "S"}, {OptionID: "a", Name: "M"}}}, "modifier_groups[0].options[1].option_id"},
+		"long names":         {models.ModifierGroup{Name: long, MaxSelect: 1, Options: []models.ModifierOption{{Name: long}}}, "modifier_groups[0].name modifier_groups[0].options[0].name"},
+		"delta too large":    {models.ModifierGroup{Name: "Size", MaxSelect: 1, Options: []models.ModifierOption{{Name: "S", PriceDelta: models.Money{Amount: maxMenuItemPrice + 1}}}}, "modifier_groups[0].options[0].price_delta.amount"},
+		"delta too small":    {models.ModifierGroup{Name: "Size", MaxSelect: 1, Options: []models.ModifierOption{{Name: "S", PriceDelta: models.Money{Amount: math.MinInt64}}}}, "modifier_groups[0].options[0].price_delta.amount"},
+	}
+	for name, tc := range invalid {
+		var v validate.Validator
+		normalizeModifierGroups(&v, []models.ModifierGroup{tc.group}, "EUR")
+		if got := invalidFields(v.Err()); got != tc.fields {
+			t.Errorf("%s: expected invalid fields %q, got %q", name, tc.fields, got)
+		}
+	}
+}
+
+func TestUpdateMenuItemCurrencyChangeRevalidatesModifiers(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", BusinessID: "b1"})
+	itemRepo := memory.NewMenuItemRepository()
+	seedItems(t, itemRepo, newTestModifierItem())
+	svc := NewMenuItemService(menuRepo, memory.NewMenuSectionRepository(), itemRepo, memory.NewBusinessRepository(), NewAuditService(memory.NewAuditRepository()))
+
+	usd := models.Money{Amount: 1000, Currency: "USD"}
+	if _, err := svc.UpdateMenuItem(context.Background(), "m1", "i1", &models.UpdateMenuItemRequest{Price: &usd}, "b1"); err == nil {
+		t.Error("expected error for modifiers priced in the old currency")
+	}
+}
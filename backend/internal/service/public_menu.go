@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+	"github.com/custard-technology/abakcus/backend/internal/validate"
+)
+
+// ErrMenuUnavailable is returned by GetPublicMenu for an active menu whose
+// schedule does not allow serving it right now.
+var ErrMenuUnavailable = apperr.New(apperr.ErrNotFound, "menu is not available at this time")
+
+// PublicMenuService serves menus to diners without authentication. It only
+// exposes the published version of active menus that are currently
+// scheduled, and its active items. Drafts are never served.
+type PublicMenuService struct {
+	menuRepo     mongo.MenuRepositoryI
+	versionRepo  mongo.MenuVersionRepositoryI
+	businessRepo mongo.BusinessRepositoryI
+
+	now func() time.Time
+}
+
+func NewPublicMenuService(menuRepo mongo.MenuRepositoryI, versionRepo mongo.MenuVersionRepositoryI, businessRepo mongo.BusinessRepositoryI) *PublicMenuService {
+	return &PublicMenuService{
+		menuRepo:     menuRepo,
+		versionRepo:  versionRepo,
+		businessRepo: businessRepo,
+		now:          time.Now,
+	}
+}
+
+// GetPublicMenu returns the diner view of the menu with the given slug,
+// keeping only the items that pass filter. Inactive, deleted and never
+// published menus are reported as not found and menus outside their
+// schedule as ErrMenuUnavailable. Sections without a remaining item are left
+// out.
+//
+// UpdatedAt is the later of the publication and the last change to the menu
+// document, whose slug, activation and schedule apply without publishing.
+func (s *PublicMenuService) GetPublicMenu(ctx context.Context, slug string, filter models.PublicMenuFilter) (*models.PublicMenu, error) {
+	if slug == "" {
+		return nil, apperr.Required("slug")
+	}
+	var v validate.Validator
+	excluded := normalizeAllergens(&v, "exclude_allergens", filter.ExcludeAllergens)
+	diets := normalizeDietaryTags(&v, "diet", filter.Diets)
+	// the filter comes from the query string, so an unknown code is a 400
+	// naming the parameter rather than the 422 of an invalid body
+	if fields := apperr.FieldsOf(v.Err()); len(fields) > 0 {
+		return nil, apperr.Invalid(fields[0].Field, fields[0].Message)
+	}
+	keep := func(item models.MenuItem) bool {
+		return item.IsActive && !containsAny(item.Allergens, excluded) && containsAll(item.DietaryTags, diets)
+	}
+
+	menu, err := s.menuRepo.GetMenuBySlug(ctx, slug)
+	if err != nil {
+		return nil, err
+	}
+	if menu == nil || !menu.IsActive || menu.PublishedVersion == 0 {
+		return nil, apperr.NotFound("menu")
+	}
+	// availability is decided by the same rule as the business's list of
+	// active menus, so the two cannot disagree
+	active, err := activeMenusAt(ctx, s.menuRepo, s.businessRepo, menu.BusinessID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(active, func(m models.Menu) bool { return m.MenuID == menu.MenuID }) {
+		return nil, ErrMenuUnavailable
+	}
+
+	version, err := s.versionRepo.GetMenuVersion(ctx, menu.MenuID, menu.PublishedVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedAt := menu.UpdatedAt
+	if version.PublishedAt.After(updatedAt) {
+		updatedAt = version.PublishedAt
+	}
+
+	tree := buildMenuTree(menu, version.Sections, version.Items)
+	public := &models.PublicMenu{
+		Slug:        menu.Slug,
+		Name:        version.Name,
+		Description: version.Description,
+		Schedule:    menu.Schedule,
+		UpdatedAt:   updatedAt,
+		Sections:    []models.PublicMenuSection{},
+		Items:       publicMenuItems(tree.Items, keep),
+	}
+	for _, section := range tree.Sections {
+		sectionItems := publicMenuItems(section.Items, keep)
+		if len(sectionItems) == 0 {
+			continue
+		}
+		public.Sections = append(public.Sections, models.PublicMenuSection{Name: section.Name, Items: sectionItems})
+	}
+
+	return public, nil
+}
+
+// publicMenuItems returns the diner view of the items accepted by keep,
+// keeping order.
+func publicMenuItems(items []models.MenuItem, keep func(models.MenuItem) bool) []models.PublicMenuItem {
+	public := []models.PublicMenuItem{}
+	for _, item := range items {
+		if !keep(item) {
+			continue
+		}
+		ingredients := item.Ingredients
+		if ingredients == nil {
+			ingredients = []string{}
+		}
+		allergens := item.Allergens
+		if allergens == nil {
+			allergens = []string{}
+		}
+		dietaryTags := item.DietaryTags
+		if dietaryTags == nil {
+			dietaryTags = []string{}
+		}
+		modifierGroups := item.ModifierGroups
+		if modifierGroups == nil {
+			modifierGroups = []models.ModifierGroup{}
+		}
+		public = append(public, models.PublicMenuItem{
+			Title:          item.Title,
+			Description:    item.Description,
+			Price:          item.Price,
+			ImageURL:       item.ImageURL,
+			Image:          item.Image,
+			Ingredients:    ingredients,
+			Allergens:      allergens,
+			DietaryTags:    dietaryTags,
+			ModifierGroups: modifierGroups,
+		})
+	}
+	return public
+}
+
+func containsAny(values, wanted []string) bool {
+	for _, w := range wanted {
+		for _, v := range values {
+			if v == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsAll(values, wanted []string) bool {
+	for _, w := range wanted {
+		found := false
+		for _, v := range values {
+			if v == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/memory"
+)
+
+// newPublishedMenuService publishes every live menu of business b1 at
+// publishedAt and returns a public service reading the published versions.
+func newPublishedMenuService(t *testing.T, menuRepo *memory.MenuRepository, sectionRepo *memory.MenuSectionRepository, itemRepo *memory.MenuItemRepository, businessRepo *memory.BusinessRepository, publishedAt time.Time) *PublicMenuService {
+	t.Helper()
+	versionRepo := memory.NewMenuVersionRepository()
+	versions := NewMenuVersionService(menuRepo, sectionRepo, itemRepo, versionRepo, NewAuditService(memory.NewAuditRepository()))
+	versions.now = func() time.Time { return publishedAt }
+	menus, err := menuRepo.ListMenusByBusiness(context.Background(), "b1", models.MenuListOptions{})
+	if err != nil {
+		t.Fatalf("listing menus: %v", err)
+	}
+	for _, menu := range menus {
+		if _, err := versions.PublishMenu(context.Background(), menu.MenuID, menu.BusinessID, "u1"); err != nil {
+			t.Fatalf("publishing %s: %v", menu.MenuID, err)
+		}
+	}
+	return NewPublicMenuService(menuRepo, versionRepo, businessRepo)
+}
+
+func TestGetPublicMenu(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Dinner", Slug: "dinner", BusinessID: "b1", IsActive: true, UpdatedAt: base})
+	sectionRepo := memory.NewMenuSectionRepository()
+	seedSections(t, sectionRepo, &models.MenuSection{SectionID: "s1", MenuID: "m1", Name: "Mains", Position: 0, UpdatedAt: base})
+	seedSections(t, sectionRepo, &models.MenuSection{SectionID: "s2", MenuID: "m1", Name: "Empty", Position: 1, UpdatedAt: base})
+	itemRepo := memory.NewMenuItemRepository()
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", BusinessID: "b1", MenuID: "m1", SectionID: "s1", Title: "Steak", IsActive: true, UpdatedAt: base})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i2", BusinessID: "b1", MenuID: "m1", SectionID: "s2", Title: "Hidden", UpdatedAt: base.Add(time.Hour)})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i3", BusinessID: "b1", MenuID: "m1", Title: "Bread", IsActive: true, UpdatedAt: base})
+
+	svc := newPublishedMenuService(t, menuRepo, sectionRepo, itemRepo, newTestBusinessRepository(t, "b1"), base.Add(2*time.Hour))
+	menu, err := svc.GetPublicMenu(context.Background(), "dinner", models.PublicMenuFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(menu.Sections) != 1 || menu.Sections[0].Name != "Mains" || len(menu.Sections[0].Items) != 1 {
+		t.Errorf("expected only the Mains section with one item, got %+v", menu.Sections)
+	}
+	if len(menu.Items) != 1 || menu.Items[0].Title != "Bread" {
+		t.Errorf("unexpected unsectioned items: %+v", menu.Items)
+	}
+	if !menu.UpdatedAt.Equal(base.Add(2 * time.Hour)) {
+		t.Errorf("expected UpdatedAt to follow the publication, got %v", menu.UpdatedAt)
+	}
+}
+
+func TestGetPublicMenuHidesInactiveAndDeleted(t *testing.T) {
+	deletedAt := time.Now()
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", Slug: "draft", BusinessID: "b1"})
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m2", Name: "Test", Slug: "gone", BusinessID: "b1", IsActive: true, DeletedAt: &deletedAt})
+
+	svc := newPublishedMenuService(t, menuRepo, memory.NewMenuSectionRepository(), memory.NewMenuItemRepository(), newTestBusinessRepository(t, "b1"), time.Now())
+	for _, slug := range []string{"draft", "gone", "missing"} {
+		if _, err := svc.GetPublicMenu(context.Background(), slug, models.PublicMenuFilter{}); err == nil || err.Error() != "menu not found" {
+			t.Errorf("%s: expected menu not found, got %v", slug, err)
+		}
+	}
+}
+
+func TestGetPublicMenuDietaryFilter(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", Slug: "lunch", BusinessID: "b1", IsActive: true})
+	itemRepo := memory.NewMenuItemRepository()
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i1", BusinessID: "b1", MenuID: "m1", Title: "Pizza", IsActive: true, Position: 0,
+		Allergens: []string{"gluten", "milk"}, DietaryTags: []string{"vegetarian"}})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i2", BusinessID: "b1", MenuID: "m1", Title: "Salad", IsActive: true, Position: 1,
+		Allergens: []string{"mustard"}, DietaryTags: []string{"gluten-free", "vegan", "vegetarian"}})
+	seedItems(t, itemRepo, &models.MenuItem{ItemID: "i3", BusinessID: "b1", MenuID: "m1", Title: "Satay", IsActive: true, Position: 2,
+		Allergens: []string{"peanuts"}, DietaryTags: []string{"halal"}})
+
+	svc := newPublishedMenuService(t, menuRepo, memory.NewMenuSectionRepository(), itemRepo, newTestBusinessRepository(t, "b1"), time.Now())
+
+	cases := []struct {
+		filter models.PublicMenuFilter
+		want   []string
+	}{
+		{models.PublicMenuFilter{}, []string{"Pizza", "Salad", "Satay"}},
+		{models.PublicMenuFilter{ExcludeAllergens: []string{"milk", "Peanuts"}}, []string{"Salad"}},
+		{models.PublicMenuFilter{Diets: []string{"vegetarian"}}, []string{"Pizza", "Salad"}},
+		{models.PublicMenuFilter{Diets: []string{"vegetarian", "gluten-free"}}, []string{"Salad"}},
+	}
+	for _, tc := range cases {
+		menu, err := svc.GetPublicMenu(context.Background(), "lunch", tc.filter)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var got []string
+		for _, item := range menu.Items {
+			got = append(got, item.Title)
+		}
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("%+v: expected %v, got %v", tc.filter, tc.want, got)
+		}
+	}
+
+	if _, err := svc.GetPublicMenu(context.Background(), "lunch", models.PublicMenuFilter{Diets: []string{"keto"}}); err == nil {
+		t.Error("expected error for unknown diet")
+	}
+}
+
+func TestGetPublicMenuOutsideSchedule(t *testing.T) {
+	menuRepo := memory.NewMenuRepository()
+	seedMenus(t, menuRepo, &models.Menu{MenuID: "m1", Name: "Test", Slug: "breakfast", BusinessID: "b1", IsActive: true,
+		Schedule: &models.MenuSchedule{Windows: []models.WeeklyWindow{{Days: []string{"sat", "sun"}, Start: "08:00", End: "11:00"}}}})
+	businessRepo := memory.NewBusinessRepository()
+	seedBusinesses(t, businessRepo, &models.Business{BusinessID: "b1", Name: "Test", Timezone: "Europe/Lisbon"})
+
+	svc := newPublishedMenuService(t, menuRepo, memory.NewMenuSectionRepository(), memory.NewMenuItemRepository(), businessRepo, time.Now())
+
+	// Saturday 09:00 in Lisbon (UTC+1 in summer)
+	svc.now = func() time.Time { return time.Date(2026, 6, 6, 8, 0, 0, 0, time.UTC) }
+	if _, err := svc.GetPublicMenu(context.Background(), "breakfast", models.PublicMenuFilter{}); err != nil {
+		t.Errorf("expected the menu during breakfast, got %v", err)
+	}
+
+	svc.now = func() time.Time { return time.Date(2026, 6, 6, 12, 0, 0, 0, time.UTC) }
+	if _, err := svc.GetPublicMenu(context.Background(), "breakfast", models.PublicMenuFilter{}); err != ErrMenuUnavailable {
+		t.Errorf("expected ErrMenuUnavailable, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/custard-technology/abakcus/backend/internal/models"
+	"github.com/custard-technology/abakcus/backend/internal/repository/mongo"
+)
+
+// The service tests run against the in-memory repositories. The helpers
+// below store and read fixtures through the repository interfaces, so tests
+// never share values with the repositories they exercise.
+
+func seedMenus(t *testing.T, repo mongo.MenuRepositoryI, menus ...*models.Menu) {
+	t.Helper()
+	for _, menu := range menus {
+		if err := repo.CreateMenu(context.Background(), menu); err != nil {
+			t.Fatalf("seeding menu %s: %v", menu.MenuID, err)
+		}
+	}
+}
+
+func seedSections(t *testing.T, repo mongo.MenuSectionRepositoryI, sections ...*models.MenuSection) {
+	t.Helper()
+	for _, section := range sections {
+		if err := repo.CreateMenuSection(context.Background(), section); err != nil {
+			t.Fatalf("seeding section %s: %v", section.SectionID, err)
+		}
+	}
+}
+
+func seedItems(t *testing.T, repo mongo.MenuItemRepositoryI, items ...*models.MenuItem) {
+	t.Helper()
+	for _, item := range items {
+		if err := repo.CreateMenuItem(context.Background(), item); err != nil {
+			t.Fatalf("seeding item %s: %v", item.ItemID, err)
+		}
+	}
+}
+
+func seedBusinesses(t *testing.T, repo mongo.BusinessRepositoryI, businesses ...*models.Business) {
+	t.Helper()
+	for _, business := range businesses {
+		if err := repo.CreateBusiness(context.Background(), business); err != nil {
+			t.Fatalf("seeding business %s: %v", business.BusinessID, err)
+		}
+	}
+}
+
+// storedMenu reads a live menu back from repo.
+func storedMenu(t *testing.T, repo mongo.MenuRepositoryI, menuID, businessID string) *models.Menu {
+	t.Helper()
+	menu, err := repo.GetMenuByID(context.Background(), menuID, businessID)
+	if err != nil {
+		t.Fatalf("loading menu %s: %v", menuID, err)
+	}
+	return menu
+}
+
+// storedItem reads an item back from repo.
+func storedItem(t *testing.T, repo mongo.MenuItemRepositoryI, menuID, itemID string) *models.MenuItem {
+	t.Helper()
+	item, err := repo.GetMenuItemByID(context.Background(), menuID, itemID)
+	if err != nil {
+		t.Fatalf("loading item %s: %v", itemID, err)
+	}
+	return item
+}
+
+// editMenu changes a stored menu behind the services' back, as another
+// writer would.
+func editMenu(t *testing.T, repo mongo.MenuRepositoryI, menuID, businessID string, edit func(*models.Menu)) {
+	t.Helper()
+	menu := storedMenu(t, repo, menuID, businessID)
+	edit(menu)
+	if err := repo.UpdateMenu(context.Background(), menuID, businessID, menu); err != nil {
+		t.Fatalf("editing menu %s: %v", menuID, err)
+	}
+}
+
+// editItem changes a stored item behind the services' back.
+func editItem(t *testing.T, repo mongo.MenuItemRepositoryI, menuID, itemID string, edit func(*models.MenuItem)) {
+	t.Helper()
+	item := storedItem(t, repo, menuID, itemID)
+	edit(item)
+	if err := repo.UpdateMenuItem(context.Background(), menuID, itemID, item); err != nil {
+		t.Fatalf("editing item %s: %v", itemID, err)
+	}
+}
+
+// auditEvents returns every event recorded for a business, newest first.
+func auditEvents(t *testing.T, repo mongo.AuditRepositoryI, businessID string) []models.AuditEvent {
+	t.Helper()
+	events, err := repo.ListAuditEvents(context.Background(), businessID, models.AuditListOptions{})
+	if err != nil {
+		t.Fatalf("listing audit events: %v", err)
+	}
+	return events
+}
+
+// newTestAuditService records into repo with a clock that moves a
+// millisecond per event, so events recorded in quick succession keep their
+// order at the millisecond precision the repositories store.
+func newTestAuditService(repo mongo.AuditRepositoryI) *AuditService {
+	svc := NewAuditService(repo)
+	var mu sync.Mutex
+	clock := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		clock = clock.Add(time.Millisecond)
+		return clock
+	}
+	return svc
+}
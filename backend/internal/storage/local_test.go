@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	root := t.TempDir()
+	store, err := NewLocalStorage(root, "http://localhost:8080/media/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "items/i1/a/w160.jpg", strings.NewReader("jpeg"), 4, "image/jpeg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(root, "items", "i1", "a", "w160.jpg"))
+	if err != nil || string(data) != "jpeg" {
+		t.Fatalf("unexpected stored data %q: %v", data, err)
+	}
+	if got := store.URL("items/i1/a/w160.jpg"); got != "http://localhost:8080/media/items/i1/a/w160.jpg" {
+		t.Errorf("unexpected URL %q", got)
+	}
+
+	if err := store.Delete(ctx, "items/i1/a/w160.jpg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "items", "i1", "a", "w160.jpg")); !os.IsNotExist(err) {
+		t.Errorf("expected file to be deleted, got %v", err)
+	}
+	if err := store.Delete(ctx, "items/i1/a/w160.jpg"); err != nil {
+		t.Errorf("deleting a missing object should succeed, got %v", err)
+	}
+}
+
+func TestLocalStorageRejectsUnsafeKeys(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir(), "http://localhost:8080/media")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"", "/etc/passwd", "../outside", "items/../../outside", "items//a", "items\\a", "items/"} {
+		if err := store.Put(context.Background(), key, strings.NewReader("x"), 1, "text/plain"); err == nil {
+			t.Errorf("expected error for key %q", key)
+		}
+		if err := store.Delete(context.Background(), key); err == nil {
+			t.Errorf("expected delete error for key %q", key)
+		}
+	}
+}
+
+func TestLocalStorageHandler(t *testing.T) {
+	store, err := NewLocalStorage(t.TempDir(), "http://localhost:8080/media")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Put(context.Background(), "items/i1/original.png", strings.NewReader("\x89PNG\r\n\x1a\n"), 8, "image/png"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	handler := http.StripPrefix("/media/", store.Handler())
+
+	req := httptest.NewRequest(http.MethodGet, "/media/items/i1/original.png", nil)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	if w.Header().Get("Cache-Control") != CacheControl {
+		t.Errorf("unexpected Cache-Control %q", w.Header().Get("Cache-Control"))
+	}
+
+	for _, path := range []string{"/media/", "/media/items/", "/media/items/i1/"} {
+		req = httptest.NewRequest(http.MethodGet, path, nil)
+		w = httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404 for a directory, got %d", path, w.Code)
+		}
+	}
+}
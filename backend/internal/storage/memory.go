@@ -0,0 +1,87 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MemoryStorage keeps objects in memory. It is meant for tests, which can
+// inspect what was stored through Object and Keys.
+type MemoryStorage struct {
+	baseURL string
+
+	mu      sync.RWMutex
+	objects map[string]Object
+}
+
+// Object is an object held by MemoryStorage.
+type Object struct {
+	Data        []byte
+	ContentType string
+}
+
+// NewMemoryStorage returns an empty store whose URLs start with baseURL.
+func NewMemoryStorage(baseURL string) *MemoryStorage {
+	return &MemoryStorage{baseURL: strings.TrimRight(baseURL, "/"), objects: map[string]Object{}}
+}
+
+func (s *MemoryStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.objects[key] = Object{Data: data, ContentType: contentType}
+	return nil
+}
+
+func (s *MemoryStorage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.objects, key)
+	return nil
+}
+
+func (s *MemoryStorage) URL(key string) string {
+	return s.baseURL + "/" + key
+}
+
+// Object returns a copy of the object stored under key.
+func (s *MemoryStorage) Object(key string) (Object, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	obj, ok := s.objects[key]
+	if !ok {
+		return Object{}, false
+	}
+	obj.Data = append([]byte(nil), obj.Data...)
+	return obj, true
+}
+
+// Keys returns the keys of every stored object in order.
+func (s *MemoryStorage) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.objects))
+	for key := range s.objects {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestMemoryStorage(t *testing.T) {
+	store := NewMemoryStorage("https://media.example.com/")
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "items/i1/a/w160.jpg", strings.NewReader("jpeg"), 4, "image/jpeg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	obj, ok := store.Object("items/i1/a/w160.jpg")
+	if !ok || string(obj.Data) != "jpeg" || obj.ContentType != "image/jpeg" {
+		t.Fatalf("unexpected stored object %+v", obj)
+	}
+	obj.Data[0] = 'J'
+	if again, _ := store.Object("items/i1/a/w160.jpg"); string(again.Data) != "jpeg" {
+		t.Errorf("changing a returned object altered the store: %q", again.Data)
+	}
+	if got := store.URL("items/i1/a/w160.jpg"); got != "https://media.example.com/items/i1/a/w160.jpg" {
+		t.Errorf("unexpected URL %q", got)
+	}
+	if err := store.Put(ctx, "../escape", strings.NewReader("x"), 1, "text/plain"); err == nil {
+		t.Error("expected an error for a key escaping the root")
+	}
+
+	if err := store.Delete(ctx, "items/i1/a/w160.jpg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if keys := store.Keys(); !slices.Equal(keys, []string{}) {
+		t.Errorf("expected no objects left, got %v", keys)
+	}
+	if err := store.Delete(ctx, "items/i1/a/w160.jpg"); err != nil {
+		t.Errorf("deleting a missing object should succeed, got %v", err)
+	}
+}
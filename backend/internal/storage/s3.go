@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Options configures an S3Storage.
+type S3Options struct {
+	// Endpoint is the host[:port] of the S3 API, e.g. s3.eu-west-1.amazonaws.com
+	// or localhost:9000 for MinIO.
+	Endpoint  string
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+	// PublicURL is the address objects are downloaded from, such as a CDN in
+	// front of the bucket. It defaults to the path-style bucket URL.
+	PublicURL string
+}
+
+// S3Storage keeps objects in a bucket of any S3-compatible service. The
+// bucket must exist and allow public reads of the stored objects.
+type S3Storage struct {
+	client    *minio.Client
+	bucket    string
+	publicURL string
+}
+
+// NewS3Storage connects to the endpoint in opts. It does not contact the
+// service; the first Put reports unreachable endpoints or bad credentials.
+func NewS3Storage(opts S3Options) (*S3Storage, error) {
+	if opts.Endpoint == "" {
+		return nil, errors.New("S3 endpoint is required")
+	}
+	if opts.Bucket == "" {
+		return nil, errors.New("S3 bucket is required")
+	}
+
+	client, err := minio.New(opts.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(opts.AccessKey, opts.SecretKey, ""),
+		Secure: opts.UseSSL,
+		Region: opts.Region,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publicURL := opts.PublicURL
+	if publicURL == "" {
+		scheme := "http"
+		if opts.UseSSL {
+			scheme = "https"
+		}
+		publicURL = scheme + "://" + opts.Endpoint + "/" + opts.Bucket
+	}
+
+	return &S3Storage{client: client, bucket: opts.Bucket, publicURL: strings.TrimRight(publicURL, "/")}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	_, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType:  contentType,
+		CacheControl: CacheControl,
+	})
+	return err
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	// deleting a missing object succeeds, as with LocalStorage
+	return s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+}
+
+func (s *S3Storage) URL(key string) string {
+	return s.publicURL + "/" + key
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestS3Storage runs against a real S3-compatible service when
+// S3_TEST_ENDPOINT is set, e.g. a local MinIO:
+//
+//	docker run -p 9000:9000 minio/minio server /data
+//	S3_TEST_ENDPOINT=localhost:9000 S3_TEST_BUCKET=test \
+//	S3_TEST_ACCESS_KEY=minioadmin S3_TEST_SECRET_KEY=minioadmin go test ./internal/storage
+//
+// The bucket must exist and allow anonymous reads.
+func TestS3Storage(t *testing.T) {
+	endpoint := os.Getenv("S3_TEST_ENDPOINT")
+	if endpoint == "" {
+		t.Skip("S3_TEST_ENDPOINT not set")
+	}
+
+	store, err := NewS3Storage(S3Options{
+		Endpoint:  endpoint,
+		Bucket:    os.Getenv("S3_TEST_BUCKET"),
+		AccessKey: os.Getenv("S3_TEST_ACCESS_KEY"),
+		SecretKey: os.Getenv("S3_TEST_SECRET_KEY"),
+		UseSSL:    os.Getenv("S3_TEST_USE_SSL") == "true",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	key := "test/" + uuid.New().String() + "/w160.jpg"
+	if err := store.Put(ctx, key, strings.NewReader("jpeg"), 4, "image/jpeg"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	resp, err := http.Get(store.URL(key))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || string(body) != "jpeg" {
+		t.Errorf("unexpected download: %d %q", resp.StatusCode, body)
+	}
+	if resp.Header.Get("Content-Type") != "image/jpeg" || resp.Header.Get("Cache-Control") != CacheControl {
+		t.Errorf("unexpected headers: %v", resp.Header)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("deleting a missing object should succeed, got %v", err)
+	}
+}
+
+func TestS3StorageURL(t *testing.T) {
+	store, err := NewS3Storage(S3Options{Endpoint: "localhost:9000", Bucket: "menus", AccessKey: "a", SecretKey: "b"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := store.URL("items/i1/original.jpg"); got != "http://localhost:9000/menus/items/i1/original.jpg" {
+		t.Errorf("unexpected URL %q", got)
+	}
+
+	store, err = NewS3Storage(S3Options{Endpoint: "s3.amazonaws.com", Bucket: "menus", UseSSL: true, PublicURL: "https://cdn.example.com/"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := store.URL("items/i1/original.jpg"); got != "https://cdn.example.com/items/i1/original.jpg" {
+		t.Errorf("unexpected URL %q", got)
+	}
+
+	if _, err := NewS3Storage(S3Options{Endpoint: "localhost:9000"}); err == nil {
+		t.Error("expected error without a bucket")
+	}
+}
@@ -0,0 +1,35 @@
+// Package storage stores uploaded files such as menu item images.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+// Storage saves objects under slash-separated keys and tells where clients
+// can download them. Keys are chosen by the caller and are never reused, so
+// implementations may let clients cache objects forever.
+type Storage interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL returns the public address of the object stored under key.
+	URL(key string) string
+}
+
+// CacheControl is sent with stored objects.
+const CacheControl = "public, max-age=31536000, immutable"
+
+// validateKey rejects keys that are empty, absolute or would escape the
+// storage root once joined to it.
+func validateKey(key string) error {
+	if key == "" {
+		return errors.New("storage key is required")
+	}
+	if strings.HasPrefix(key, "/") || strings.Contains(key, "\\") || path.Clean(key) != key || key == ".." || strings.HasPrefix(key, "../") {
+		return errors.New("storage key must be a clean relative path")
+	}
+	return nil
+}
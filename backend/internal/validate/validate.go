@@ -0,0 +1,142 @@
+// Package validate checks the fields of request bodies. A Validator collects
+// every invalid field instead of stopping at the first, so that a client can
+// fix them all in one round trip:
+//
+//	var v validate.Validator
+//	v.Name("name", &req.Name, validate.MaxNameLength)
+//	v.Text("description", &req.Description, validate.MaxTextLength)
+//	if err := v.Err(); err != nil {
+//		return nil, err
+//	}
+package validate
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+)
+
+// Common length limits, in characters.
+const (
+	// MaxNameLength bounds names and titles shown as headings.
+	MaxNameLength = 200
+	// MaxTextLength bounds free text such as descriptions.
+	MaxTextLength = 2000
+	// MaxURLLength bounds URLs stored on a resource.
+	MaxURLLength = 2048
+)
+
+// Validator collects invalid fields. The zero value is ready to use.
+type Validator struct {
+	fields []apperr.FieldError
+}
+
+// Add records field as invalid with message.
+func (v *Validator) Add(field, message string) {
+	v.fields = append(v.fields, apperr.FieldError{Field: field, Message: message})
+}
+
+// Addf is Add with a formatted message.
+func (v *Validator) Addf(field, format string, args ...any) {
+	v.Add(field, fmt.Sprintf(format, args...))
+}
+
+// Check records field as invalid with message unless ok.
+func (v *Validator) Check(ok bool, field, message string) {
+	if !ok {
+		v.Add(field, message)
+	}
+}
+
+// Merge records err, returned by a nested validation such as Money.Validate,
+// against field. A field named by err is nested under field, e.g.
+// "price.currency". A nil err is ignored.
+func (v *Validator) Merge(field string, err error) {
+	if err == nil {
+		return
+	}
+	nested := apperr.FieldsOf(err)
+	if len(nested) == 0 {
+		v.Add(field, err.Error())
+		return
+	}
+	for _, f := range nested {
+		name := field
+		if f.Field != "" && f.Field != field {
+			name = field + "." + f.Field
+		}
+		v.Add(name, f.Message)
+	}
+}
+
+// Valid reports whether no field has been recorded as invalid.
+func (v *Validator) Valid() bool {
+	return len(v.fields) == 0
+}
+
+// Err returns an apperr.ErrInvalidFields error listing the invalid fields,
+// or nil when there are none.
+func (v *Validator) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	return apperr.InvalidFields(v.fields)
+}
+
+// Name trims surrounding whitespace from *value and checks that what remains
+// is a non-empty single line of at most max characters.
+func (v *Validator) Name(field string, value *string, max int) {
+	*value = strings.TrimSpace(*value)
+	switch {
+	case *value == "":
+		v.Add(field, field+" is required")
+	case !v.length(field, *value, max):
+	case strings.ContainsFunc(*value, unicode.IsControl):
+		v.Add(field, field+" must be a single line without control characters")
+	}
+}
+
+// Text trims surrounding whitespace from *value and checks that what remains
+// is at most max characters without control characters other than line
+// breaks and tabs. Empty text is valid.
+func (v *Validator) Text(field string, value *string, max int) {
+	*value = strings.TrimSpace(*value)
+	if !v.length(field, *value, max) {
+		return
+	}
+	if strings.ContainsFunc(*value, isDisallowedControl) {
+		v.Add(field, field+" must not contain control characters")
+	}
+}
+
+// URL trims surrounding whitespace from *value and checks that what remains
+// is empty or an absolute http or https URL of at most MaxURLLength
+// characters.
+func (v *Validator) URL(field string, value *string) {
+	*value = strings.TrimSpace(*value)
+	if *value == "" || !v.length(field, *value, MaxURLLength) {
+		return
+	}
+	parsed, err := url.Parse(*value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		v.Add(field, field+" must be an absolute http or https URL")
+	}
+}
+
+// length records field as too long unless value has at most max characters,
+// and reports whether it has.
+func (v *Validator) length(field, value string, max int) bool {
+	if utf8.RuneCountInString(value) > max {
+		v.Addf(field, "%s must be at most %d characters", field, max)
+		return false
+	}
+	return true
+}
+
+func isDisallowedControl(r rune) bool {
+	return unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t'
+}
@@ -0,0 +1,63 @@
+package validate
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/custard-technology/abakcus/backend/internal/apperr"
+)
+
+func TestValidatorCollectsEveryField(t *testing.T) {
+	name := "  Lunch  "
+	title := "   "
+	heading := "Two\nlines"
+	description := "Fresh\n\tdaily\x00"
+	long := strings.Repeat("é", MaxNameLength+1)
+	logo := "ftp://example.com/logo.png"
+
+	var v Validator
+	v.Name("name", &name, MaxNameLength)
+	v.Name("title", &title, MaxNameLength)
+	v.Name("heading", &heading, MaxNameLength)
+	v.Text("description", &description, MaxTextLength)
+	v.Name("long", &long, MaxNameLength)
+	v.URL("logo_url", &logo)
+	v.Merge("price", apperr.Required("currency"))
+
+	if name != "Lunch" {
+		t.Errorf("expected the name to be trimmed, got %q", name)
+	}
+
+	err := v.Err()
+	if !errors.Is(err, apperr.ErrInvalidFields) {
+		t.Fatalf("expected invalid fields, got %v", err)
+	}
+	var got []string
+	for _, f := range apperr.FieldsOf(err) {
+		got = append(got, f.Field)
+	}
+	want := "title heading description long logo_url price.currency"
+	if strings.Join(got, " ") != want {
+		t.Errorf("expected fields %s, got %v", want, got)
+	}
+}
+
+func TestValidatorAcceptsValidInput(t *testing.T) {
+	name := strings.Repeat("é", MaxNameLength)
+	description := "Fresh\n\tdaily"
+	logo := "https://example.com/logo.png"
+	empty := ""
+
+	var v Validator
+	v.Name("name", &name, MaxNameLength)
+	v.Text("description", &description, MaxTextLength)
+	v.Text("notes", &empty, MaxTextLength)
+	v.URL("logo_url", &logo)
+	v.URL("image_url", &empty)
+	v.Merge("schedule", nil)
+
+	if err := v.Err(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
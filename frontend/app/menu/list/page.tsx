@@ -1,6 +1,7 @@
 'use client'
 
 import { Navbar } from '@/components/navbar'
+import { AuthGate } from '@/components/auth-gate'
 import { AppAlert } from '@/components/app-alert'
 import { MenuList } from '@/components/menu-list'
 import { MenuForm } from '@/components/menu-form'
@@ -15,9 +16,11 @@ export default function Home() {
       <Navbar />
       <main className="mx-auto w-full max-w-[800px] flex-1 px-6 py-8">
         <AppAlert />
-        <div className="mt-2">
-          <MenuList />
-        </div>
+        <AuthGate>
+          <div className="mt-2">
+            <MenuList />
+          </div>
+        </AuthGate>
       </main>
       <footer className="border-t border-border bg-card py-4 text-center text-xs text-muted-foreground">
         Menu Manager &middot; made by MINDSGN STUDIO (PTY) LTD
@@ -0,0 +1,28 @@
+'use client'
+
+import { useEffect, useState } from 'react'
+import { LoginForm } from '@/components/login-form'
+import { useMenuStore } from '@/lib/store'
+
+/** Renders children for a signed-in user and the login screen otherwise */
+export function AuthGate({ children }: { children: React.ReactNode }) {
+  const token = useMenuStore((s) => s.token)
+  const restoreSession = useMenuStore((s) => s.restoreSession)
+  // the token lives in localStorage, which only exists after hydration
+  const [restored, setRestored] = useState(false)
+
+  useEffect(() => {
+    restoreSession()
+    setRestored(true)
+  }, [restoreSession])
+
+  if (!restored) return null
+  if (!token) {
+    return (
+      <div className="mt-2">
+        <LoginForm />
+      </div>
+    )
+  }
+  return <>{children}</>
+}
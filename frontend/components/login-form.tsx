@@ -0,0 +1,103 @@
+'use client'
+
+import { useState } from 'react'
+import { login, register } from '@/lib/api'
+import { useMenuStore } from '@/lib/store'
+import { Button } from '@/components/ui/button'
+import { Input } from '@/components/ui/input'
+import { Label } from '@/components/ui/label'
+import { Spinner } from '@/components/ui/spinner'
+
+export function LoginForm() {
+  const signIn = useMenuStore((s) => s.signIn)
+  const showAlert = useMenuStore((s) => s.showAlert)
+
+  const [mode, setMode] = useState<'login' | 'register'>('login')
+  const [email, setEmail] = useState('')
+  const [password, setPassword] = useState('')
+  const [submitting, setSubmitting] = useState(false)
+
+  const isLogin = mode === 'login'
+
+  async function handleSubmit(e: React.FormEvent) {
+    e.preventDefault()
+    setSubmitting(true)
+    try {
+      const res = isLogin
+        ? await login(email, password)
+        : await register(email, password)
+      signIn(res.access_token)
+    } catch {
+      showAlert(
+        'error',
+        isLogin
+          ? 'Invalid email or password.'
+          : 'Could not create the account. Use a valid email and a password of at least 8 characters.',
+      )
+    } finally {
+      setSubmitting(false)
+    }
+  }
+
+  return (
+    <div className="flex flex-col gap-6">
+      <h2 className="font-[family-name:var(--font-heading)] text-2xl font-bold text-foreground">
+        {isLogin ? 'Sign In' : 'Create Account'}
+      </h2>
+
+      <form
+        onSubmit={handleSubmit}
+        className="flex flex-col gap-5 rounded-lg border border-border bg-card p-6 shadow-sm"
+      >
+        <div className="flex flex-col gap-1.5">
+          <Label htmlFor="login-email" className="text-sm font-semibold text-foreground">
+            Email
+          </Label>
+          <Input
+            id="login-email"
+            type="email"
+            autoComplete="email"
+            required
+            value={email}
+            onChange={(e) => setEmail(e.target.value)}
+            className="h-10 border-input bg-card text-foreground focus-visible:border-secondary focus-visible:ring-secondary/30"
+          />
+        </div>
+
+        <div className="flex flex-col gap-1.5">
+          <Label htmlFor="login-password" className="text-sm font-semibold text-foreground">
+            Password
+          </Label>
+          <Input
+            id="login-password"
+            type="password"
+            autoComplete={isLogin ? 'current-password' : 'new-password'}
+            required
+            value={password}
+            onChange={(e) => setPassword(e.target.value)}
+            className="h-10 border-input bg-card text-foreground focus-visible:border-secondary focus-visible:ring-secondary/30"
+          />
+        </div>
+
+        <div className="flex items-center gap-3 pt-2">
+          <Button
+            type="submit"
+            disabled={submitting}
+            className="bg-primary text-primary-foreground hover:bg-primary/90"
+          >
+            {submitting && <Spinner className="size-4" />}
+            {isLogin ? 'Sign In' : 'Create Account'}
+          </Button>
+          <Button
+            type="button"
+            variant="ghost"
+            onClick={() => setMode(isLogin ? 'register' : 'login')}
+            className="text-foreground hover:bg-muted"
+          >
+            {isLogin ? 'New here? Create an account' : 'Have an account? Sign in'}
+          </Button>
+        </div>
+      </form>
+    </div>
+  )
+}
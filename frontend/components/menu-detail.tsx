@@ -2,7 +2,7 @@
 
 import { useEffect, useState } from 'react'
 import { ArrowLeft, Pencil, Trash2, Download } from 'lucide-react'
-import { getMenu, deleteMenu, listMenus } from '@/lib/api'
+import { deleteErrorMessage, getMenu, deleteMenu, listMenus } from '@/lib/api'
 import { useMenuStore } from '@/lib/store'
 import { Button } from '@/components/ui/button'
 import { Badge } from '@/components/ui/badge'
@@ -48,13 +48,13 @@ export function MenuDetail({ menuId }: MenuDetailProps) {
     if (!window.confirm(`Delete "${menu.name}"? This cannot be undone.`)) return
     setDeleting(true)
     try {
-      await deleteMenu(menuId)
+      await deleteMenu(menu)
       const menus = await listMenus()
       setMenus(menus)
       showAlert('success', `"${menu.name}" was deleted.`)
       navigate({ kind: 'list' })
-    } catch {
-      showAlert('error', `Failed to delete "${menu.name}".`)
+    } catch (err) {
+      showAlert('error', deleteErrorMessage(err, menu.name))
       setDeleting(false)
     }
   }
@@ -149,7 +149,7 @@ export function MenuDetail({ menuId }: MenuDetailProps) {
               <div className="flex gap-2">
                 <dt className="font-medium text-foreground">ID:</dt>
                 <dd className="truncate font-mono text-xs text-muted-foreground/70">
-                  {menu.id}
+                  {menu.menu_id}
                 </dd>
               </div>
             </dl>
@@ -177,7 +177,7 @@ export function MenuDetail({ menuId }: MenuDetailProps) {
       {/* Actions */}
       <div className="flex items-center gap-3">
         <Button
-          onClick={() => navigate({ kind: 'edit', menuId: menu.id })}
+          onClick={() => navigate({ kind: 'edit', menuId: menu.menu_id })}
           className="bg-secondary text-secondary-foreground hover:bg-secondary/90"
         >
           <Pencil className="size-4" />
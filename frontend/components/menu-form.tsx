@@ -2,8 +2,8 @@
 
 import { useEffect, useState } from 'react'
 import { ArrowLeft } from 'lucide-react'
-import { menuFormSchema, type MenuFormValues } from '@/lib/types'
-import { createMenu, getMenu, updateMenu, listMenus } from '@/lib/api'
+import { menuFormSchema, type Menu, type MenuFormValues } from '@/lib/types'
+import { ApiError, createMenu, getMenu, updateMenu, listMenus } from '@/lib/api'
 import { useMenuStore } from '@/lib/store'
 import { Button } from '@/components/ui/button'
 import { Input } from '@/components/ui/input'
@@ -23,6 +23,8 @@ export function MenuForm({ menuId }: MenuFormProps) {
 
   const isEdit = !!menuId
 
+  // the menu as loaded; updates send its revision and keep its slug
+  const [loaded, setLoaded] = useState<Menu | null>(null)
   const [name, setName] = useState('')
   const [description, setDescription] = useState('')
   const [isActive, setIsActive] = useState(true)
@@ -37,6 +39,7 @@ export function MenuForm({ menuId }: MenuFormProps) {
       setLoadingMenu(true)
       try {
         const menu = await getMenu(menuId!)
+        setLoaded(menu)
         setName(menu.name)
         setDescription(menu.description || '')
         setIsActive(menu.is_active)
@@ -72,8 +75,8 @@ export function MenuForm({ menuId }: MenuFormProps) {
         description: parsed.data.description,
         is_active: parsed.data.is_active,
       }
-      if (isEdit && menuId) {
-        await updateMenu(menuId, payload)
+      if (isEdit && loaded) {
+        await updateMenu(loaded, payload)
         showAlert('success', `"${name}" updated successfully.`)
       } else {
         await createMenu(payload)
@@ -83,8 +86,12 @@ export function MenuForm({ menuId }: MenuFormProps) {
       const menus = await listMenus()
       setMenus(menus)
       navigate({ kind: 'list' })
-    } catch {
-      showAlert('error', `Failed to ${isEdit ? 'update' : 'create'} menu.`)
+    } catch (err) {
+      if (err instanceof ApiError && err.status === 412) {
+        showAlert('error', 'This menu was changed elsewhere. Reload it and try again.')
+      } else {
+        showAlert('error', `Failed to ${isEdit ? 'update' : 'create'} menu.`)
+      }
     } finally {
       setSubmitting(false)
     }
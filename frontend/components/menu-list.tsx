@@ -3,7 +3,7 @@
 
 import { useEffect } from 'react'
 import { Plus, Eye, Pencil, Trash2, QrCode } from 'lucide-react'
-import { listMenus, deleteMenu } from '@/lib/api'
+import { deleteErrorMessage, listMenus, deleteMenu } from '@/lib/api'
 import { useMenuStore } from '@/lib/store'
 import { Button } from '@/components/ui/button'
 import { Badge } from '@/components/ui/badge'
@@ -41,13 +41,13 @@ export function MenuList() {
 
   async function handleDelete(menu: Menu) {
     if (!window.confirm(`Delete "${menu.name}"? This cannot be undone.`)) return
-    setDeletingId(menu.id)
+    setDeletingId(menu.menu_id)
     try {
-      await deleteMenu(menu.id)
-      setMenus(menus.filter((m) => m.id !== menu.id))
+      await deleteMenu(menu)
+      setMenus(menus.filter((m) => m.menu_id !== menu.menu_id))
       showAlert('success', `"${menu.name}" was deleted.`)
-    } catch {
-      showAlert('error', `Failed to delete "${menu.name}".`)
+    } catch (err) {
+      showAlert('error', deleteErrorMessage(err, menu.name))
     } finally {
       setDeletingId(null)
     }
@@ -119,7 +119,7 @@ export function MenuList() {
       <div className="flex flex-col gap-4">
         {menus.map((menu) => (
           <div
-            key={menu.id}
+            key={menu.menu_id}
             className="group rounded-lg border border-border bg-card p-5 shadow-sm transition-shadow hover:shadow-md"
           >
             <div className="flex items-start justify-between gap-4">
@@ -153,7 +153,7 @@ export function MenuList() {
                   variant="ghost"
                   size="icon-sm"
                   onClick={() => {
-                    navigate({ kind: 'detail', menuId: menu.id })
+                    navigate({ kind: 'detail', menuId: menu.menu_id })
                   }}
                   aria-label={`View ${menu.name}`}
                 >
@@ -162,7 +162,7 @@ export function MenuList() {
                 <Button
                   variant="ghost"
                   size="icon-sm"
-                  onClick={() => navigate({ kind: 'edit', menuId: menu.id })}
+                  onClick={() => navigate({ kind: 'edit', menuId: menu.menu_id })}
                   aria-label={`Edit ${menu.name}`}
                 >
                   <Pencil className="size-4" />
@@ -171,11 +171,11 @@ export function MenuList() {
                   variant="ghost"
                   size="icon-sm"
                   onClick={() =>
-                    setQrOpenId(qrOpenId === menu.id ? null : menu.id)
+                    setQrOpenId(qrOpenId === menu.menu_id ? null : menu.menu_id)
                   }
                   aria-label={`Show QR code for ${menu.name}`}
                   className={cn(
-                    qrOpenId === menu.id && 'bg-secondary/10 text-secondary',
+                    qrOpenId === menu.menu_id && 'bg-secondary/10 text-secondary',
                   )}
                 >
                   <QrCode className="size-4" />
@@ -183,12 +183,12 @@ export function MenuList() {
                 <Button
                   variant="ghost"
                   size="icon-sm"
-                  disabled={deletingId === menu.id}
+                  disabled={deletingId === menu.menu_id}
                   onClick={() => handleDelete(menu)}
                   aria-label={`Delete ${menu.name}`}
                   className="text-destructive hover:bg-destructive/10 hover:text-destructive"
                 >
-                  {deletingId === menu.id ? (
+                  {deletingId === menu.menu_id ? (
                     <Spinner className="size-4" />
                   ) : (
                     <Trash2 className="size-4" />
@@ -197,9 +197,9 @@ export function MenuList() {
               </div>
             </div>
             {/* QR code panel */}
-            {qrOpenId === menu.id && (
+            {qrOpenId === menu.menu_id && (
               <div className="mt-4 flex items-center justify-center rounded-md border border-border bg-muted/50 py-5 animate-in fade-in slide-in-from-top-1">
-                <QRCode value={menuUrl(menu.id)} size={140} />
+                <QRCode value={menuUrl(menu.menu_id)} size={140} />
               </div>
             )}
           </div>
@@ -1,10 +1,13 @@
 'use client'
 
-import { UtensilsCrossed } from 'lucide-react'
+import { LogOut, UtensilsCrossed } from 'lucide-react'
 import { useMenuStore } from '@/lib/store'
+import { Button } from '@/components/ui/button'
 
 export function Navbar() {
   const navigate = useMenuStore((s) => s.navigate)
+  const token = useMenuStore((s) => s.token)
+  const signOut = useMenuStore((s) => s.signOut)
 
   return (
     <header className="sticky top-0 z-50 border-b border-border bg-card">
@@ -21,6 +24,17 @@ export function Navbar() {
             Menus
           </h1>
         </button>
+        {token && (
+          <Button
+            variant="ghost"
+            size="sm"
+            onClick={signOut}
+            className="ml-auto text-foreground hover:bg-muted"
+          >
+            <LogOut className="size-4" />
+            Sign out
+          </Button>
+        )}
       </div>
     </header>
   )
@@ -1,33 +1,87 @@
-import type { Menu, MenuFormValues } from './types'
+import type { Menu, MenuFormValues, TokenResponse } from './types'
+import { loadToken } from './session'
+import { useMenuStore } from './store'
 
-const BASE_URL = 'https://abakcus.onrender.com'
-const BUSINESS_ID = 'business-123'
+const BASE_URL =
+  process.env.NEXT_PUBLIC_API_URL ?? 'https://abakcus.onrender.com'
 
-async function request<T>(
-  path: string,
-  options: RequestInit = {},
-): Promise<T> {
+/** Error thrown for non-2xx responses; status lets callers tell 412 apart */
+export class ApiError extends Error {
+  constructor(
+    readonly status: number,
+    message: string,
+  ) {
+    super(message)
+  }
+}
+
+async function send(path: string, options: RequestInit = {}): Promise<Response> {
+  const token = loadToken()
   const res = await fetch(`${BASE_URL}${path}`, {
     ...options,
     headers: {
       'Content-Type': 'application/json',
-      'X-Business-ID': BUSINESS_ID,
+      ...(token ? { Authorization: `Bearer ${token}` } : {}),
       ...options.headers,
     },
   })
 
   if (!res.ok) {
+    // an expired or revoked token sends the user back to the login screen
+    if (res.status === 401 && token) useMenuStore.getState().signOut()
     const text = await res.text().catch(() => 'Unknown error')
-    throw new Error(`API ${res.status}: ${text}`)
+    throw new ApiError(res.status, `API ${res.status}: ${text}`)
   }
 
+  return res
+}
+
+async function request<T>(
+  path: string,
+  options: RequestInit = {},
+): Promise<T> {
+  const res = await send(path, options)
   if (res.status === 204) return undefined as T
 
   return res.json() as Promise<T>
 }
 
+/** The If-Match value naming the revision of menu the client last read */
+function ifMatch(menu: Menu): HeadersInit {
+  return { 'If-Match': `"${menu.revision}"` }
+}
+
+export async function login(
+  email: string,
+  password: string,
+): Promise<TokenResponse> {
+  return request<TokenResponse>('/auth/login', {
+    method: 'POST',
+    body: JSON.stringify({ email, password }),
+  })
+}
+
+export async function register(
+  email: string,
+  password: string,
+): Promise<TokenResponse> {
+  return request<TokenResponse>('/auth/register', {
+    method: 'POST',
+    body: JSON.stringify({ email, password }),
+  })
+}
+
+/** Lists every menu, following the X-Next-Cursor header across pages */
 export async function listMenus(): Promise<Menu[]> {
-  return request<Menu[]>('/menus')
+  const menus: Menu[] = []
+  let cursor: string | null = null
+  do {
+    const query: string = cursor ? `?cursor=${encodeURIComponent(cursor)}` : ''
+    const res = await send(`/menus${query}`)
+    menus.push(...((await res.json()) as Menu[]))
+    cursor = res.headers.get('X-Next-Cursor')
+  } while (cursor)
+  return menus
 }
 
 export async function getMenu(id: string): Promise<Menu> {
@@ -35,22 +89,46 @@ export async function getMenu(id: string): Promise<Menu> {
 }
 
 export async function createMenu(data: MenuFormValues): Promise<Menu> {
+  // new menus start active; the API rejects is_active on create
   return request<Menu>('/menus', {
     method: 'POST',
-    body: JSON.stringify(data),
+    body: JSON.stringify({ name: data.name, description: data.description ?? '' }),
   })
 }
 
+/**
+ * Replaces the editable fields of menu with data. PUT is a full replacement,
+ * so the slug and schedule the form does not edit are sent back unchanged.
+ * Fails with a 412 ApiError when the menu changed since it was read.
+ */
 export async function updateMenu(
-  id: string,
+  menu: Menu,
   data: MenuFormValues,
 ): Promise<Menu> {
-  return request<Menu>(`/menus/${id}`, {
+  return request<Menu>(`/menus/${menu.menu_id}`, {
     method: 'PUT',
-    body: JSON.stringify(data),
+    headers: ifMatch(menu),
+    body: JSON.stringify({
+      name: data.name,
+      slug: menu.slug,
+      description: data.description ?? '',
+      is_active: data.is_active ?? menu.is_active,
+      schedule: menu.schedule,
+    }),
+  })
+}
+
+export async function deleteMenu(menu: Menu): Promise<void> {
+  return request<void>(`/menus/${menu.menu_id}`, {
+    method: 'DELETE',
+    headers: ifMatch(menu),
   })
 }
 
-export async function deleteMenu(id: string): Promise<void> {
-  return request<void>(`/menus/${id}`, { method: 'DELETE' })
+/** Explains a failed delete, telling a concurrent edit apart */
+export function deleteErrorMessage(err: unknown, name: string): string {
+  if (err instanceof ApiError && err.status === 412) {
+    return `"${name}" was changed elsewhere. Reload and try again.`
+  }
+  return `Failed to delete "${name}".`
 }
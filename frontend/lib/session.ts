@@ -0,0 +1,15 @@
+const TOKEN_KEY = 'abakcus.token'
+
+/** Returns the stored access token, or null when signed out or on the server */
+export function loadToken(): string | null {
+  if (typeof window === 'undefined') return null
+  return window.localStorage.getItem(TOKEN_KEY)
+}
+
+export function saveToken(token: string): void {
+  window.localStorage.setItem(TOKEN_KEY, token)
+}
+
+export function clearToken(): void {
+  window.localStorage.removeItem(TOKEN_KEY)
+}
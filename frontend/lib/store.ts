@@ -1,5 +1,6 @@
 import { create } from 'zustand'
 import type { Menu, View } from './types'
+import { clearToken, loadToken, saveToken } from './session'
 
 interface AlertState {
   type: 'success' | 'error'
@@ -7,6 +8,12 @@ interface AlertState {
 }
 
 interface MenuStore {
+  /* session; null until loaded from storage and while signed out */
+  token: string | null
+  restoreSession: () => void
+  signIn: (token: string) => void
+  signOut: () => void
+
   /* data */
   menus: Menu[]
   setMenus: (menus: Menu[]) => void
@@ -28,6 +35,17 @@ interface MenuStore {
 }
 
 export const useMenuStore = create<MenuStore>((set) => ({
+  token: null,
+  restoreSession: () => set({ token: loadToken() }),
+  signIn: (token) => {
+    saveToken(token)
+    set({ token, view: { kind: 'list' }, alert: null })
+  },
+  signOut: () => {
+    clearToken()
+    set({ token: null, menus: [], selectedMenu: null, view: { kind: 'list' } })
+  },
+
   menus: [],
   setMenus: (menus) => set({ menus }),
   selectedMenu: null,
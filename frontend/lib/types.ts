@@ -11,15 +11,29 @@ export type MenuFormValues = z.infer<typeof menuFormSchema>
 
 /** Shape returned by the API */
 export interface Menu {
-  id: string
+  menu_id: string
   business_id: string
   name: string
+  slug: string
   description: string
   is_active: boolean
+  /** Opaque here; PUT sends it back unchanged so the schedule is kept */
+  schedule?: unknown
+  published_version: number
+  /** Served as the menu's ETag; writes send it back in If-Match */
+  revision: number
   created_at: string
   updated_at: string
 }
 
+/** Returned by /auth/login and /auth/register */
+export interface TokenResponse {
+  access_token: string
+  token_type: string
+  expires_at: string
+  user: { user_id: string; email: string; business_id: string; role: string }
+}
+
 /** Views used by conditional rendering */
 export type View =
   | { kind: 'list' }